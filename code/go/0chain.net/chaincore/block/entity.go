@@ -6,8 +6,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
-	"strconv"
-	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -452,38 +450,7 @@ func (b *Block) GetMerkleTree() *util.MerkleTree {
 }
 
 func (b *Block) getHashData() string {
-	mt := b.GetMerkleTree()
-	merkleRoot := mt.GetRoot()
-	rmt := b.GetReceiptsMerkleTree()
-	rMerkleRoot := rmt.GetRoot()
-
-	hashBuilder := strings.Builder{}
-	hashBuilder.WriteString(b.MinerID)
-	hashBuilder.WriteString(":")
-	hashBuilder.WriteString(b.PrevHash)
-	hashBuilder.WriteString(":")
-	hashBuilder.WriteString(common.TimeToString(b.CreationDate))
-	hashBuilder.WriteString(":")
-	hashBuilder.WriteString(strconv.FormatInt(b.Round, 10))
-	hashBuilder.WriteString(":")
-	hashBuilder.WriteString(strconv.FormatInt(b.GetRoundRandomSeed(), 10))
-	hashBuilder.WriteString(":")
-	hashBuilder.WriteString(strconv.Itoa(b.StateChangesCount))
-	hashBuilder.WriteString(":")
-	hashBuilder.WriteString(merkleRoot)
-	hashBuilder.WriteString(":")
-	hashBuilder.WriteString(rMerkleRoot)
-
-	if b.MagicBlock != nil {
-		if b.MagicBlock.Hash == "" {
-			b.MagicBlock.Hash = b.MagicBlock.GetHash()
-		}
-
-		hashBuilder.WriteString(":")
-		hashBuilder.WriteString(b.MagicBlock.Hash)
-	}
-
-	return hashBuilder.String()
+	return b.GetHeader().hashData()
 }
 
 /*ComputeHash - compute the hash of the block */
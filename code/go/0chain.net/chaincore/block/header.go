@@ -0,0 +1,78 @@
+package block
+
+import (
+	"strconv"
+	"strings"
+
+	"0chain.net/core/common"
+	"0chain.net/core/encryption"
+)
+
+// Header is the part of a block the block hash is computed from. It allows
+// verifying the hash, and so the signature of the generator, without the
+// transactions of the block.
+type Header struct {
+	MinerID               string           `json:"miner_id"`
+	PrevHash              string           `json:"prev_hash"`
+	CreationDate          common.Timestamp `json:"creation_date"`
+	Round                 int64            `json:"round"`
+	RoundRandomSeed       int64            `json:"round_random_seed"`
+	StateChangesCount     int              `json:"state_changes_count"`
+	MerkleTreeRoot        string           `json:"merkle_tree_root"`
+	ReceiptMerkleTreeRoot string           `json:"receipt_merkle_tree_root"`
+	MagicBlockHash        string           `json:"magic_block_hash,omitempty"`
+}
+
+func (h *Header) hashData() string {
+	hashBuilder := strings.Builder{}
+	hashBuilder.WriteString(h.MinerID)
+	hashBuilder.WriteString(":")
+	hashBuilder.WriteString(h.PrevHash)
+	hashBuilder.WriteString(":")
+	hashBuilder.WriteString(common.TimeToString(h.CreationDate))
+	hashBuilder.WriteString(":")
+	hashBuilder.WriteString(strconv.FormatInt(h.Round, 10))
+	hashBuilder.WriteString(":")
+	hashBuilder.WriteString(strconv.FormatInt(h.RoundRandomSeed, 10))
+	hashBuilder.WriteString(":")
+	hashBuilder.WriteString(strconv.Itoa(h.StateChangesCount))
+	hashBuilder.WriteString(":")
+	hashBuilder.WriteString(h.MerkleTreeRoot)
+	hashBuilder.WriteString(":")
+	hashBuilder.WriteString(h.ReceiptMerkleTreeRoot)
+
+	if h.MagicBlockHash != "" {
+		hashBuilder.WriteString(":")
+		hashBuilder.WriteString(h.MagicBlockHash)
+	}
+
+	return hashBuilder.String()
+}
+
+// Hash returns the hash of the block the header belongs to.
+func (h *Header) Hash() string {
+	return encryption.Hash(h.hashData())
+}
+
+// GetHeader returns the header of the block.
+func (b *Block) GetHeader() *Header {
+	h := &Header{
+		MinerID:               b.MinerID,
+		PrevHash:              b.PrevHash,
+		CreationDate:          b.CreationDate,
+		Round:                 b.Round,
+		RoundRandomSeed:       b.GetRoundRandomSeed(),
+		StateChangesCount:     b.StateChangesCount,
+		MerkleTreeRoot:        b.GetMerkleTree().GetRoot(),
+		ReceiptMerkleTreeRoot: b.GetReceiptsMerkleTree().GetRoot(),
+	}
+
+	if b.MagicBlock != nil {
+		if b.MagicBlock.Hash == "" {
+			b.MagicBlock.Hash = b.MagicBlock.GetHash()
+		}
+		h.MagicBlockHash = b.MagicBlock.Hash
+	}
+
+	return h
+}
@@ -18,6 +18,7 @@ import (
 	"0chain.net/core/cache"
 	"0chain.net/core/config"
 	"0chain.net/core/util/orderbuffer"
+	"0chain.net/smartcontract/rest"
 	"0chain.net/smartcontract/stakepool"
 	"0chain.net/smartcontract/stakepool/spenum"
 	"github.com/0chain/common/core/currency"
@@ -2208,6 +2209,7 @@ func (c *Chain) SetLatestFinalizedBlock(b *block.Block) {
 	c.BroadcastLFBTicket(context.Background(), b)
 	go c.notifyToSyncFinalizedRoundState(bs)
 	c.lfbMutex.Unlock()
+	rest.InvalidateResponseCache(b.Round)
 
 	if b.Round > 0 {
 		// do not store genesis block, otherwise it would re-write the LFB to 0 round every time
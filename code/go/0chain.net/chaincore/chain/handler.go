@@ -1478,7 +1478,19 @@ func PutTransaction(ctx context.Context, entity datastore.Entity) (interface{},
 			return nil, err
 		}
 
-		if nonce+1 == txn.Nonce && s.Balance < txn.Fee {
+		if txn.IsSponsored() {
+			fps, err := GetStateById(lfb.ClientState, txn.FeePayerID)
+			if cstate.ErrInvalidState(err) {
+				return nil, common.NewErrInternal("miner state not ready")
+			}
+			if fps == nil || fps.Balance < txn.Fee {
+				logging.Logger.Error("insufficient fee payer balance",
+					zap.String("txn", txn.Hash),
+					zap.String("fee_payer", txn.FeePayerID),
+					zap.Any("fee", txn.Fee))
+				return nil, errors.New("insufficient fee payer balance to pay fee")
+			}
+		} else if nonce+1 == txn.Nonce && s.Balance < txn.Fee {
 			logging.Logger.Error("insufficient balance",
 				zap.String("txn", txn.Hash),
 				zap.String("client_id", txn.ClientID),
@@ -0,0 +1,219 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"testing"
+
+	"0chain.net/chaincore/block"
+	bcstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/smartcontract"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/statecache"
+	"github.com/0chain/common/core/util"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	multiCallTestAddress = encryption.Hash("multi-call test smart contract")
+	multiCallTestClient  = encryption.Hash("multi-call test client")
+)
+
+// multiCallTestSC pays the value of the call to itself, records the call in
+// the state, or fails.
+type multiCallTestSC struct{}
+
+func multiCallTestKey(txnHash string) string {
+	return multiCallTestAddress + ":call:" + txnHash
+}
+
+func (multiCallTestSC) Execute(t *transaction.Transaction, funcName string, _ []byte,
+	balances bcstate.StateContextI) (string, error) {
+	switch funcName {
+	case "pay":
+		if err := balances.AddTransfer(state.NewTransfer(t.ClientID, t.ToClientID, t.Value)); err != nil {
+			return "", err
+		}
+	case "record":
+		if _, err := balances.InsertTrieNode(multiCallTestKey(t.Hash), &state.State{Balance: 1}); err != nil {
+			return "", err
+		}
+	default:
+		return "", errors.New("call failed")
+	}
+	return t.Hash, nil
+}
+
+func (multiCallTestSC) GetHandlerStats(context.Context, url.Values) (interface{}, error) {
+	return nil, nil
+}
+
+func (multiCallTestSC) GetExecutionStats() map[string]interface{} {
+	return map[string]interface{}{}
+}
+
+func (multiCallTestSC) GetName() string { return "multicall_test" }
+
+func (multiCallTestSC) GetAddress() string { return multiCallTestAddress }
+
+func (multiCallTestSC) GetCostTable(bcstate.StateContextI) (map[string]int, error) {
+	return map[string]int{"pay": 100, "record": 200, "fail": 50, "pay_and_record": 300}, nil
+}
+
+func init() {
+	smartcontract.ContractMap[multiCallTestAddress] = multiCallTestSC{}
+}
+
+// multiCallTestState returns the state with the balance of the test client
+// and the hermes hard fork in the given round.
+func multiCallTestState(t *testing.T, ch *Chain, b *block.Block, hermesRound int64) util.MerklePatriciaTrieI {
+	base := util.NewMerklePatriciaTrie(util.NewMemoryNodeDB(), 1, nil, statecache.NewEmpty())
+	sctx := ch.NewStateContext(b, base, &transaction.Transaction{}, nil)
+	st := &state.State{Balance: 100}
+	require.NoError(t, st.SetTxnHash(encryption.Hash("multi-call state")))
+	_, err := sctx.SetClientState(multiCallTestClient, st)
+	require.NoError(t, err)
+	hf := bcstate.NewHardFork("hermes", hermesRound)
+	_, err = sctx.InsertTrieNode(hf.GetKey(), hf)
+	require.NoError(t, err)
+	require.NoError(t, base.SaveChanges(context.Background(), base.GetNodeDB(), false))
+
+	return util.NewMerklePatriciaTrie(base.GetNodeDB(), 2, base.GetRoot(), statecache.NewEmpty())
+}
+
+func multiCallTestTxn(t *testing.T, calls ...transaction.MultiCall) *transaction.Transaction {
+	data, err := json.Marshal(&transaction.MultiCallData{Calls: calls})
+	require.NoError(t, err)
+
+	txn := &transaction.Transaction{
+		TransactionType: transaction.TxnTypeMultiCall,
+		TransactionData: string(data),
+		ClientID:        multiCallTestClient,
+		Nonce:           1,
+		SmartContractData: &transaction.SmartContractData{
+			FunctionName: transaction.MultiCallFunctionName,
+		},
+	}
+	txn.Hash = encryption.Hash("multi-call " + txn.TransactionData)
+	for _, call := range calls {
+		txn.Value += call.Value
+	}
+	return txn
+}
+
+func multiCallTestCall(funcName string, value currency.Coin) transaction.MultiCall {
+	return transaction.MultiCall{
+		Address:           multiCallTestAddress,
+		Value:             value,
+		SmartContractData: transaction.SmartContractData{FunctionName: funcName},
+	}
+}
+
+func TestUpdateStateMultiCall(t *testing.T) {
+	ch := NewChainFromConfig()
+	b := block.NewBlock("", 1)
+	b.PrevBlock = block.NewBlock("", 0)
+
+	execute := func(bState util.MerklePatriciaTrieI, txn *transaction.Transaction) {
+		bc := statecache.NewBlockCache(statecache.NewStateCache(), statecache.Block{Round: b.Round})
+		_, err := ch.updateState(context.Background(), b, bState, txn, bc)
+		require.NoError(t, err)
+	}
+	balance := func(bState util.MerklePatriciaTrieI, clientID string) currency.Coin {
+		s, err := GetStateById(bState, clientID)
+		if err == util.ErrValueNotPresent {
+			return 0
+		}
+		require.NoError(t, err)
+		return s.Balance
+	}
+	recorded := func(bState util.MerklePatriciaTrieI, txnHash string) bool {
+		sctx := ch.NewStateContext(b, bState, &transaction.Transaction{}, nil)
+		err := sctx.GetTrieNode(multiCallTestKey(txnHash), &state.State{})
+		if err == util.ErrValueNotPresent {
+			return false
+		}
+		require.NoError(t, err)
+		return true
+	}
+
+	t.Run("outputs of the calls", func(t *testing.T) {
+		bState := multiCallTestState(t, ch, b, 0)
+		txn := multiCallTestTxn(t, multiCallTestCall("pay", 5), multiCallTestCall("record", 0), multiCallTestCall("pay", 7))
+		execute(bState, txn)
+
+		require.Equal(t, transaction.TxnSuccess, txn.Status)
+		var results []transaction.MultiCallResult
+		require.NoError(t, json.Unmarshal([]byte(txn.TransactionOutput), &results))
+		require.Len(t, results, 3)
+		for i, res := range results {
+			require.Equal(t, multiCallTestAddress, res.Address)
+			require.Equal(t, transaction.CallHash(txn.Hash, i), res.Output)
+		}
+		require.Equal(t, "record", results[1].Name)
+
+		require.True(t, recorded(bState, transaction.CallHash(txn.Hash, 1)))
+		require.EqualValues(t, 88, balance(bState, multiCallTestClient))
+		require.EqualValues(t, 12, balance(bState, multiCallTestAddress))
+	})
+
+	t.Run("atomic rollback", func(t *testing.T) {
+		bState := multiCallTestState(t, ch, b, 0)
+		txn := multiCallTestTxn(t, multiCallTestCall("pay", 5), multiCallTestCall("record", 0), multiCallTestCall("fail", 0))
+		execute(bState, txn)
+
+		require.Equal(t, transaction.TxnError, txn.Status)
+		require.Contains(t, txn.TransactionOutput, "call 2 (fail)")
+		require.False(t, recorded(bState, transaction.CallHash(txn.Hash, 1)))
+		require.EqualValues(t, 100, balance(bState, multiCallTestClient))
+		require.EqualValues(t, 0, balance(bState, multiCallTestAddress))
+
+		s, err := GetStateById(bState, multiCallTestClient)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, s.Nonce, "the transaction is in the block")
+	})
+
+	t.Run("before the hard fork", func(t *testing.T) {
+		bState := multiCallTestState(t, ch, b, b.Round+1)
+		txn := multiCallTestTxn(t, multiCallTestCall("record", 0))
+		execute(bState, txn)
+
+		require.Equal(t, transaction.TxnError, txn.Status)
+		require.Contains(t, txn.TransactionOutput, "not enabled")
+		require.False(t, recorded(bState, transaction.CallHash(txn.Hash, 0)))
+	})
+}
+
+func TestEstimateMultiCallCostFee(t *testing.T) {
+	ch := NewChainFromConfig()
+	ch.ChainConfig.(*ConfigImpl).conf.TxnCostFeeCoeff = 1000
+	b := block.NewBlock("", 1)
+	b.ClientState = multiCallTestState(t, ch, b, 0)
+
+	txn := multiCallTestTxn(t, multiCallTestCall("pay", 5), multiCallTestCall("record", 0))
+	cost, fee, err := ch.EstimateTransactionCostFee(context.Background(), b, txn)
+	require.NoError(t, err)
+	require.Equal(t, 300, cost, "the sum of the costs of the calls")
+
+	// the fee of a single call of the same cost
+	single := multiCallTestCall("pay_and_record", 0)
+	data, err := json.Marshal(&single.SmartContractData)
+	require.NoError(t, err)
+	_, singleFee, err := ch.EstimateTransactionCostFee(context.Background(), b, &transaction.Transaction{
+		TransactionType:   transaction.TxnTypeSmartContract,
+		TransactionData:   string(data),
+		ToClientID:        multiCallTestAddress,
+		SmartContractData: &single.SmartContractData,
+	})
+	require.NoError(t, err)
+	require.Equal(t, singleFee, fee)
+
+	want, err := currency.ParseZCN(0.3)
+	require.NoError(t, err)
+	require.Equal(t, want, fee)
+}
@@ -0,0 +1,258 @@
+package chain
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sort"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/smartcontract/dbs/event"
+	"github.com/0chain/common/core/statecache"
+	"github.com/0chain/common/core/util"
+)
+
+// ErrReplayNoPreviousBlock is returned when a block is replayed without the
+// block it builds on.
+var ErrReplayNoPreviousBlock = errors.New("replay block: previous block is required")
+
+// TxnReplay is the outcome of re-executing a single transaction of a block.
+type TxnReplay struct {
+	Hash          string        `json:"hash"`
+	FunctionName  string        `json:"function_name,omitempty"`
+	Status        int           `json:"status"`
+	StoredStatus  int           `json:"stored_status"`
+	Output        string        `json:"output,omitempty"`
+	StoredOutput  string        `json:"stored_output,omitempty"`
+	OutputMatches bool          `json:"output_matches"`
+	Error         string        `json:"error,omitempty"`
+	Events        []event.Event `json:"events,omitempty"`
+	ChangedKeys   []string      `json:"changed_keys,omitempty"`
+	DeletedKeys   []string      `json:"deleted_keys,omitempty"`
+	StateRoot     string        `json:"state_root"`
+}
+
+// StateChangeDiff lists the MPT nodes on which the replayed state changes
+// and the stored block state changes disagree.
+type StateChangeDiff struct {
+	StoredRoot    string   `json:"stored_root"`
+	StoredChanges int      `json:"stored_changes"`
+	ReplayChanges int      `json:"replay_changes"`
+	MissingNodes  []string `json:"missing_nodes,omitempty"`
+	ExtraNodes    []string `json:"extra_nodes,omitempty"`
+	MissingKeys   []string `json:"missing_keys,omitempty"`
+	ExtraKeys     []string `json:"extra_keys,omitempty"`
+}
+
+// BlockReplay is the outcome of re-executing all the transactions of a
+// block on top of the state of its previous block.
+type BlockReplay struct {
+	Round             int64            `json:"round"`
+	Hash              string           `json:"hash"`
+	PrevHash          string           `json:"prev_hash"`
+	PrevStateRoot     string           `json:"prev_state_root"`
+	StoredStateRoot   string           `json:"stored_state_root"`
+	ComputedStateRoot string           `json:"computed_state_root"`
+	StateRootMatches  bool             `json:"state_root_matches"`
+	Txns              []*TxnReplay     `json:"txns"`
+	StateChange       *StateChangeDiff `json:"state_change,omitempty"`
+}
+
+// ReplayBlock re-executes the transactions of the given block on top of the
+// state root of its previous block and reports the per transaction results.
+// Nothing is written to the state db or the global state cache, so it is
+// safe to run against a live node. When a stored block state change is
+// given, the replayed state changes are diffed against it.
+func (c *Chain) ReplayBlock(ctx context.Context, b, pb *block.Block, bsc *block.StateChange) (*BlockReplay, error) {
+	if pb == nil {
+		return nil, ErrReplayNoPreviousBlock
+	}
+
+	// work on a copy that points to the given previous block, leaving the
+	// block and its transactions as they were loaded
+	rb := block.NewBlock(b.ChainID, b.Round)
+	rb.Hash = b.Hash
+	rb.PrevHash = b.PrevHash
+	rb.MinerID = b.MinerID
+	rb.CreationDate = b.CreationDate
+	rb.RoundRandomSeed = b.RoundRandomSeed
+	rb.ClientStateHash = b.ClientStateHash
+	rb.MagicBlock = b.MagicBlock
+	rb.LatestFinalizedMagicBlockHash = b.LatestFinalizedMagicBlockHash
+	rb.LatestFinalizedMagicBlockRound = b.LatestFinalizedMagicBlockRound
+	rb.PrevBlock = pb
+
+	var (
+		bState          = block.CreateState(c.GetStateDB(), b.Round, pb.ClientStateHash)
+		blockStateCache = statecache.NewBlockCache(statecache.NewStateCache(), statecache.Block{
+			Round:    b.Round,
+			Hash:     b.Hash,
+			PrevHash: b.PrevHash,
+		})
+		report = &BlockReplay{
+			Round:           b.Round,
+			Hash:            b.Hash,
+			PrevHash:        b.PrevHash,
+			PrevStateRoot:   util.ToHex(pb.ClientStateHash),
+			StoredStateRoot: util.ToHex(b.ClientStateHash),
+		}
+	)
+
+	for _, txn := range b.Txns {
+		rtxn := txn.Clone()
+		rtxn.Status = 0
+		rtxn.TransactionOutput = ""
+		if rtxn.ClientID == "" {
+			if err := rtxn.ComputeClientID(); err != nil {
+				return nil, err
+			}
+		}
+
+		before := changedLeaves(bState)
+		events, err := c.updateState(ctx, rb, bState, rtxn, blockStateCache)
+		tr := &TxnReplay{
+			Hash:         txn.Hash,
+			FunctionName: txn.FunctionName,
+			Status:       rtxn.Status,
+			StoredStatus: txn.Status,
+			Output:       rtxn.TransactionOutput,
+			StoredOutput: txn.TransactionOutput,
+			Events:       events,
+			StateRoot:    util.ToHex(bState.GetRoot()),
+		}
+		tr.OutputMatches = tr.Output == tr.StoredOutput && tr.Status == tr.StoredStatus
+		if err != nil {
+			tr.Error = err.Error()
+		}
+		tr.ChangedKeys, tr.DeletedKeys = diffLeaves(before, changedLeaves(bState))
+		report.Txns = append(report.Txns, tr)
+
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+	}
+
+	report.ComputedStateRoot = util.ToHex(bState.GetRoot())
+	report.StateRootMatches = bytes.Equal(bState.GetRoot(), b.ClientStateHash)
+	if bsc != nil {
+		report.StateChange = diffStateChange(bState, bsc)
+	}
+	return report, nil
+}
+
+type leafSet struct {
+	changed map[string]string // key -> node hash
+	deleted map[string]struct{}
+}
+
+// changedLeaves collects the keys of the leaf nodes changed in the mpt so far.
+func changedLeaves(mpt util.MerklePatriciaTrieI) leafSet {
+	_, changes, deletes, _ := mpt.GetChanges()
+	ls := leafSet{
+		changed: make(map[string]string, len(changes)),
+		deleted: make(map[string]struct{}),
+	}
+	for _, change := range changes {
+		if ln, ok := change.New.(*util.LeafNode); ok {
+			ls.changed[leafKey(ln)] = ln.GetHash()
+		}
+	}
+	for _, n := range deletes {
+		if ln, ok := n.(*util.LeafNode); ok {
+			key := leafKey(ln)
+			if _, ok := ls.changed[key]; !ok {
+				ls.deleted[key] = struct{}{}
+			}
+		}
+	}
+	return ls
+}
+
+func leafKey(ln *util.LeafNode) string {
+	key := make([]byte, 0, len(ln.Prefix)+len(ln.Path))
+	key = append(key, ln.Prefix...)
+	key = append(key, ln.Path...)
+	return string(key)
+}
+
+// diffLeaves returns the keys changed and deleted between two snapshots.
+func diffLeaves(before, after leafSet) (changed, deleted []string) {
+	for key, hash := range after.changed {
+		if before.changed[key] != hash {
+			changed = append(changed, key)
+		}
+	}
+	for key := range after.deleted {
+		if _, ok := before.deleted[key]; !ok {
+			deleted = append(deleted, key)
+		}
+	}
+	sort.Strings(changed)
+	sort.Strings(deleted)
+	return
+}
+
+func diffStateChange(mpt util.MerklePatriciaTrieI, bsc *block.StateChange) *StateChangeDiff {
+	_, changes, _, _ := mpt.GetChanges()
+	diff := &StateChangeDiff{
+		StoredRoot:    util.ToHex(bsc.Hash),
+		StoredChanges: len(bsc.Nodes),
+		ReplayChanges: len(changes),
+	}
+
+	replayed := make(map[string]util.Node, len(changes))
+	for _, change := range changes {
+		replayed[change.New.GetHash()] = change.New
+	}
+	stored := make(map[string]util.Node, len(bsc.Nodes))
+	for _, n := range bsc.Nodes {
+		stored[n.GetHash()] = n
+	}
+
+	for hash, n := range stored {
+		if _, ok := replayed[hash]; ok {
+			continue
+		}
+		diff.MissingNodes = append(diff.MissingNodes, hash)
+		if ln, ok := n.(*util.LeafNode); ok {
+			diff.MissingKeys = append(diff.MissingKeys, leafKey(ln))
+		}
+	}
+	for hash, n := range replayed {
+		if _, ok := stored[hash]; ok {
+			continue
+		}
+		diff.ExtraNodes = append(diff.ExtraNodes, hash)
+		if ln, ok := n.(*util.LeafNode); ok {
+			diff.ExtraKeys = append(diff.ExtraKeys, leafKey(ln))
+		}
+	}
+	sort.Strings(diff.MissingNodes)
+	sort.Strings(diff.ExtraNodes)
+	sort.Strings(diff.MissingKeys)
+	sort.Strings(diff.ExtraKeys)
+	return diff
+}
+
+// LocalBlockStateChange returns the state changes of the block if it is
+// still held in memory with a computed state, or nil.
+func (c *Chain) LocalBlockStateChange(ctx context.Context, hash string) *block.StateChange {
+	b, err := c.GetBlock(ctx, hash)
+	if err != nil || b.ClientState == nil {
+		return nil
+	}
+	if b.GetStateStatus() != block.StateSuccessful && b.GetStateStatus() != block.StateSynched {
+		return nil
+	}
+	bsc, err := block.NewBlockStateChange(b)
+	if err != nil {
+		return nil
+	}
+	return bsc
+}
+
+// FetchBlockStateChange requests the state changes of the block from the
+// miners of the latest finalized magic block.
+func (c *Chain) FetchBlockStateChange(b *block.Block) (*block.StateChange, error) {
+	return c.getBlockStateChange(b)
+}
@@ -0,0 +1,64 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"0chain.net/chaincore/block"
+	"github.com/0chain/common/core/statecache"
+	"github.com/0chain/common/core/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayChangedKeys(t *testing.T) {
+	base := util.NewMerklePatriciaTrie(util.NewMemoryNodeDB(), 1, nil, statecache.NewEmpty())
+	_, err := base.Insert(util.Path("aaaa01"), &util.SecureSerializableValue{Buffer: []byte("a")})
+	require.NoError(t, err)
+	_, err = base.Insert(util.Path("bbbb01"), &util.SecureSerializableValue{Buffer: []byte("b")})
+	require.NoError(t, err)
+	require.NoError(t, base.SaveChanges(context.Background(), base.GetNodeDB(), false))
+
+	mpt := block.CreateState(base.GetNodeDB(), 2, base.GetRoot())
+	before := changedLeaves(mpt)
+
+	_, err = mpt.Insert(util.Path("aaaa01"), &util.SecureSerializableValue{Buffer: []byte("a2")})
+	require.NoError(t, err)
+	_, err = mpt.Insert(util.Path("cccc01"), &util.SecureSerializableValue{Buffer: []byte("c")})
+	require.NoError(t, err)
+	middle := changedLeaves(mpt)
+
+	changed, deleted := diffLeaves(before, middle)
+	require.Equal(t, []string{"aaaa01", "cccc01"}, changed)
+	require.Empty(t, deleted)
+
+	_, err = mpt.Delete(util.Path("bbbb01"))
+	require.NoError(t, err)
+	changed, deleted = diffLeaves(middle, changedLeaves(mpt))
+	require.Empty(t, changed)
+	require.Equal(t, []string{"bbbb01"}, deleted)
+}
+
+func TestReplayDiffStateChange(t *testing.T) {
+	mpt := util.NewMerklePatriciaTrie(util.NewMemoryNodeDB(), 1, nil, statecache.NewEmpty())
+	_, err := mpt.Insert(util.Path("aaaa01"), &util.SecureSerializableValue{Buffer: []byte("a")})
+	require.NoError(t, err)
+
+	other := util.NewMerklePatriciaTrie(util.NewMemoryNodeDB(), 1, nil, statecache.NewEmpty())
+	_, err = other.Insert(util.Path("bbbb01"), &util.SecureSerializableValue{Buffer: []byte("b")})
+	require.NoError(t, err)
+
+	bsc := &block.StateChange{}
+	var changes []*util.NodeChange
+	bsc.Hash, changes, _, _ = other.GetChanges()
+	for _, c := range changes {
+		bsc.Nodes = append(bsc.Nodes, c.New)
+	}
+
+	diff := diffStateChange(mpt, bsc)
+	require.Equal(t, 1, diff.StoredChanges)
+	require.Equal(t, 1, diff.ReplayChanges)
+	require.Equal(t, []string{"bbbb01"}, diff.MissingKeys)
+	require.Equal(t, []string{"aaaa01"}, diff.ExtraKeys)
+
+	require.Empty(t, diffStateChange(other, bsc).MissingNodes)
+}
@@ -142,7 +142,15 @@ func (c *Chain) ExecuteSmartContract(
 	}
 
 	go func() {
-		output, err := smartcontract.ExecuteSmartContract(txn, balances)
+		var (
+			output string
+			err    error
+		)
+		if txn.TransactionType == transaction.TxnTypeMultiCall {
+			output, err = smartcontract.ExecuteMultiCall(txn, balances)
+		} else {
+			output, err = smartcontract.ExecuteSmartContract(txn, balances)
+		}
 		resultC <- result{output: output, err: err}
 	}()
 	select {
@@ -227,17 +235,23 @@ func (c *Chain) EstimateTransactionCost(ctx context.Context,
 
 	switch txn.TransactionType {
 
-	case transaction.TxnTypeSmartContract:
-		var scData sci.SmartContractTransactionData
-		dataBytes := []byte(txn.TransactionData)
-		err := json.Unmarshal(dataBytes, &scData)
-		if err != nil {
-			logging.Logger.Error("Error while decoding the JSON from transaction",
-				zap.String("input", txn.TransactionData), zap.Error(err))
-			return math.MaxInt32, err
-		}
+	case transaction.TxnTypeSmartContract, transaction.TxnTypeMultiCall:
+		var (
+			cost int
+			err  error
+		)
+		if txn.TransactionType == transaction.TxnTypeMultiCall {
+			cost, err = smartcontract.EstimateMultiCallCost(txn, sctx)
+		} else {
+			var scData sci.SmartContractTransactionData
+			if err := json.Unmarshal([]byte(txn.TransactionData), &scData); err != nil {
+				logging.Logger.Error("Error while decoding the JSON from transaction",
+					zap.String("input", txn.TransactionData), zap.Error(err))
+				return math.MaxInt32, err
+			}
 
-		cost, err := smartcontract.EstimateTransactionCost(txn, scData, sctx)
+			cost, err = smartcontract.EstimateTransactionCost(txn, scData, sctx)
+		}
 		if missingKeys := sctx.GetMissingNodeKeys(); len(missingKeys) > 0 {
 			syncOpts := &SyncReplyC{}
 			for _, opt := range opts {
@@ -441,7 +455,7 @@ func (c *Chain) updateState(ctx context.Context,
 	}
 
 	switch txn.TransactionType {
-	case transaction.TxnTypeSmartContract:
+	case transaction.TxnTypeSmartContract, transaction.TxnTypeMultiCall:
 		t := time.Now()
 		output, err := c.ExecuteSmartContract(ctx, txn, sctx)
 		switch err {
@@ -531,7 +545,11 @@ func (c *Chain) updateState(ctx context.Context,
 			return nil, err
 		}
 
-		if balance < txn.Fee+txn.Value {
+		required := txn.Fee + txn.Value
+		if txn.IsSponsored() {
+			required = txn.Value
+		}
+		if balance < required {
 			return nil, errors.New("insufficient balance to send")
 		}
 
@@ -551,11 +569,12 @@ func (c *Chain) updateState(ctx context.Context,
 	}
 
 	if c.ChainConfig.IsFeeEnabled() {
-		err = sctx.AddTransfer(state.NewTransfer(txn.ClientID, minersc.ADDRESS, txn.Fee))
+		err = sctx.AddTransfer(state.NewTransfer(txn.GetFeePayer(), minersc.ADDRESS, txn.Fee))
 		if err != nil {
 			logging.Logger.Error("Failed to add transfer",
 				zap.Int("txn type", txn.TransactionType),
 				zap.String("transaction_ClientID", txn.ClientID),
+				zap.String("fee_payer", txn.GetFeePayer()),
 				zap.String("minersc_address", minersc.ADDRESS),
 				zap.Any("state_balance", txn.Fee))
 			return nil, err
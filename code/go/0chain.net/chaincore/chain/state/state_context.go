@@ -296,7 +296,8 @@ func (sc *StateContext) Validate() error {
 	}
 
 	totalValue := sc.txn.Value
-	if config.Configuration().ChainConfig.IsFeeEnabled() {
+	// the fee of a sponsored transaction isn't paid by the client
+	if config.Configuration().ChainConfig.IsFeeEnabled() && !sc.txn.IsSponsored() {
 		totalValue, err = currency.AddCoin(totalValue, sc.txn.Fee)
 		if err != nil {
 			return err
@@ -18,8 +18,10 @@ import (
 	"0chain.net/core/common"
 	"0chain.net/core/encryption"
 	"0chain.net/smartcontract/faucetsc"
+	"0chain.net/smartcontract/graphql"
 	"0chain.net/smartcontract/minersc"
 	"0chain.net/smartcontract/rest"
+	"0chain.net/smartcontract/schedulersc"
 	"0chain.net/smartcontract/storagesc"
 	"0chain.net/smartcontract/vestingsc"
 	"0chain.net/smartcontract/zcnsc"
@@ -46,6 +48,7 @@ func SetupSwagger() {
 
 func SetupScRestApiHandlers() {
 	c := GetServerChain()
+	rest.ConfigResponseCache()
 	restHandler := rest.NewRestHandler(c)
 	SetupSwagger()
 	if c.EventDb != nil {
@@ -54,6 +57,8 @@ func SetupScRestApiHandlers() {
 		storagesc.SetupRestHandler(restHandler)
 		vestingsc.SetupRestHandler(restHandler)
 		zcnsc.SetupRestHandler(restHandler)
+		schedulersc.SetupRestHandler(restHandler)
+		graphql.SetupRestHandler(restHandler)
 
 	} else {
 		logging.Logger.Warn("cannot find event database, REST API will not be supported on this sharder")
@@ -88,6 +93,15 @@ func (c *Chain) GetQueryStateContext() state.TimedQueryStateContextI {
 func (c *Chain) SetQueryStateContext(_ state.TimedQueryStateContextI) {
 }
 
+// GetQueryRound returns the round of the state the REST queries run on.
+func (c *Chain) GetQueryRound() int64 {
+	lfb := c.GetLatestFinalizedBlock()
+	if lfb == nil || lfb.ClientState == nil {
+		return -1
+	}
+	return lfb.Round
+}
+
 func (c *Chain) GetStateContextI() state.StateContextI {
 	lfb := c.GetLatestFinalizedBlock()
 	if lfb == nil || lfb.ClientState == nil {
@@ -274,6 +288,8 @@ func GetFunctionNames(address string) []string {
 		endpoints = vestingsc.GetEndpoints(nil)
 	case zcnsc.ADDRESS:
 		endpoints = zcnsc.GetEndpoints(nil)
+	case schedulersc.ADDRESS:
+		endpoints = schedulersc.GetEndpoints(nil)
 	default:
 		return []string{}
 	}
@@ -25,6 +25,12 @@ func SetClientSignatureScheme(scheme string) {
 	defaultClientSignatureScheme = scheme
 }
 
+// GetClientSignatureScheme returns a new instance of the signature scheme
+// used by the clients
+func GetClientSignatureScheme() encryption.SignatureScheme {
+	return encryption.GetSignatureScheme(defaultClientSignatureScheme)
+}
+
 var cacher cache.Cache
 
 func init() {
@@ -0,0 +1,178 @@
+// Package harness sets up a network of miner and sharder nodes in one process
+// for tests. The nodes exchange their messages through an in-memory transport
+// with controllable delays and partitions instead of HTTP, each node having
+// its own keys, request handlers, redis stand-in and data directory.
+//
+// Only the node to node layer is covered. The harness doesn't boot the chains
+// of the nodes: the chain services keep their state in process wide
+// singletons, chain.GetServerChain and the stores among them, so the nodes
+// can't run their own chain in one process yet, and the finality, view change
+// and DKG scenarios still need the conductor. node.Self is such a singleton
+// too, the harness runs the code of a node under Exec, one node at a time,
+// while the handlers of the receivers are called concurrently as the messages
+// are delivered.
+package harness
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"0chain.net/chaincore/node"
+	"0chain.net/core/common"
+	"0chain.net/core/encryption"
+	"github.com/alicebob/miniredis/v2"
+)
+
+const basePort = 7000
+
+// Config of the nodes of the network.
+type Config struct {
+	Miners   int
+	Sharders int
+}
+
+// Node of the network.
+type Node struct {
+	*node.Node
+	// Self is the self node of the node, node.Self acts as it under Exec.
+	Self *node.SelfNode
+	// Mux serves the node to node requests sent to the node.
+	Mux *http.ServeMux
+	// Redis is the redis stand-in of the node.
+	Redis *miniredis.Miniredis
+	// Dir is the data directory of the node, the rocksdb and the block
+	// store paths go under it.
+	Dir string
+}
+
+// Harness is a network of miners and sharders.
+type Harness struct {
+	Network  *Network
+	Miners   *node.Pool
+	Sharders *node.Pool
+	Nodes    []*Node
+
+	execMutex sync.Mutex
+}
+
+// New sets up a network of the configured miners and sharders, torn down at
+// the end of the test.
+func New(t testing.TB, cfg Config) *Harness {
+	if common.GetRootContext() == nil {
+		common.SetupRootContext(context.Background())
+	}
+
+	h := &Harness{
+		Network:  NewNetwork(),
+		Miners:   node.NewPool(node.NodeTypeMiner),
+		Sharders: node.NewPool(node.NodeTypeSharder),
+	}
+
+	for i := 0; i < cfg.Miners; i++ {
+		h.addNode(t, node.NodeTypeMiner, i)
+	}
+	for i := 0; i < cfg.Sharders; i++ {
+		h.addNode(t, node.NodeTypeSharder, i)
+	}
+
+	prevTransport := node.SetTransport(h.Network)
+	t.Cleanup(func() {
+		node.SetTransport(prevTransport)
+		for _, n := range h.Nodes {
+			n.Redis.Close()
+		}
+	})
+	return h
+}
+
+func (h *Harness) addNode(t testing.TB, nodeType node.NodeType, index int) {
+	scheme := encryption.NewBLS0ChainScheme()
+	if err := scheme.GenerateKeys(); err != nil {
+		t.Fatal(err)
+	}
+
+	nd := node.Provider()
+	nd.Type = nodeType
+	nd.Host = fmt.Sprintf("%s%d.harness", node.NodeTypeNames[nodeType].Code, index)
+	nd.Port = basePort + len(h.Nodes)
+	nd.Status = node.NodeStatusActive
+	if err := nd.SetSignatureScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := nd.SetID(encryption.Hash(nd.PublicKeyBytes)); err != nil {
+		t.Fatal(err)
+	}
+	if err := nd.ComputeProperties(); err != nil {
+		t.Fatal(err)
+	}
+	nd.Description = nd.Host
+
+	self := &node.SelfNode{Node: nd}
+	if err := self.SetSignatureScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	pool := h.Miners
+	if nodeType == node.NodeTypeSharder {
+		pool = h.Sharders
+	}
+	if err := pool.AddNode(nd); err != nil {
+		t.Fatal(err)
+	}
+
+	rs, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := filepath.Join(t.TempDir(), nd.Host)
+	if err := os.MkdirAll(filepath.Join(dir, "data", "rocksdb"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	n := &Node{
+		Node:  nd,
+		Self:  self,
+		Mux:   http.NewServeMux(),
+		Redis: rs,
+		Dir:   dir,
+	}
+	h.Network.Attach(nd, n.Mux)
+	h.Nodes = append(h.Nodes, n)
+}
+
+// Node returns the node of the id, nil if it isn't part of the network.
+func (h *Harness) Node(id string) *Node {
+	for _, n := range h.Nodes {
+		if n.GetKey() == id {
+			return n
+		}
+	}
+	return nil
+}
+
+// Exec runs f as the node, with node.Self acting as the node. The calls of
+// Exec are serialized.
+func (h *Harness) Exec(n *Node, f func()) {
+	h.execMutex.Lock()
+	defer h.execMutex.Unlock()
+
+	prev := node.Self.Swap(n.Self)
+	defer node.Self.Swap(prev)
+	f()
+}
+
+// Stop detaches the node from the network, as if it was stopped.
+func (h *Harness) Stop(n *Node) {
+	h.Network.Detach(n.Node)
+}
+
+// Start attaches a stopped node back to the network.
+func (h *Harness) Start(n *Node) {
+	h.Network.Attach(n.Node, n.Mux)
+}
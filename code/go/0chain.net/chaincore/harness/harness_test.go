@@ -0,0 +1,161 @@
+package harness
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"0chain.net/chaincore/node"
+	"0chain.net/core/datastore"
+	"github.com/0chain/common/core/logging"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const pingURI = "/v1/_n2n/harness/ping"
+
+type ping struct {
+	datastore.IDField
+}
+
+var pingEntityMetadata = &datastore.EntityMetadataImpl{
+	Name:         "harness_ping",
+	IDColumnName: "id",
+	Provider: func() datastore.Entity {
+		return &ping{}
+	},
+}
+
+func (p *ping) GetEntityMetadata() datastore.EntityMetadata {
+	return pingEntityMetadata
+}
+
+func init() {
+	logging.Logger = zap.NewNop()
+	logging.N2n = zap.NewNop()
+	datastore.RegisterEntityMetadata(pingEntityMetadata.Name, pingEntityMetadata)
+}
+
+// pings records the pings received by the nodes.
+type pings struct {
+	mutex    sync.Mutex
+	received map[string][]string // ping ids by receiver
+}
+
+func handlePings(h *Harness) *pings {
+	ps := &pings{received: make(map[string][]string)}
+	for _, n := range h.Nodes {
+		id := n.GetKey()
+		handler := func(ctx context.Context, entity datastore.Entity) (interface{}, error) {
+			ps.mutex.Lock()
+			defer ps.mutex.Unlock()
+			ps.received[id] = append(ps.received[id], entity.GetKey())
+			return nil, nil
+		}
+		n.Mux.HandleFunc(pingURI, node.ToN2NReceiveEntityHandler(
+			node.SenderValidateHandler(handler), nil))
+	}
+	return ps
+}
+
+func (ps *pings) count(id string) int {
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+	return len(ps.received[id])
+}
+
+// requireCount waits for the node to have received the number of pings, the
+// entities are handled asynchronously.
+func (ps *pings) requireCount(t *testing.T, id string, n int) {
+	require.Eventually(t, func() bool {
+		return ps.count(id) == n
+	}, time.Second, 10*time.Millisecond)
+}
+
+// sendPing sends the ping to all the miners and returns once the sends are
+// done. The nodes the pool reports as sent to can miss the last ones, the
+// tests check the pings received instead.
+func sendPing(h *Harness, from *Node, id string) {
+	options := &node.SendOptions{Timeout: time.Second, CODEC: node.CODEC_JSON}
+	h.Exec(from, func() {
+		p := &ping{}
+		p.SetKey(id)
+		handler := node.SendEntityHandler(pingURI, options)(p)
+		h.Miners.SendAll(context.Background(), handler)
+	})
+}
+
+func TestHarnessSendAll(t *testing.T) {
+	h := New(t, Config{Miners: 3, Sharders: 1})
+	require.Equal(t, 3, h.Miners.Size())
+	require.Equal(t, 1, h.Sharders.Size())
+	ps := handlePings(h)
+
+	m0 := h.Node(h.Miners.Nodes[0].GetKey())
+	sendPing(h, m0, "p1")
+
+	// all miners but the sender
+	for _, n := range h.Miners.Nodes[1:] {
+		id := n.GetKey()
+		ps.requireCount(t, id, 1)
+		require.Equal(t, 1, h.Network.Delivered(m0.GetKey(), id))
+	}
+	require.Zero(t, ps.count(m0.GetKey()))
+}
+
+func TestHarnessPartition(t *testing.T) {
+	h := New(t, Config{Miners: 3})
+	ps := handlePings(h)
+
+	var (
+		m0 = h.Node(h.Miners.Nodes[0].GetKey())
+		m1 = h.Node(h.Miners.Nodes[1].GetKey())
+		m2 = h.Node(h.Miners.Nodes[2].GetKey())
+	)
+
+	h.Network.Partition([]string{m0.GetKey(), m1.GetKey()}, []string{m2.GetKey()})
+	sendPing(h, m0, "p1")
+	ps.requireCount(t, m1.GetKey(), 1)
+	require.Zero(t, ps.count(m2.GetKey()))
+	require.Equal(t, 1, h.Network.Dropped(m0.GetKey(), m2.GetKey()))
+
+	h.Network.Heal()
+	sendPing(h, m0, "p2")
+	ps.requireCount(t, m1.GetKey(), 2)
+	ps.requireCount(t, m2.GetKey(), 1)
+
+	h.Stop(m2)
+	sendPing(h, m0, "p3")
+	ps.requireCount(t, m1.GetKey(), 3)
+	ps.requireCount(t, m2.GetKey(), 1)
+
+	h.Start(m2)
+	sendPing(h, m0, "p4")
+	ps.requireCount(t, m1.GetKey(), 4)
+	ps.requireCount(t, m2.GetKey(), 2)
+}
+
+func TestHarnessDelay(t *testing.T) {
+	h := New(t, Config{Miners: 2})
+	ps := handlePings(h)
+
+	var (
+		m0    = h.Node(h.Miners.Nodes[0].GetKey())
+		m1    = h.Node(h.Miners.Nodes[1].GetKey())
+		delay = 200 * time.Millisecond
+	)
+
+	h.Network.SetDelay(m0.GetKey(), m1.GetKey(), delay)
+	start := time.Now()
+	sendPing(h, m0, "p1")
+	require.GreaterOrEqual(t, time.Since(start), delay)
+	ps.requireCount(t, m1.GetKey(), 1)
+
+	// a delay over the send timeout loses the message
+	h.Network.SetDelay(m0.GetKey(), m1.GetKey(), 2*time.Second)
+	sendPing(h, m0, "p2")
+	require.Never(t, func() bool {
+		return ps.count(m1.GetKey()) > 1
+	}, 100*time.Millisecond, 10*time.Millisecond)
+}
@@ -0,0 +1,199 @@
+package harness
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"0chain.net/chaincore/node"
+)
+
+var (
+	// ErrUnreachable is returned sending to a node not attached to the
+	// network, the in-memory counterpart of a refused connection.
+	ErrUnreachable = errors.New("node unreachable")
+	// ErrPartitioned is returned sending across a network partition.
+	ErrPartitioned = errors.New("nodes partitioned")
+)
+
+type link struct {
+	from, to string
+}
+
+type endpoint struct {
+	id      string
+	handler http.Handler
+}
+
+// Network is an in-memory transport of the node to node messages. The
+// messages are routed by the host of the request to the handler of the
+// attached node and by the node id header to the sender, so the delays and
+// the partitions are set per pair of node ids. It implements the
+// http.RoundTripper used by the n2n layer, see node.SetTransport.
+type Network struct {
+	mutex        sync.RWMutex
+	endpoints    map[string]*endpoint // by host:port
+	delays       map[link]time.Duration
+	defaultDelay time.Duration
+	groups       map[string]int // partition group by node id
+	delivered    map[link]int
+	dropped      map[link]int
+}
+
+// NewNetwork returns a network without nodes, delays or partitions.
+func NewNetwork() *Network {
+	return &Network{
+		endpoints: make(map[string]*endpoint),
+		delays:    make(map[link]time.Duration),
+		delivered: make(map[link]int),
+		dropped:   make(map[link]int),
+	}
+}
+
+func n2nAddress(n *node.Node) string {
+	return fmt.Sprintf("%v:%v", n.N2NHost, n.Port)
+}
+
+// Attach makes the node reachable, the messages sent to it are served by the
+// handler.
+func (nw *Network) Attach(n *node.Node, handler http.Handler) {
+	nw.mutex.Lock()
+	defer nw.mutex.Unlock()
+	nw.endpoints[n2nAddress(n)] = &endpoint{id: n.GetKey(), handler: handler}
+}
+
+// Detach makes the node unreachable, as if it was stopped.
+func (nw *Network) Detach(n *node.Node) {
+	nw.mutex.Lock()
+	defer nw.mutex.Unlock()
+	delete(nw.endpoints, n2nAddress(n))
+}
+
+// SetDefaultDelay sets the delay of the links without their own delay.
+func (nw *Network) SetDefaultDelay(delay time.Duration) {
+	nw.mutex.Lock()
+	defer nw.mutex.Unlock()
+	nw.defaultDelay = delay
+}
+
+// SetDelay sets the delay of the messages sent from a node to another.
+func (nw *Network) SetDelay(from, to string, delay time.Duration) {
+	nw.mutex.Lock()
+	defer nw.mutex.Unlock()
+	nw.delays[link{from: from, to: to}] = delay
+}
+
+// Partition splits the network in the groups of node ids, the messages
+// between the nodes of different groups are dropped. The nodes not listed
+// are reachable from all the groups.
+func (nw *Network) Partition(groups ...[]string) {
+	nw.mutex.Lock()
+	defer nw.mutex.Unlock()
+	nw.groups = make(map[string]int)
+	for i, ids := range groups {
+		for _, id := range ids {
+			nw.groups[id] = i
+		}
+	}
+}
+
+// Heal removes the partitions of the network.
+func (nw *Network) Heal() {
+	nw.mutex.Lock()
+	defer nw.mutex.Unlock()
+	nw.groups = nil
+}
+
+// Delivered returns the number of messages delivered from a node to another.
+func (nw *Network) Delivered(from, to string) int {
+	nw.mutex.RLock()
+	defer nw.mutex.RUnlock()
+	return nw.delivered[link{from: from, to: to}]
+}
+
+// Dropped returns the number of messages from a node to another dropped by a
+// partition.
+func (nw *Network) Dropped(from, to string) int {
+	nw.mutex.RLock()
+	defer nw.mutex.RUnlock()
+	return nw.dropped[link{from: from, to: to}]
+}
+
+func (nw *Network) partitioned(l link) bool {
+	if nw.groups == nil {
+		return false
+	}
+	fg, ok := nw.groups[l.from]
+	if !ok {
+		return false
+	}
+	tg, ok := nw.groups[l.to]
+	if !ok {
+		return false
+	}
+	return fg != tg
+}
+
+// route resolves the receiver and the delay of the request, and accounts it.
+func (nw *Network) route(r *http.Request) (*endpoint, time.Duration, error) {
+	nw.mutex.Lock()
+	defer nw.mutex.Unlock()
+
+	ep, ok := nw.endpoints[r.URL.Host]
+	if !ok {
+		return nil, 0, ErrUnreachable
+	}
+
+	l := link{from: r.Header.Get(node.HeaderNodeID), to: ep.id}
+	if nw.partitioned(l) {
+		nw.dropped[l]++
+		return nil, 0, ErrPartitioned
+	}
+	nw.delivered[l]++
+
+	delay, ok := nw.delays[l]
+	if !ok {
+		delay = nw.defaultDelay
+	}
+	return ep, delay, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (nw *Network) RoundTrip(r *http.Request) (*http.Response, error) {
+	ep, delay, err := nw.route(r)
+	if err != nil {
+		if r.Body != nil {
+			r.Body.Close()
+		}
+		return nil, err
+	}
+
+	ctx := r.Context()
+	if delay > 0 {
+		tm := time.NewTimer(delay)
+		select {
+		case <-tm.C:
+		case <-ctx.Done():
+			tm.Stop()
+			return nil, ctx.Err()
+		}
+	}
+
+	// serve the request as the http server of the receiver would
+	req := r.Clone(ctx)
+	req.RequestURI = r.URL.RequestURI()
+	req.RemoteAddr = r.Header.Get(node.HeaderNodeID)
+	if req.Body == nil {
+		req.Body = http.NoBody
+	}
+
+	rec := httptest.NewRecorder()
+	ep.handler.ServeHTTP(rec, req)
+
+	resp := rec.Result()
+	resp.Request = r
+	return resp, nil
+}
@@ -132,6 +132,15 @@ func init() {
 	}
 }
 
+// SetTransport replaces the transport the node to node messages are sent
+// with, and returns the previous one. It's meant for tests running a network
+// of nodes in process, the HTTP transport is used otherwise.
+func SetTransport(transport http.RoundTripper) http.RoundTripper {
+	prev := httpClient.Transport
+	httpClient.Transport = transport
+	return prev
+}
+
 const (
 	// SENDER - key used to get the connection object from the context */
 	SENDER               common.ContextKey = "node.sender"
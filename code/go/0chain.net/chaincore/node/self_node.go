@@ -76,6 +76,21 @@ func (sn *SelfNode) SetSignatureScheme(signatureScheme encryption.SignatureSchem
 	return sn.Node.SetSignatureScheme(signatureScheme)
 }
 
+// Swap makes the self node act as the other one, taking its node and keys,
+// and returns the previous ones. It's meant for tests running several nodes
+// in process.
+func (sn *SelfNode) Swap(other *SelfNode) *SelfNode {
+	other.mx.RLock()
+	nd, scheme := other.Node, other.signatureScheme
+	other.mx.RUnlock()
+
+	sn.mx.Lock()
+	defer sn.mx.Unlock()
+	prev := &SelfNode{Node: sn.Node, signatureScheme: sn.signatureScheme}
+	sn.Node, sn.signatureScheme = nd, scheme
+	return prev
+}
+
 /*Sign - sign the given hash */
 func (sn *SelfNode) Sign(hash string) (string, error) {
 	sn.mx.RLock()
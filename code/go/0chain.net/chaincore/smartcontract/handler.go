@@ -2,6 +2,7 @@ package smartcontract
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
@@ -73,6 +74,51 @@ func ExecuteSmartContract(txn *transaction.Transaction, balances c_state.StateCo
 	return "", common.NewError("invalid_smart_contract_address", "Invalid Smart Contract address")
 }
 
+// ExecuteMultiCall executes the calls of a multi-call transaction in order,
+// in the same state context. It stops at the first failing call, so the
+// caller drops the state changes of all the calls. The output is the list of
+// the outputs of the calls. The multi-call transactions are enabled by the
+// hermes hard fork.
+func ExecuteMultiCall(txn *transaction.Transaction, balances c_state.StateContextI) (string, error) {
+	if err := c_state.WithActivation(balances, "hermes", func() error {
+		return common.NewError("invalid_multicall", "multi-call transactions are not enabled yet")
+	}, func() error {
+		return nil
+	}); err != nil {
+		return "", err
+	}
+
+	data, err := txn.GetMultiCallData()
+	if err != nil {
+		return "", common.NewError("invalid_multicall", err.Error())
+	}
+
+	results := make([]transaction.MultiCallResult, 0, len(data.Calls))
+	for i := range data.Calls {
+		call := &data.Calls[i]
+		ct, err := txn.CallTransaction(call, i)
+		if err != nil {
+			return "", common.NewError("invalid_multicall", err.Error())
+		}
+
+		output, err := ExecuteSmartContract(ct, balances)
+		if err != nil {
+			return "", fmt.Errorf("call %d (%s): %v", i, call.FunctionName, err)
+		}
+		results = append(results, transaction.MultiCallResult{
+			Address: call.Address,
+			Name:    call.FunctionName,
+			Output:  output,
+		})
+	}
+
+	output, err := json.Marshal(results)
+	if err != nil {
+		return "", err
+	}
+	return string(output), nil
+}
+
 func EstimateTransactionCost(t *transaction.Transaction, scData sci.SmartContractTransactionData, balances c_state.StateContextI) (int, error) {
 	contractObj := getSmartContract(t.ToClientID)
 	if contractObj == nil {
@@ -89,9 +135,41 @@ func EstimateTransactionCost(t *transaction.Transaction, scData sci.SmartContrac
 		//return math.MaxInt, errors.New("no cost found for function")
 		return math.MaxInt, nil
 	}
+	if scaler, ok := contractObj.(sci.InputCostScaler); ok {
+		cost = scaler.ScaleCost(strings.ToLower(scData.FunctionName), scData.InputData, cost)
+	}
 	return cost, nil
 }
 
+// EstimateMultiCallCost returns the cost of a multi-call transaction, the
+// sum of the costs of its calls.
+func EstimateMultiCallCost(t *transaction.Transaction, balances c_state.StateContextI) (int, error) {
+	data, err := t.GetMultiCallData()
+	if err != nil {
+		return math.MaxInt, err
+	}
+
+	var total int
+	for i := range data.Calls {
+		ct, err := t.CallTransaction(&data.Calls[i], i)
+		if err != nil {
+			return math.MaxInt, err
+		}
+		cost, err := EstimateTransactionCost(ct, sci.SmartContractTransactionData{
+			FunctionName: ct.FunctionName,
+			InputData:    ct.InputData,
+		}, balances)
+		if err != nil || cost == math.MaxInt {
+			return math.MaxInt, err
+		}
+		if total > math.MaxInt-cost {
+			return math.MaxInt, nil
+		}
+		total += cost
+	}
+	return total, nil
+}
+
 func GetTransactionCostTable(balances c_state.StateContextI) map[string]map[string]int {
 	res := make(map[string]map[string]int)
 	for addr, sc := range ContractMap {
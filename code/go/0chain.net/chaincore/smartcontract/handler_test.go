@@ -135,7 +135,7 @@ func TestGetSmartContract(t *testing.T) {
 		{
 			name:       "storage",
 			address:    storagesc.ADDRESS,
-			restpoints: 46,
+			restpoints: 49,
 		},
 		{
 			name:       "multisig",
@@ -145,7 +145,7 @@ func TestGetSmartContract(t *testing.T) {
 		{
 			name:       "miner",
 			address:    minersc.ADDRESS,
-			restpoints: 22,
+			restpoints: 24,
 		},
 		{
 			name:       "vesting",
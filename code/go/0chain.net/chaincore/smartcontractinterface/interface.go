@@ -40,6 +40,12 @@ type SmartContractInterface interface {
 	GetCostTable(balances c_state.StateContextI) (map[string]int, error)
 }
 
+// InputCostScaler is implemented by smart contracts with functions whose
+// cost depends on the input, e.g. on the number of items of a batch.
+type InputCostScaler interface {
+	ScaleCost(funcName string, input []byte, cost int) int
+}
+
 /*
 BCContextI interface for smart contracts to access blockchain.
 These functions should not modify blockchain states in anyway.
@@ -0,0 +1,231 @@
+package transaction
+
+// Client transactions entity db indexes the transactions stored by a sharder
+// by their client, it serves the transaction history and the nonce of the
+// clients without the events database.
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"0chain.net/core/datastore"
+	"0chain.net/core/ememorystore"
+)
+
+const (
+	ClientTxnMetaName   = "client_txn"
+	ClientNonceMetaName = "client_nonce"
+	ClientTxnDBName     = "clienttxndb"
+
+	clientTxnKeyspace   = "client_txn"
+	clientNonceKeyspace = "client_nonce"
+)
+
+var (
+	clientTxnEntityMetadata   *datastore.EntityMetadataImpl
+	clientNonceEntityMetadata *datastore.EntityMetadataImpl
+)
+
+// ClientTxn represents a transaction of a client in the index, the keys of
+// the transactions of a client share a prefix and are ordered by round and
+// nonce.
+type ClientTxn struct {
+	datastore.NOIDField
+	Key      string `json:"key"`
+	ClientID string `json:"client_id"`
+	Round    int64  `json:"round"`
+	Hash     string `json:"hash"`
+	Nonce    int64  `json:"nonce"`
+	Status   int    `json:"status"`
+}
+
+// ClientTxnPrefix returns the common prefix of the keys of the transactions
+// of a client.
+func ClientTxnPrefix(clientID string) string {
+	return fmt.Sprintf("%s:%s:", clientTxnKeyspace, clientID)
+}
+
+// BuildClientTxnKey returns the key of a transaction of a client.
+func BuildClientTxnKey(clientID string, round, nonce int64, hash string) datastore.Key {
+	return datastore.ToKey(fmt.Sprintf("%s%020d:%020d:%s",
+		ClientTxnPrefix(clientID), round, nonce, hash))
+}
+
+// NewClientTxn returns the index entry of a transaction stored at the round.
+func NewClientTxn(txn *Transaction, round int64) *ClientTxn {
+	return &ClientTxn{
+		Key:      datastore.ToString(BuildClientTxnKey(txn.ClientID, round, txn.Nonce, txn.Hash)),
+		ClientID: txn.ClientID,
+		Round:    round,
+		Hash:     txn.Hash,
+		Nonce:    txn.Nonce,
+		Status:   txn.Status,
+	}
+}
+
+func ClientTxnProvider() datastore.Entity {
+	return &ClientTxn{}
+}
+
+// GetEntityMetadata returns the clientTxnEntityMetadata
+func (ct *ClientTxn) GetEntityMetadata() datastore.EntityMetadata {
+	return clientTxnEntityMetadata
+}
+
+// GetKey returns the key of the entity
+func (ct *ClientTxn) GetKey() datastore.Key {
+	return datastore.ToKey(ct.Key)
+}
+
+// SetKey sets the key of the entity
+func (ct *ClientTxn) SetKey(key datastore.Key) {
+	ct.Key = datastore.ToString(key)
+}
+
+// Read reads the client transaction from the store
+func (ct *ClientTxn) Read(ctx context.Context, key datastore.Key) error {
+	return ct.GetEntityMetadata().GetStore().Read(ctx, key, ct)
+}
+
+// Write writes the client transaction to the store
+func (ct *ClientTxn) Write(ctx context.Context) error {
+	return ct.GetEntityMetadata().GetStore().Write(ctx, ct)
+}
+
+// Delete deletes the client transaction from the store
+func (ct *ClientTxn) Delete(ctx context.Context) error {
+	return ct.GetEntityMetadata().GetStore().Delete(ctx, ct)
+}
+
+// ClientNonce is the highest nonce of the stored transactions of a client and
+// the gaps below it, the nonces of the transactions of the blocks not stored.
+type ClientNonce struct {
+	datastore.NOIDField
+	Key      string     `json:"key"`
+	ClientID string     `json:"client_id"`
+	Nonce    int64      `json:"nonce"`
+	Round    int64      `json:"round"`
+	Lowest   int64      `json:"lowest"`
+	Gaps     []NonceGap `json:"gaps,omitempty"`
+}
+
+// NonceGap is a range of the missing nonces of a client, inclusive.
+type NonceGap struct {
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+}
+
+// Add adds the nonce of a transaction of the client stored in the round,
+// keeping the gaps sorted. The zero nonces are skipped.
+func (cn *ClientNonce) Add(nonce, round int64) {
+	switch {
+	case nonce <= 0:
+	case cn.Nonce == 0:
+		cn.Lowest, cn.Nonce, cn.Round = nonce, nonce, round
+	case nonce > cn.Nonce:
+		if nonce > cn.Nonce+1 {
+			cn.Gaps = append(cn.Gaps, NonceGap{From: cn.Nonce + 1, To: nonce - 1})
+		}
+		cn.Nonce, cn.Round = nonce, round
+	case nonce < cn.Lowest:
+		if nonce < cn.Lowest-1 {
+			cn.Gaps = append([]NonceGap{{From: nonce + 1, To: cn.Lowest - 1}}, cn.Gaps...)
+		}
+		cn.Lowest = nonce
+	default:
+		cn.fill(nonce)
+	}
+}
+
+// fill removes the nonce from its gap, splitting the gap.
+func (cn *ClientNonce) fill(nonce int64) {
+	for i, g := range cn.Gaps {
+		if nonce < g.From || nonce > g.To {
+			continue
+		}
+		var rest []NonceGap
+		if g.From < nonce {
+			rest = append(rest, NonceGap{From: g.From, To: nonce - 1})
+		}
+		if nonce < g.To {
+			rest = append(rest, NonceGap{From: nonce + 1, To: g.To})
+		}
+		cn.Gaps = append(cn.Gaps[:i], append(rest, cn.Gaps[i+1:]...)...)
+		return
+	}
+}
+
+// BuildClientNonceKey returns the key of the nonce of a client.
+func BuildClientNonceKey(clientID string) datastore.Key {
+	return datastore.ToKey(fmt.Sprintf("%s:%s", clientNonceKeyspace, clientID))
+}
+
+func ClientNonceProvider() datastore.Entity {
+	return &ClientNonce{}
+}
+
+// GetEntityMetadata returns the clientNonceEntityMetadata
+func (cn *ClientNonce) GetEntityMetadata() datastore.EntityMetadata {
+	return clientNonceEntityMetadata
+}
+
+// GetKey returns the key of the entity
+func (cn *ClientNonce) GetKey() datastore.Key {
+	return datastore.ToKey(cn.Key)
+}
+
+// SetKey sets the key of the entity
+func (cn *ClientNonce) SetKey(key datastore.Key) {
+	cn.Key = datastore.ToString(key)
+}
+
+// Read reads the client nonce from the store
+func (cn *ClientNonce) Read(ctx context.Context, key datastore.Key) error {
+	return cn.GetEntityMetadata().GetStore().Read(ctx, key, cn)
+}
+
+// Write writes the client nonce to the store
+func (cn *ClientNonce) Write(ctx context.Context) error {
+	return cn.GetEntityMetadata().GetStore().Write(ctx, cn)
+}
+
+// Delete deletes the client nonce from the store
+func (cn *ClientNonce) Delete(ctx context.Context) error {
+	return cn.GetEntityMetadata().GetStore().Delete(ctx, cn)
+}
+
+// SetupClientTxnEntity - setup the client transaction and nonce entities
+func SetupClientTxnEntity(store datastore.Store) {
+	clientTxnEntityMetadata = datastore.MetadataProvider()
+	clientTxnEntityMetadata.Name = ClientTxnMetaName
+	clientTxnEntityMetadata.DB = ClientTxnDBName
+	clientTxnEntityMetadata.Provider = ClientTxnProvider
+	clientTxnEntityMetadata.Store = store
+	clientTxnEntityMetadata.IDColumnName = "key"
+	datastore.RegisterEntityMetadata(ClientTxnMetaName, clientTxnEntityMetadata)
+
+	clientNonceEntityMetadata = datastore.MetadataProvider()
+	clientNonceEntityMetadata.Name = ClientNonceMetaName
+	clientNonceEntityMetadata.DB = ClientTxnDBName
+	clientNonceEntityMetadata.Provider = ClientNonceProvider
+	clientNonceEntityMetadata.Store = store
+	clientNonceEntityMetadata.IDColumnName = "key"
+	datastore.RegisterEntityMetadata(ClientNonceMetaName, clientNonceEntityMetadata)
+}
+
+// SetupClientTxnDB - sets up the client transactions database
+func SetupClientTxnDB(workdir string) {
+	datadir := filepath.Join(workdir, "data/rocksdb/clienttxns")
+	db, err := ememorystore.CreateDB(datadir)
+	if err != nil {
+		panic(err)
+	}
+	ememorystore.AddPool(ClientTxnDBName, db)
+}
+
+// ClientTxnIndexEnabled reports whether the client transactions index is
+// set up.
+func ClientTxnIndexEnabled() bool {
+	return clientTxnEntityMetadata != nil
+}
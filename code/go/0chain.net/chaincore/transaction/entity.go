@@ -115,11 +115,22 @@ type Transaction struct {
 	// required: true
 	Nonce           int64            `json:"transaction_nonce" msgpack:"n"`
 
+	// FeePayerID - the client paying the fee of a sponsored transaction,
+	// the issuing client pays it if empty
+	FeePayerID string `json:"fee_payer_id,omitempty" msgpack:"fpid,omitempty"`
+
+	// FeePayerPublicKey - the public key of the fee payer
+	FeePayerPublicKey string `json:"fee_payer_public_key,omitempty" msgpack:"fppk,omitempty"`
+
+	// FeePayerSignature - the fee payer signature of the transaction hash and fee
+	FeePayerSignature string `json:"fee_payer_signature,omitempty" msgpack:"fps,omitempty"`
+
 	// TransactionType - the type of the transaction. 
 	//	Possible values are:
 	//		- 0: TxnTypeSend - A transaction to send tokens to another account, state is maintained by account.
 	//		- 10: TxnTypeData - A transaction to just store a piece of data on the block chain.
 	//		- 1000: TxnTypeSmartContract - A smart contract transaction type.
+	//		- 1002: TxnTypeMultiCall - An atomic batch of smart contract calls.
 	// required: true
 	TransactionType   int    `json:"transaction_type" msgpack:"tt"`
 
@@ -165,6 +176,12 @@ func (t *Transaction) ComputeProperties() error {
 			return fmt.Errorf("invalid smart contract data: %v", err)
 		}
 	}
+	if t.TransactionType == TxnTypeMultiCall {
+		if _, err := t.GetMultiCallData(); err != nil {
+			return err
+		}
+		t.SmartContractData.FunctionName = MultiCallFunctionName
+	}
 	return t.ComputeClientID()
 }
 
@@ -257,6 +274,11 @@ func (t *Transaction) ValidateWrtTimeForBlock(ctx context.Context, ts common.Tim
 		if err != nil {
 			return err
 		}
+		if t.IsSponsored() {
+			if err := t.VerifyFeePayerSignature(); err != nil {
+				return err
+			}
+		}
 	}
 	if t.OutputHash != "" {
 		err = t.VerifyOutputHash(ctx)
@@ -269,7 +291,8 @@ func (t *Transaction) ValidateWrtTimeForBlock(ctx context.Context, ts common.Tim
 
 /*Validate - Entity implementation */
 func (t *Transaction) Validate(ctx context.Context) error {
-	if !encryption.IsHash(t.ToClientID) {
+	// the calls of a multi-call transaction have their own addresses
+	if t.TransactionType != TxnTypeMultiCall && !encryption.IsHash(t.ToClientID) {
 		return errors.New("invalid to client id")
 	}
 	return t.ValidateWrtTime(ctx, common.Now())
@@ -339,6 +362,11 @@ func (t *Transaction) HashData() string {
 	s.WriteString(strconv.FormatUint(uint64(t.Value), 10))
 	s.WriteString(":")
 	s.WriteString(encryption.Hash(t.TransactionData))
+	if t.FeePayerID != "" {
+		// the issuing client agrees on the sponsor
+		s.WriteString(":")
+		s.WriteString(t.FeePayerID)
+	}
 	return s.String()
 }
 
@@ -517,6 +545,9 @@ func (t *Transaction) Clone() *Transaction {
 		CreationDate:      t.CreationDate,
 		Fee:               t.Fee,
 		Nonce:             t.Nonce,
+		FeePayerID:        t.FeePayerID,
+		FeePayerPublicKey: t.FeePayerPublicKey,
+		FeePayerSignature: t.FeePayerSignature,
 		TransactionType:   t.TransactionType,
 		TransactionOutput: t.TransactionOutput,
 		OutputHash:        t.OutputHash,
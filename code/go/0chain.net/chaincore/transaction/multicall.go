@@ -0,0 +1,112 @@
+package transaction
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/currency"
+)
+
+const (
+	// MultiCallFunctionName is the function name of the multi-call
+	// transactions, used for their metrics and fee exemption.
+	MultiCallFunctionName = "multicall"
+	// MaxMultiCalls is the max number of calls of a multi-call transaction.
+	MaxMultiCalls = 20
+)
+
+// MultiCall is a smart contract call of a multi-call transaction.
+type MultiCall struct {
+	// Address of the smart contract called
+	Address string `json:"address"`
+	// Value of the call, the sum of the values of the calls is the value
+	// of the transaction
+	Value currency.Coin `json:"value,omitempty"`
+	SmartContractData
+}
+
+// MultiCallData is the transaction data of a multi-call transaction, the
+// calls are executed in order, either all of them or none.
+type MultiCallData struct {
+	Calls []MultiCall `json:"calls"`
+}
+
+// MultiCallResult is the output of a call of a multi-call transaction.
+type MultiCallResult struct {
+	Address string `json:"address"`
+	Name    string `json:"name"`
+	Output  string `json:"output"`
+}
+
+// GetMultiCallData decodes and validates the calls of a multi-call
+// transaction.
+func (t *Transaction) GetMultiCallData() (*MultiCallData, error) {
+	if t.TransactionType != TxnTypeMultiCall {
+		return nil, errors.New("not a multi-call transaction")
+	}
+
+	var data MultiCallData
+	if err := json.Unmarshal([]byte(t.TransactionData), &data); err != nil {
+		return nil, fmt.Errorf("invalid multi-call data: %v", err)
+	}
+	if len(data.Calls) == 0 {
+		return nil, errors.New("multi-call without calls")
+	}
+	if len(data.Calls) > MaxMultiCalls {
+		return nil, fmt.Errorf("too many calls: %d, max %d", len(data.Calls), MaxMultiCalls)
+	}
+
+	var (
+		total currency.Coin
+		err   error
+	)
+	for i, call := range data.Calls {
+		if !encryption.IsHash(call.Address) {
+			return nil, fmt.Errorf("call %d: invalid smart contract address", i)
+		}
+		if call.FunctionName == "" {
+			return nil, fmt.Errorf("call %d: missing function name", i)
+		}
+		if total, err = currency.AddCoin(total, call.Value); err != nil {
+			return nil, err
+		}
+	}
+	if total != t.Value {
+		return nil, fmt.Errorf("value of the calls %v doesn't match the transaction value %v", total, t.Value)
+	}
+	return &data, nil
+}
+
+// CallTransaction returns the transaction the call of the given index of
+// the multi-call transaction is executed as: the call's smart contract,
+// function and value with the identity, fee and nonce of the transaction.
+// The hash of the call is derived from the hash of the transaction and the
+// index, so the calls don't share the entities the smart contracts key by
+// the transaction hash.
+func (t *Transaction) CallTransaction(call *MultiCall, index int) (*Transaction, error) {
+	data, err := json.Marshal(&call.SmartContractData)
+	if err != nil {
+		return nil, err
+	}
+
+	ct := t.Clone()
+	ct.Hash = CallHash(t.Hash, index)
+	ct.TransactionType = TxnTypeSmartContract
+	ct.ToClientID = call.Address
+	ct.Value = call.Value
+	ct.TransactionData = string(data)
+	ct.SmartContractData = &SmartContractData{
+		FunctionName: call.FunctionName,
+		InputData:    call.InputData,
+	}
+	return ct, nil
+}
+
+// CallHash returns the hash of the call of the given index of the multi-call
+// transaction of the given hash.
+func CallHash(txnHash string, index int) string {
+	return encryption.Hash(txnHash + ":" + strconv.Itoa(index))
+}
@@ -0,0 +1,99 @@
+package transaction
+
+import (
+	"encoding/json"
+	"testing"
+
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/currency"
+	"github.com/stretchr/testify/require"
+)
+
+func multiCallTxn(t *testing.T, value currency.Coin, calls ...MultiCall) *Transaction {
+	data, err := json.Marshal(&MultiCallData{Calls: calls})
+	require.NoError(t, err)
+
+	return &Transaction{
+		TransactionType: TxnTypeMultiCall,
+		TransactionData: string(data),
+		ClientID:        encryption.Hash("client"),
+		Value:           value,
+		Fee:             10,
+		Nonce:           3,
+	}
+}
+
+func TestGetMultiCallData(t *testing.T) {
+	var (
+		storage = encryption.Hash("storage")
+		miner   = encryption.Hash("miner")
+		calls   = []MultiCall{
+			{
+				Address:           storage,
+				Value:             5,
+				SmartContractData: SmartContractData{FunctionName: "new_allocation_request", InputData: json.RawMessage(`{"size":1}`)},
+			},
+			{
+				Address:           miner,
+				Value:             7,
+				SmartContractData: SmartContractData{FunctionName: "addToDelegatePool", InputData: json.RawMessage(`{}`)},
+			},
+		}
+	)
+
+	data, err := multiCallTxn(t, 12, calls...).GetMultiCallData()
+	require.NoError(t, err)
+	require.Equal(t, calls, data.Calls)
+
+	_, err = multiCallTxn(t, 11, calls...).GetMultiCallData()
+	require.Error(t, err, "value mismatch")
+
+	_, err = multiCallTxn(t, 0).GetMultiCallData()
+	require.Error(t, err, "no calls")
+
+	_, err = multiCallTxn(t, 0, MultiCall{Address: "sc", SmartContractData: SmartContractData{FunctionName: "f"}}).GetMultiCallData()
+	require.Error(t, err, "invalid address")
+
+	_, err = multiCallTxn(t, 0, MultiCall{Address: storage}).GetMultiCallData()
+	require.Error(t, err, "missing function")
+
+	tooMany := make([]MultiCall, MaxMultiCalls+1)
+	for i := range tooMany {
+		tooMany[i] = MultiCall{Address: storage, SmartContractData: SmartContractData{FunctionName: "f"}}
+	}
+	_, err = multiCallTxn(t, 0, tooMany...).GetMultiCallData()
+	require.Error(t, err, "too many calls")
+}
+
+func TestCallTransaction(t *testing.T) {
+	call := MultiCall{
+		Address:           encryption.Hash("storage"),
+		Value:             5,
+		SmartContractData: SmartContractData{FunctionName: "new_allocation_request", InputData: json.RawMessage(`{"size":1}`)},
+	}
+	txn := multiCallTxn(t, 5, call)
+
+	txn.Hash = encryption.Hash("multi-call")
+
+	ct, err := txn.CallTransaction(&call, 0)
+	require.NoError(t, err)
+	require.Equal(t, TxnTypeSmartContract, ct.TransactionType)
+	require.Equal(t, call.Address, ct.ToClientID)
+	require.Equal(t, call.Value, ct.Value)
+	require.Equal(t, "new_allocation_request", ct.FunctionName)
+	require.JSONEq(t, `{"size":1}`, string(ct.InputData))
+	require.JSONEq(t, `{"name":"new_allocation_request","input":{"size":1}}`, ct.TransactionData)
+
+	// the identity, fee and nonce of the transaction
+	require.Equal(t, txn.ClientID, ct.ClientID)
+	require.Equal(t, txn.Fee, ct.Fee)
+	require.Equal(t, txn.Nonce, ct.Nonce)
+
+	// a hash of its own for every call
+	require.Equal(t, CallHash(txn.Hash, 0), ct.Hash)
+	other, err := txn.CallTransaction(&call, 1)
+	require.NoError(t, err)
+	require.NotEqual(t, ct.Hash, other.Hash)
+	require.NotEqual(t, txn.Hash, ct.Hash)
+	require.Equal(t, TxnTypeMultiCall, txn.TransactionType)
+}
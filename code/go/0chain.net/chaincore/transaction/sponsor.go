@@ -0,0 +1,79 @@
+package transaction
+
+import (
+	"errors"
+	"strconv"
+
+	"0chain.net/chaincore/client"
+	"0chain.net/core/common"
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/currency"
+)
+
+// IsSponsored tells if the fee of the transaction is paid by a fee payer
+// other than the issuing client.
+func (t *Transaction) IsSponsored() bool {
+	return t.FeePayerID != ""
+}
+
+// GetFeePayer returns the client paying the fee of the transaction, the fee
+// payer of a sponsored transaction and the issuing client otherwise.
+func (t *Transaction) GetFeePayer() string {
+	if t.IsSponsored() {
+		return t.FeePayerID
+	}
+	return t.ClientID
+}
+
+// FeePayerHash returns the digest signed by the fee payer, over the
+// transaction hash, which covers the fee payer id, and the fee, which the
+// transaction hash does not cover.
+func (t *Transaction) FeePayerHash() string {
+	return feePayerHash(t.Hash, t.Fee)
+}
+
+func feePayerHash(txnHash string, fee currency.Coin) string {
+	return encryption.Hash(txnHash + ":" + strconv.FormatUint(uint64(fee), 10))
+}
+
+// VerifyFeePayerSignature verifies the fee payer of a sponsored transaction
+// signed the fee payer hash, so the fee can't be raised once signed.
+func (t *Transaction) VerifyFeePayerSignature() error {
+	if t.FeePayerID == t.ClientID {
+		return common.InvalidRequest("fee payer should be different from the client")
+	}
+	if t.FeePayerPublicKey == "" || t.FeePayerSignature == "" {
+		return common.InvalidRequest("missing fee payer public key or signature")
+	}
+	if err := encryption.VerifyPublicKeyClientID(t.FeePayerPublicKey, t.FeePayerID); err != nil {
+		return common.InvalidRequest("fee payer: " + err.Error())
+	}
+
+	sigScheme := client.GetClientSignatureScheme()
+	if err := sigScheme.SetPublicKey(t.FeePayerPublicKey); err != nil {
+		return err
+	}
+	ok, err := sigScheme.Verify(t.FeePayerSignature, t.FeePayerHash())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return common.NewError("invalid_fee_payer_signature", "Invalid fee payer signature")
+	}
+	return nil
+}
+
+// SignAsFeePayer signs the transaction and its fee as the fee payer, the
+// transaction hash is computed over the fee payer id.
+func (t *Transaction) SignAsFeePayer(signatureScheme encryption.SignatureScheme) error {
+	if t.FeePayerID == "" {
+		return errors.New("transaction without fee payer")
+	}
+	t.FeePayerPublicKey = signatureScheme.GetPublicKey()
+	signature, err := signatureScheme.Sign(feePayerHash(t.ComputeHash(), t.Fee))
+	if err != nil {
+		return err
+	}
+	t.FeePayerSignature = signature
+	return nil
+}
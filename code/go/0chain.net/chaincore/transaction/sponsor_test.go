@@ -0,0 +1,74 @@
+package transaction
+
+import (
+	"context"
+	"testing"
+
+	"0chain.net/chaincore/client"
+	"0chain.net/core/encryption"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestScheme(t *testing.T) (encryption.SignatureScheme, string) {
+	scheme := encryption.NewBLS0ChainScheme()
+	require.NoError(t, scheme.GenerateKeys())
+	id, err := client.GetIDFromPublicKey(scheme.GetPublicKey())
+	require.NoError(t, err)
+	return scheme, id
+}
+
+func TestSponsoredTransaction(t *testing.T) {
+	var (
+		clientScheme, clientID = newTestScheme(t)
+		payerScheme, payerID   = newTestScheme(t)
+	)
+
+	txn := &Transaction{
+		ClientID:        clientID,
+		PublicKey:       clientScheme.GetPublicKey(),
+		ToClientID:      encryption.Hash("storage"),
+		TransactionType: TxnTypeSmartContract,
+		TransactionData: `{"name":"new_allocation_request","input":{}}`,
+		Fee:             10,
+		Nonce:           1,
+	}
+	require.False(t, txn.IsSponsored())
+	require.Equal(t, clientID, txn.GetFeePayer())
+	unsponsoredHash := txn.ComputeHash()
+
+	txn.FeePayerID = payerID
+	require.True(t, txn.IsSponsored())
+	require.Equal(t, payerID, txn.GetFeePayer())
+	require.NotEqual(t, unsponsoredHash, txn.ComputeHash(), "the hash covers the fee payer")
+
+	_, err := txn.Sign(clientScheme)
+	require.NoError(t, err)
+	require.Error(t, txn.VerifyFeePayerSignature(), "not signed by the fee payer")
+
+	require.NoError(t, txn.SignAsFeePayer(payerScheme))
+	require.NoError(t, txn.VerifyFeePayerSignature())
+
+	// the fee raised after signing
+	raised := txn.Clone()
+	raised.Fee = txn.Fee * 10
+	require.NoError(t, raised.VerifyHash(context.Background()), "the hash doesn't cover the fee")
+	require.Error(t, raised.VerifyFeePayerSignature())
+
+	// signed by another client
+	otherScheme, _ := newTestScheme(t)
+	signature, err := otherScheme.Sign(txn.FeePayerHash())
+	require.NoError(t, err)
+	forged := txn.Clone()
+	forged.FeePayerSignature = signature
+	require.Error(t, forged.VerifyFeePayerSignature())
+
+	// the public key of another client
+	forged = txn.Clone()
+	forged.FeePayerPublicKey = otherScheme.GetPublicKey()
+	require.Error(t, forged.VerifyFeePayerSignature())
+
+	// the client can't sponsor itself
+	self := txn.Clone()
+	self.FeePayerID = clientID
+	require.Error(t, self.VerifyFeePayerSignature())
+}
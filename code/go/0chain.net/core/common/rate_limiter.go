@@ -1,11 +1,20 @@
 package common
 
 import (
+	"math"
+	"net"
 	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/0chain/common/core/logging"
 	"github.com/didip/tollbooth"
 	"github.com/didip/tollbooth/limiter"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
 
 	"0chain.net/core/viper"
 )
@@ -16,7 +25,7 @@ type ratelimit struct {
 	RequestsPerSecond float64
 }
 
-var userRateLimit *ratelimit
+var userRateLimit *userRateLimiter
 var n2nRateLimit *ratelimit
 
 func (rl *ratelimit) init() {
@@ -30,24 +39,307 @@ func (rl *ratelimit) init() {
 		SetMethods([]string{"GET", "POST"})
 }
 
+const (
+	// DefaultAPIKeyHeader is the request header carrying the API key of a
+	// client.
+	DefaultAPIKeyHeader = "X-Api-Key"
+	// DefaultQuotaPeriod is the period of the tier quotas.
+	DefaultQuotaPeriod = 24 * time.Hour
+
+	// bucketTTL is how long the bucket of an idle client is kept.
+	bucketTTL = time.Hour
+)
+
+// RateLimitTier is a named set of limits shared by the clients of the API
+// keys of the tier. The limits are in cost units, a request costs the
+// weight of its endpoint.
+type RateLimitTier struct {
+	Name string
+	// Rate is the number of cost units refilled per second.
+	Rate float64
+	// Burst is the size of the bucket.
+	Burst int
+	// Quota is the number of cost units allowed per quota period, zero for
+	// no quota.
+	Quota       int64
+	QuotaPeriod time.Duration
+}
+
+func (t *RateLimitTier) limit() rate.Limit {
+	if t.Rate <= 0 {
+		return rate.Inf
+	}
+	return rate.Limit(t.Rate)
+}
+
+func (t *RateLimitTier) burst() int {
+	if t.Burst > 0 {
+		return t.Burst
+	}
+	return int(math.Max(1, t.Rate))
+}
+
+// UserRateLimits are the rate limits of the end user handlers. Clients
+// without an API key are limited by IP with the anonymous tier.
+type UserRateLimits struct {
+	Anonymous *RateLimitTier
+	Tiers     map[string]*RateLimitTier
+	// APIKeys maps the API keys to the names of their tiers.
+	APIKeys      map[string]string
+	APIKeyHeader string
+	// Weights of the endpoints by lower cased path or last segment of the
+	// path, the endpoints without weight cost 1.
+	Weights map[string]int
+}
+
+func (ul *UserRateLimits) weight(urlPath string) int {
+	urlPath = strings.ToLower(urlPath)
+	if w, ok := ul.Weights[urlPath]; ok {
+		return w
+	}
+	if w, ok := ul.Weights[path.Base(urlPath)]; ok {
+		return w
+	}
+	return 1
+}
+
+// userRateLimiter limits the end user requests by API key or by IP with a
+// token bucket per client.
+type userRateLimiter struct {
+	mu        sync.Mutex
+	limits    *UserRateLimits
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+type bucket struct {
+	tier        *RateLimitTier
+	limiter     *rate.Limiter
+	quotaUsed   int64
+	quotaReset  time.Time
+	lastRequest time.Time
+}
+
+// rateLimitStatus of a request, it is sent back in the RateLimit headers.
+type rateLimitStatus struct {
+	allowed   bool
+	limit     int64
+	remaining int64
+	reset     time.Duration
+}
+
+func newUserRateLimiter(limits *UserRateLimits) *userRateLimiter {
+	return &userRateLimiter{
+		limits:  limits,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// setLimits replaces the limits, the buckets of the clients are kept and
+// take the new limits of their tiers on their next request.
+func (ul *userRateLimiter) setLimits(limits *UserRateLimits) {
+	ul.mu.Lock()
+	defer ul.mu.Unlock()
+	ul.limits = limits
+}
+
+// tier returns the tier and the bucket key of the client of the request, it
+// fails for an unknown API key.
+func (ul *userRateLimiter) tier(r *http.Request) (*RateLimitTier, string, bool) {
+	limits := ul.limits
+	if key := r.Header.Get(limits.APIKeyHeader); key != "" {
+		t, ok := limits.Tiers[limits.APIKeys[key]]
+		if !ok {
+			return nil, "", false
+		}
+		return t, "key:" + key, true
+	}
+	return limits.Anonymous, "ip:" + remoteIP(r), true
+}
+
+// allow takes the cost of the request from the bucket of its client.
+func (ul *userRateLimiter) allow(r *http.Request, now time.Time) (*rateLimitStatus, bool) {
+	if ul == nil {
+		return nil, true
+	}
+	ul.mu.Lock()
+	defer ul.mu.Unlock()
+
+	t, key, ok := ul.tier(r)
+	if !ok {
+		return nil, false
+	}
+	if t == nil {
+		return nil, true
+	}
+	ul.sweep(now)
+
+	b := ul.buckets[key]
+	if b == nil {
+		b = &bucket{tier: t, limiter: rate.NewLimiter(t.limit(), t.burst())}
+		ul.buckets[key] = b
+	} else if b.tier != t {
+		b.tier = t
+		b.limiter.SetLimitAt(now, t.limit())
+		b.limiter.SetBurstAt(now, t.burst())
+	}
+	b.lastRequest = now
+
+	cost := ul.limits.weight(r.URL.Path)
+	if cost > t.burst() {
+		cost = t.burst()
+	}
+	s := &rateLimitStatus{limit: int64(t.burst())}
+
+	if t.Quota > 0 {
+		period := t.QuotaPeriod
+		if period <= 0 {
+			period = DefaultQuotaPeriod
+		}
+		if !now.Before(b.quotaReset) {
+			b.quotaUsed = 0
+			b.quotaReset = now.Add(period)
+		}
+		if b.quotaUsed+int64(cost) > t.Quota {
+			s.limit = t.Quota
+			s.remaining = t.Quota - b.quotaUsed
+			s.reset = b.quotaReset.Sub(now)
+			return s, true
+		}
+	}
+
+	if s.allowed = b.limiter.AllowN(now, cost); s.allowed {
+		b.quotaUsed += int64(cost)
+	}
+	tokens := b.limiter.TokensAt(now)
+	s.remaining = int64(math.Max(0, math.Floor(tokens)))
+	if t.Rate > 0 {
+		missing := float64(t.burst()) - tokens
+		if !s.allowed {
+			missing = float64(cost) - tokens
+		}
+		s.reset = time.Duration(math.Max(0, missing) / t.Rate * float64(time.Second))
+	}
+	if t.Quota > 0 && t.Quota-b.quotaUsed < s.remaining {
+		s.limit = t.Quota
+		s.remaining = t.Quota - b.quotaUsed
+		s.reset = b.quotaReset.Sub(now)
+	}
+	return s, true
+}
+
+// sweep drops the buckets of the clients idle for a while.
+func (ul *userRateLimiter) sweep(now time.Time) {
+	if now.Sub(ul.lastSweep) < bucketTTL {
+		return
+	}
+	ul.lastSweep = now
+	for key, b := range ul.buckets {
+		if now.Sub(b.lastRequest) > bucketTTL && !now.Before(b.quotaReset) {
+			delete(ul.buckets, key)
+		}
+	}
+}
+
+func (s *rateLimitStatus) setHeaders(h http.Header) {
+	reset := int64(math.Ceil(s.reset.Seconds()))
+	h.Set("RateLimit-Limit", strconv.FormatInt(s.limit, 10))
+	h.Set("RateLimit-Remaining", strconv.FormatInt(s.remaining, 10))
+	h.Set("RateLimit-Reset", strconv.FormatInt(reset, 10))
+	if !s.allowed {
+		h.Set("Retry-After", strconv.FormatInt(reset, 10))
+	}
+}
+
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// readUserRateLimits reads the rate limits of the end user handlers from
+// the network.user_handlers settings.
+func readUserRateLimits(v *viper.Viper) *UserRateLimits {
+	const prefix = "network.user_handlers."
+	limits := &UserRateLimits{
+		Tiers:        make(map[string]*RateLimitTier),
+		APIKeys:      make(map[string]string),
+		APIKeyHeader: v.GetString(prefix + "api_key_header"),
+		Weights:      v.GetStringMapInt(prefix + "weights"),
+	}
+	if limits.APIKeyHeader == "" {
+		limits.APIKeyHeader = DefaultAPIKeyHeader
+	}
+	if rps := v.GetFloat64(prefix + "rate_limit"); rps > 0 {
+		limits.Anonymous = &RateLimitTier{
+			Name:  "anonymous",
+			Rate:  rps,
+			Burst: v.GetInt(prefix + "burst"),
+		}
+	}
+	for name := range v.GetStringMap(prefix + "tiers") {
+		key := prefix + "tiers." + name + "."
+		limits.Tiers[name] = &RateLimitTier{
+			Name:        name,
+			Rate:        v.GetFloat64(key + "rate_limit"),
+			Burst:       v.GetInt(key + "burst"),
+			Quota:       v.GetInt64(key + "quota"),
+			QuotaPeriod: v.GetDuration(key + "quota_period"),
+		}
+		// the API keys are listed rather than mapped, the map keys are
+		// lower cased by viper
+		for _, apiKey := range v.GetStringSlice(key + "api_keys") {
+			limits.APIKeys[apiKey] = name
+		}
+	}
+	return limits
+}
+
 // ConfigRateLimits - configure the rate limits
 func ConfigRateLimits() {
-	userRl := viper.GetFloat64("network.user_handlers.rate_limit")
-	userRateLimit = &ratelimit{RequestsPerSecond: userRl}
-	userRateLimit.init()
+	userRateLimit = newUserRateLimiter(readUserRateLimits(viper.GetViper()))
 
 	n2nRl := viper.GetFloat64("network.n2n_handlers.rate_limit")
 	n2nRateLimit = &ratelimit{RequestsPerSecond: n2nRl}
 	n2nRateLimit.init()
 }
 
-// UserRateLimit - rate limiting for end user handlers
+// ReloadRateLimits applies the changes of the rate limits of the end user
+// handlers, the API keys and the tiers, the buckets of the clients are kept.
+func ReloadRateLimits() {
+	limits := readUserRateLimits(viper.GetViper())
+	userRateLimit.setLimits(limits)
+	logging.Logger.Info("reloaded rate limits",
+		zap.Int("tiers", len(limits.Tiers)),
+		zap.Int("api_keys", len(limits.APIKeys)))
+}
+
+// UserRateLimit - rate limiting for end user handlers, by API key or by IP
+// for the clients without one
 func UserRateLimit(handler ReqRespHandlerf) ReqRespHandlerf {
-	if !userRateLimit.RateLimit {
-		return Recover(handler)
-	}
+	handler = Recover(handler)
 	return func(writer http.ResponseWriter, request *http.Request) {
-		tollbooth.LimitFuncHandler(userRateLimit.Limiter, Recover(handler)).ServeHTTP(writer, request)
+		if request.Method != http.MethodGet && request.Method != http.MethodPost {
+			handler(writer, request)
+			return
+		}
+		s, ok := userRateLimit.allow(request, time.Now())
+		if !ok {
+			errorAny(writer, http.StatusUnauthorized, "invalid API key")
+			return
+		}
+		if s == nil {
+			handler(writer, request)
+			return
+		}
+		s.setHeaders(writer.Header())
+		if !s.allowed {
+			errorAny(writer, http.StatusTooManyRequests, "You have reached maximum request limit.")
+			return
+		}
+		handler(writer, request)
 	}
 }
 
@@ -3,10 +3,14 @@ package common
 import (
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"0chain.net/core/viper"
 )
@@ -40,10 +44,9 @@ func TestUserRateLimit(t *testing.T) {
 			userRL: 1.0,
 			want: func() http.ResponseWriter {
 				w := httptest.NewRecorder()
-				w.Header().Set("X-Rate-Limit-Limit", "1.00")
-				w.Header().Set("X-Rate-Limit-Duration", "1")
-				w.Header().Set("X-Rate-Limit-Request-Forwarded-For", "")
-				w.Header().Set("X-Rate-Limit-Request-Remote-Addr", "192.0.2.1:1234")
+				w.Header().Set("RateLimit-Limit", "1")
+				w.Header().Set("RateLimit-Remaining", "0")
+				w.Header().Set("RateLimit-Reset", "1")
 				w.Body = nil
 
 				return w
@@ -57,6 +60,7 @@ func TestUserRateLimit(t *testing.T) {
 
 			w := httptest.NewRecorder()
 			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = "192.0.2.2:1234"
 			handler := UserRateLimit(tt.args.handler)
 			handler(w, r)
 			w.Body = nil
@@ -109,3 +113,122 @@ func TestN2NRateLimit(t *testing.T) {
 		})
 	}
 }
+
+const testRateLimitsConfig = `
+network:
+  user_handlers:
+    rate_limit: 2
+    tiers:
+      partner:
+        rate_limit: 10
+        burst: 20
+        quota: 30
+        quota_period: 1h
+        api_keys:
+          - Partner-Key
+    weights:
+      /v1/client/get/balance: 1
+      getBlobbers: 5
+`
+
+func TestUserRateLimiter(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "0chain.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(testRateLimitsConfig), 0644))
+	v := viper.New()
+	require.NoError(t, v.ReadConfigFile(file))
+	limits := readUserRateLimits(v)
+	require.Equal(t, DefaultAPIKeyHeader, limits.APIKeyHeader)
+	require.Equal(t, map[string]string{"Partner-Key": "partner"}, limits.APIKeys)
+	require.Equal(t, &RateLimitTier{Name: "anonymous", Rate: 2}, limits.Anonymous)
+
+	ul := newUserRateLimiter(limits)
+	now := time.Now()
+	request := func(target, remoteAddr, apiKey string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, target, nil)
+		r.RemoteAddr = remoteAddr
+		if apiKey != "" {
+			r.Header.Set(DefaultAPIKeyHeader, apiKey)
+		}
+		return r
+	}
+
+	// clients without a key are limited by IP
+	s, ok := ul.allow(request("/v1/client/get/balance", "192.0.2.1:1", ""), now)
+	require.True(t, ok)
+	require.Equal(t, &rateLimitStatus{allowed: true, limit: 2, remaining: 1, reset: 500 * time.Millisecond}, s)
+	s, _ = ul.allow(request("/v1/client/get/balance", "192.0.2.1:2", ""), now)
+	require.True(t, s.allowed)
+	s, _ = ul.allow(request("/v1/client/get/balance", "192.0.2.1:3", ""), now)
+	require.False(t, s.allowed)
+	require.Equal(t, 500*time.Millisecond, s.reset)
+	s, _ = ul.allow(request("/v1/client/get/balance", "192.0.2.2:1", ""), now)
+	require.True(t, s.allowed)
+
+	// the clients of a key share its bucket, whatever their IP
+	s, _ = ul.allow(request("/v1/screst/sc/getblobbers", "192.0.2.1:1", "Partner-Key"), now)
+	require.Equal(t, &rateLimitStatus{allowed: true, limit: 20, remaining: 15, reset: 500 * time.Millisecond}, s)
+	s, _ = ul.allow(request("/v1/screst/sc/getblobbers", "192.0.2.3:1", "Partner-Key"), now)
+	require.Equal(t, int64(10), s.remaining)
+	s, _ = ul.allow(request("/v1/screst/sc/getblobbers", "192.0.2.3:1", "Partner-Key"), now)
+	require.Equal(t, int64(5), s.remaining)
+
+	// the quota is closer than the bucket
+	s, _ = ul.allow(request("/v1/screst/sc/getblobbers", "192.0.2.3:1", "Partner-Key"), now.Add(2*time.Second))
+	require.Equal(t, &rateLimitStatus{allowed: true, limit: 30, remaining: 10, reset: time.Hour - 2*time.Second}, s)
+	s, _ = ul.allow(request("/v1/screst/sc/getblobbers", "192.0.2.3:1", "Partner-Key"), now.Add(2*time.Second))
+	require.True(t, s.allowed)
+	s, _ = ul.allow(request("/v1/screst/sc/getblobbers", "192.0.2.3:1", "Partner-Key"), now.Add(2*time.Second))
+	require.True(t, s.allowed)
+	s, _ = ul.allow(request("/v1/client/get/balance", "192.0.2.3:1", "Partner-Key"), now.Add(3*time.Second))
+	require.Equal(t, &rateLimitStatus{allowed: false, limit: 30, remaining: 0, reset: time.Hour - 3*time.Second}, s)
+	s, _ = ul.allow(request("/v1/client/get/balance", "192.0.2.3:1", "Partner-Key"), now.Add(time.Hour))
+	require.True(t, s.allowed)
+
+	// unknown keys are refused
+	_, ok = ul.allow(request("/v1/client/get/balance", "192.0.2.1:1", "partner-key"), now)
+	require.False(t, ok)
+
+	// reloaded limits apply to the existing buckets
+	limits = readUserRateLimits(v)
+	limits.Tiers["partner"].Quota = 0
+	limits.Anonymous = nil
+	ul.setLimits(limits)
+	s, _ = ul.allow(request("/v1/screst/sc/getblobbers", "192.0.2.3:1", "Partner-Key"), now.Add(time.Hour))
+	require.Equal(t, &rateLimitStatus{allowed: true, limit: 20, remaining: 14, reset: 600 * time.Millisecond}, s)
+	s, _ = ul.allow(request("/v1/client/get/balance", "192.0.2.1:1", ""), now)
+	require.Nil(t, s)
+}
+
+func TestUserRateLimitHeaders(t *testing.T) {
+	userRateLimit.setLimits(&UserRateLimits{
+		Anonymous:    &RateLimitTier{Rate: 1, Burst: 1},
+		APIKeyHeader: DefaultAPIKeyHeader,
+	})
+	t.Cleanup(func() {
+		viper.Set("network.user_handlers.rate_limit", 1.0)
+		ConfigRateLimits()
+	})
+
+	var calls int
+	handler := UserRateLimit(func(w http.ResponseWriter, r *http.Request) { calls++ })
+	serve := func(apiKey string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/v1/client/get/balance", nil)
+		r.RemoteAddr = "192.0.2.10:1234"
+		if apiKey != "" {
+			r.Header.Set(DefaultAPIKeyHeader, apiKey)
+		}
+		handler(w, r)
+		return w
+	}
+
+	w := serve("")
+	require.Equal(t, http.StatusOK, w.Code)
+	w = serve("")
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	require.Equal(t, "1", w.Header().Get("Retry-After"))
+	require.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+	w = serve("unknown")
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+	require.Equal(t, 1, calls)
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"0chain.net/core/common"
+	"0chain.net/core/viper"
+)
+
+// IsAdminRequest reports whether the request carries the admin token of the
+// node as a bearer token, there is no admin without a token configured.
+func IsAdminRequest(r *http.Request) bool {
+	token := viper.GetString("admin.token")
+	if token == "" {
+		return false
+	}
+	bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) == 1
+}
+
+// AdminOnly rejects the requests without the admin token of the node.
+func AdminOnly(handler common.ReqRespHandlerf) common.ReqRespHandlerf {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !IsAdminRequest(r) {
+			http.Error(w, "401 Unauthorized - invalid admin token", http.StatusUnauthorized)
+			return
+		}
+		handler(w, r)
+	}
+}
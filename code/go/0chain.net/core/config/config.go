@@ -97,17 +97,28 @@ func SetupDefaultConfig() {
 	viper.SetDefault("kafka.write_timeout", 10*time.Second) // seconds
 }
 
-// SetupConfig setups the main configuration system.
-func SetupConfig(workdir string) {
-	file := filepath.Join(".", "config", "0chain.yaml")
-
+// NodeConfigFile returns the path of the main configuration file.
+func NodeConfigFile(workdir string) string {
 	if len(workdir) > 0 {
-		file = filepath.Join(workdir, "config", "0chain.yaml")
+		return filepath.Join(workdir, "config", "0chain.yaml")
 	}
+	return filepath.Join(".", "config", "0chain.yaml")
+}
+
+// SetupConfig setups the main configuration system.
+func SetupConfig(workdir string) {
+	file := NodeConfigFile(workdir)
 
 	if err := viper.ReadConfigFile(file); err != nil {
 		panic(fmt.Errorf("fatal error config file: %s", err))
 	}
+	if err := Validate(viper.GetViper()); err != nil {
+		panic(fmt.Errorf("fatal error config file: %s", err))
+	}
+	if err := viper.BindEnv("admin.token", "ADMIN_TOKEN"); err != nil {
+		panic(fmt.Errorf("fatal error config file: %s", err))
+	}
+	configFile = file
 	setupDevConfig()
 }
 
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/0chain/common/core/logging"
+	"go.uber.org/zap"
+
+	"0chain.net/core/viper"
+)
+
+// ReloadResult lists the settings changed by a reload.
+type ReloadResult struct {
+	// Applied are the reloadable settings that changed.
+	Applied []string `json:"applied"`
+	// RestartRequired are the changed settings that are not applied until
+	// the node restarts.
+	RestartRequired []string `json:"restart_required"`
+}
+
+type reloadHook struct {
+	prefix string
+	fn     func()
+}
+
+var (
+	reloadMu    sync.Mutex
+	reloadHooks []reloadHook
+	configFile  string
+)
+
+// OnReload registers a function called after a reload applied a change of
+// the settings under the given key.
+func OnReload(key string, fn func()) {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+	reloadHooks = append(reloadHooks, reloadHook{prefix: key, fn: fn})
+}
+
+// Reload reads the node config file again and applies the reloadable
+// settings that changed. Nothing is applied when the file is not valid.
+func Reload() (*ReloadResult, error) {
+	v := viper.New()
+	if err := v.ReadConfigFile(configFile); err != nil {
+		return nil, fmt.Errorf("read config file: %v", err)
+	}
+	return reload(v)
+}
+
+func reload(v *viper.Viper) (*ReloadResult, error) {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+
+	if err := Validate(v); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]bool)
+	for _, k := range v.AllKeys() {
+		keys[k] = true
+	}
+	for _, k := range viper.AllKeys() {
+		if viper.InConfig(k) {
+			keys[k] = true
+		}
+	}
+
+	applied := make(map[string]bool)
+	restart := make(map[string]bool)
+	for k := range keys {
+		if fmt.Sprint(v.Get(k)) == fmt.Sprint(viper.Get(k)) {
+			continue
+		}
+		s, ok := lookup(k)
+		switch {
+		case ok && s.Reloadable && (v.IsSet(k) || s.Key != k):
+			applied[s.Key] = true
+		default:
+			restart[k] = true
+		}
+	}
+
+	result := &ReloadResult{
+		Applied:         sortedKeys(applied),
+		RestartRequired: sortedKeys(restart),
+	}
+	for _, k := range result.Applied {
+		viper.Set(k, v.Get(k))
+	}
+	for _, h := range reloadHooks {
+		for _, k := range result.Applied {
+			if k == h.prefix || strings.HasPrefix(k, h.prefix+".") || strings.HasPrefix(h.prefix, k+".") {
+				h.fn()
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// WatchReloadSignal reloads the node config on SIGHUP.
+func WatchReloadSignal(ctx context.Context) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGHUP)
+	defer signal.Stop(ch)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ch:
+			result, err := Reload()
+			if err != nil {
+				logging.Logger.Error("reload config", zap.Error(err))
+				continue
+			}
+			logging.Logger.Info("reloaded config",
+				zap.Strings("applied", result.Applied),
+				zap.Strings("restart_required", result.RestartRequired))
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"0chain.net/core/viper"
+)
+
+const testNodeConfig = `
+logging:
+  level: info
+network:
+  relay_time: 200
+  user_handlers:
+    rate_limit: 10
+    tiers:
+      partner:
+        rate_limit: 100
+        api_keys:
+          - partner-key
+server_chain:
+  tokens: 200000000
+  dbs:
+    events:
+      password: db-password
+  kafka:
+    password: kafka-password
+`
+
+func writeTestConfig(t *testing.T, content string) string {
+	file := filepath.Join(t.TempDir(), "0chain.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(content), 0644))
+	return file
+}
+
+func readTestConfig(t *testing.T, content string) *viper.Viper {
+	v := viper.New()
+	require.NoError(t, v.ReadConfigFile(writeTestConfig(t, content)))
+	return v
+}
+
+func TestValidate(t *testing.T) {
+	require.NoError(t, Validate(readTestConfig(t, testNodeConfig)))
+
+	err := Validate(readTestConfig(t, `
+logging:
+  level: verbose
+network:
+  relay_time: 0
+  user_handlers:
+    rate_limit: fast
+`))
+	require.EqualError(t, err, `invalid config: logging.level: "verbose" is not one of debug, info, warn, error, dpanic, panic, fatal; `+
+		`network.relay_time: 0 is less than 1; `+
+		`network.user_handlers.rate_limit: unable to cast "fast" of type string to float64`)
+}
+
+func TestRedactedSettings(t *testing.T) {
+	require.NoError(t, viper.ReadConfigFile(writeTestConfig(t, testNodeConfig)))
+
+	require.True(t, IsSecret("server_chain.dbs.events.password"))
+	require.True(t, IsSecret("network.user_handlers.tiers.partner.api_keys"))
+	require.True(t, IsSecret("admin.token"))
+	require.False(t, IsSecret("server_chain.tokens"))
+	require.False(t, IsSecret("network.user_handlers.api_key_header"))
+
+	settings := RedactedSettings()
+	sc := settings["server_chain"].(map[string]interface{})
+	require.Equal(t, RedactedValue, sc["dbs"].(map[string]interface{})["events"].(map[string]interface{})["password"])
+	require.Equal(t, RedactedValue, sc["kafka"].(map[string]interface{})["password"])
+	require.Equal(t, 200000000, sc["tokens"])
+	tiers := settings["network"].(map[string]interface{})["user_handlers"].(map[string]interface{})["tiers"]
+	require.Equal(t, RedactedValue, tiers.(map[string]interface{})["partner"].(map[string]interface{})["api_keys"])
+	require.Equal(t, "db-password", viper.GetString("server_chain.dbs.events.password"))
+}
+
+func TestReload(t *testing.T) {
+	require.NoError(t, viper.ReadConfigFile(writeTestConfig(t, testNodeConfig)))
+
+	var rateLimits, logLevel int
+	OnReload("network.user_handlers", func() { rateLimits++ })
+	OnReload("logging.level", func() { logLevel++ })
+	t.Cleanup(func() { reloadHooks = nil })
+
+	// nothing changed
+	result, err := reload(readTestConfig(t, testNodeConfig))
+	require.NoError(t, err)
+	require.Empty(t, result.Applied)
+	require.Empty(t, result.RestartRequired)
+
+	result, err = reload(readTestConfig(t, `
+logging:
+  level: debug
+network:
+  relay_time: 100
+  user_handlers:
+    rate_limit: 10
+    tiers:
+      partner:
+        rate_limit: 200
+        api_keys:
+          - partner-key
+      scraper:
+        rate_limit: 1
+server_chain:
+  tokens: 200000000
+  dbs:
+    events:
+      password: db-password
+  kafka:
+    password: kafka-password
+`))
+	require.NoError(t, err)
+	require.Equal(t, []string{"logging.level", "network.user_handlers.tiers"}, result.Applied)
+	require.Equal(t, []string{"network.relay_time"}, result.RestartRequired)
+	require.Equal(t, 1, rateLimits)
+	require.Equal(t, 1, logLevel)
+	require.Equal(t, "debug", viper.GetString("logging.level"))
+	require.Equal(t, 200.0, viper.GetFloat64("network.user_handlers.tiers.partner.rate_limit"))
+	require.Equal(t, 1.0, viper.GetFloat64("network.user_handlers.tiers.scraper.rate_limit"))
+	require.Equal(t, 200, viper.GetInt("network.relay_time"))
+
+	// an invalid config is not applied
+	_, err = reload(readTestConfig(t, `
+logging:
+  level: loud
+`))
+	require.Error(t, err)
+	require.Equal(t, "debug", viper.GetString("logging.level"))
+	require.Equal(t, 1, logLevel)
+}
@@ -0,0 +1,208 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cast"
+
+	"0chain.net/core/viper"
+)
+
+// Kind of the value of a node setting.
+type Kind int
+
+const (
+	KindBool Kind = iota
+	KindInt
+	KindFloat
+	KindDuration
+	KindString
+	KindStringSlice
+	KindMap
+)
+
+// Setting is a typed node setting of 0chain.yaml. A setting of the map
+// kind covers all the keys below it.
+type Setting struct {
+	Key  string
+	Kind Kind
+	// Min is the minimum of a numeric setting.
+	Min float64
+	// OneOf lists the values allowed for a string setting.
+	OneOf []string
+	// Reloadable settings are applied by a reload, the others need a
+	// restart.
+	Reloadable bool
+	// Secret settings are redacted in the config views.
+	Secret bool
+}
+
+// Schema of the node settings, the settings not in the schema are not
+// validated and need a restart to change.
+var Schema = []Setting{
+	{Key: "logging.level", Kind: KindString, Reloadable: true,
+		OneOf: []string{"debug", "info", "warn", "error", "dpanic", "panic", "fatal"}},
+	{Key: "logging.verbose", Kind: KindBool},
+	{Key: "logging.console", Kind: KindBool},
+
+	{Key: "network.relay_time", Kind: KindInt, Min: 1},
+	{Key: "network.max_concurrent_requests", Kind: KindInt, Min: 1},
+	{Key: "network.timeout.small_message", Kind: KindInt, Min: 1},
+	{Key: "network.timeout.large_message", Kind: KindInt, Min: 1},
+	{Key: "network.large_message_th_size", Kind: KindInt, Min: 1},
+	{Key: "network.n2n_handlers.rate_limit", Kind: KindFloat},
+	{Key: "network.user_handlers.rate_limit", Kind: KindFloat, Reloadable: true},
+	{Key: "network.user_handlers.burst", Kind: KindInt, Reloadable: true},
+	{Key: "network.user_handlers.api_key_header", Kind: KindString, Reloadable: true},
+	{Key: "network.user_handlers.tiers", Kind: KindMap, Reloadable: true},
+	{Key: "network.user_handlers.weights", Kind: KindMap, Reloadable: true},
+	{Key: "network.user_handlers.cache.enabled", Kind: KindBool},
+	{Key: "network.user_handlers.cache.max_entries", Kind: KindInt},
+	{Key: "network.user_handlers.cache.max_entry_size", Kind: KindInt},
+	{Key: "network.user_handlers.cache.ttl", Kind: KindDuration},
+	{Key: "network.user_handlers.cache.endpoints", Kind: KindMap},
+
+	{Key: "server_chain.dbs.events.user", Kind: KindString, Secret: true},
+	{Key: "server_chain.dbs.events.password", Kind: KindString, Secret: true},
+	{Key: "server_chain.dbs.events.max_idle_conns", Kind: KindInt},
+	{Key: "server_chain.dbs.events.max_open_conns", Kind: KindInt},
+	{Key: "server_chain.dbs.events.conn_max_lifetime", Kind: KindDuration},
+	{Key: "server_chain.kafka.username", Kind: KindString, Secret: true},
+	{Key: "server_chain.kafka.password", Kind: KindString, Secret: true},
+	{Key: "kafka.enabled", Kind: KindBool},
+	{Key: "kafka.username", Kind: KindString, Secret: true},
+	{Key: "kafka.password", Kind: KindString, Secret: true},
+	{Key: "kafka.write_timeout", Kind: KindDuration},
+	{Key: "kafka.trigger_round", Kind: KindInt, Min: 0},
+
+	{Key: "storage.cache.total_blocks", Kind: KindInt, Min: 1, Reloadable: true},
+
+	{Key: "admin.token", Kind: KindString, Secret: true},
+}
+
+// secretWords mark the keys of the settings not in the schema that are
+// redacted anyway, when they are a word of the last segment of the key.
+var secretWords = []string{"password", "secret", "token", "credentials", "keys", "private"}
+
+// RedactedValue replaces the values of the secret settings.
+const RedactedValue = "[redacted]"
+
+// lookup returns the schema setting covering the key.
+func lookup(key string) (Setting, bool) {
+	for _, s := range Schema {
+		if s.Key == key || (s.Kind == KindMap && strings.HasPrefix(key, s.Key+".")) {
+			return s, true
+		}
+	}
+	return Setting{}, false
+}
+
+// IsSecret reports whether the setting of the key is a secret.
+func IsSecret(key string) bool {
+	if s, ok := lookup(key); ok && s.Secret {
+		return true
+	}
+	name := key[strings.LastIndex(key, ".")+1:]
+	for _, word := range strings.Split(name, "_") {
+		if contains(secretWords, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// validate checks the value of a setting against its schema.
+func (s *Setting) validate(v *viper.Viper) error {
+	if !v.IsSet(s.Key) {
+		return nil
+	}
+	raw := v.Get(s.Key)
+
+	var (
+		num float64
+		err error
+	)
+	switch s.Kind {
+	case KindBool:
+		_, err = cast.ToBoolE(raw)
+	case KindInt:
+		var i int64
+		i, err = cast.ToInt64E(raw)
+		num = float64(i)
+	case KindFloat:
+		num, err = cast.ToFloat64E(raw)
+	case KindDuration:
+		var d time.Duration
+		d, err = cast.ToDurationE(raw)
+		num = float64(d)
+	case KindString:
+		var str string
+		str, err = cast.ToStringE(raw)
+		if err == nil && len(s.OneOf) > 0 && !contains(s.OneOf, str) {
+			err = fmt.Errorf("%q is not one of %s", str, strings.Join(s.OneOf, ", "))
+		}
+	case KindStringSlice:
+		_, err = cast.ToStringSliceE(raw)
+	case KindMap:
+		if raw != nil {
+			_, err = cast.ToStringMapE(raw)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %v", s.Key, err)
+	}
+	if num < s.Min {
+		return fmt.Errorf("%s: %v is less than %v", s.Key, raw, s.Min)
+	}
+	return nil
+}
+
+func contains(values []string, v string) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the node settings against the schema.
+func Validate(v *viper.Viper) error {
+	var errs []string
+	for i := range Schema {
+		if err := Schema[i].validate(v); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid config: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// RedactedSettings returns all the node settings with the values of the
+// secrets replaced.
+func RedactedSettings() map[string]interface{} {
+	return redact("", viper.AllSettings()).(map[string]interface{})
+}
+
+func redact(key string, value interface{}) interface{} {
+	if key != "" && IsSecret(key) {
+		return RedactedValue
+	}
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return value
+	}
+	out := make(map[string]interface{}, len(m))
+	for name, v := range m {
+		full := name
+		if key != "" {
+			full = key + "." + name
+		}
+		out[name] = redact(full, v)
+	}
+	return out
+}
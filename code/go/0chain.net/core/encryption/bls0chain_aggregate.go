@@ -73,3 +73,74 @@ func (b0a BLS0ChainAggregateSignatureScheme) Verify() (bool, error) {
 	}
 	return true, nil
 }
+
+//BLS0ChainAggregateVerifier - verifies a BLS0Chain signature aggregated by a third party.
+//Public keys of the signers of the same hash are added up, so a common message
+//costs one pairing no matter how many signers signed it.
+type BLS0ChainAggregateVerifier struct {
+	hashes  []string
+	pubKeys map[string]*bls.PublicKey
+}
+
+//NewBLS0ChainAggregateVerifier - create a new instance
+func NewBLS0ChainAggregateVerifier() *BLS0ChainAggregateVerifier {
+	return &BLS0ChainAggregateVerifier{pubKeys: make(map[string]*bls.PublicKey)}
+}
+
+//AddSigner - implement interface
+func (b0v *BLS0ChainAggregateVerifier) AddSigner(ss SignatureScheme, hash string) error {
+	b0sig, ok := ss.(*BLS0ChainScheme)
+	if !ok {
+		return ErrInvalidSignatureScheme
+	}
+	pk := b0sig.GetBLSPublicKey()
+	if pk == nil {
+		return errors.New("public key is nil")
+	}
+
+	if apk, ok := b0v.pubKeys[hash]; ok {
+		apk.Add(pk)
+		return nil
+	}
+
+	apk := *pk
+	b0v.pubKeys[hash] = &apk
+	b0v.hashes = append(b0v.hashes, hash)
+	return nil
+}
+
+//Verify - implement interface
+func (b0v *BLS0ChainAggregateVerifier) Verify(signature string) (bool, error) {
+	if len(b0v.hashes) == 0 {
+		return false, errors.New("no signers to verify the aggregate signature against")
+	}
+
+	asig, err := (&BLS0ChainScheme{}).GetSignature(signature)
+	if err != nil {
+		return false, err
+	}
+
+	var agtmul *bls.GT
+	for _, hash := range b0v.hashes {
+		gt, err := (&BLS0ChainScheme{pubKey: b0v.pubKeys[hash]}).PairMessageHash(hash)
+		if err != nil {
+			return false, err
+		}
+		if agtmul == nil {
+			agtmul = gt
+		} else {
+			bls.GTMul(agtmul, agtmul, gt)
+		}
+	}
+
+	var agg bls.GT
+	var asigG1 bls.G1
+	if err := asigG1.Deserialize(asig.Serialize()); err != nil {
+		return false, err
+	}
+	bls.Pairing(&agg, &asigG1, GenG2)
+	if !agg.IsEqual(agtmul) {
+		return false, errors.New("aggregate signature validation failed")
+	}
+	return true, nil
+}
@@ -291,3 +291,50 @@ func TestBLS0ChainAggregateSignatureScheme_Aggregate(t *testing.T) {
 		})
 	}
 }
+
+func TestBLS0ChainAggregateVerifier(t *testing.T) {
+	total := 10
+	passHash := Hash("challenge:blobber:true")
+	failHash := Hash("challenge:blobber:false")
+
+	sigSchemes := make([]SignatureScheme, total)
+	hashes := make([]string, total)
+	signatures := make([]string, total)
+	for i := 0; i < total; i++ {
+		sigSchemes[i] = GetSignatureScheme(SignatureSchemeBls0chain)
+		require.NoError(t, sigSchemes[i].GenerateKeys())
+
+		hashes[i] = passHash
+		if i%3 == 0 {
+			hashes[i] = failHash
+		}
+
+		sig, err := sigSchemes[i].Sign(hashes[i])
+		require.NoError(t, err)
+		signatures[i] = sig
+	}
+
+	aggSig, err := sigSchemes[0].(*BLS0ChainScheme).AggregateSignatures(signatures)
+	require.NoError(t, err)
+
+	verifier := func(n int) *BLS0ChainAggregateVerifier {
+		v := NewBLS0ChainAggregateVerifier()
+		for i := 0; i < n; i++ {
+			pub := GetSignatureScheme(SignatureSchemeBls0chain)
+			require.NoError(t, pub.SetPublicKey(sigSchemes[i].GetPublicKey()))
+			require.NoError(t, v.AddSigner(pub, hashes[i]))
+		}
+		return v
+	}
+
+	ok, err := verifier(total).Verify(aggSig)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = verifier(total - 1).Verify(aggSig)
+	require.Error(t, err)
+	require.False(t, ok)
+
+	_, err = NewBLS0ChainAggregateVerifier().Verify(aggSig)
+	require.Error(t, err)
+}
@@ -70,11 +70,7 @@ func main() {
 	config.SetupSmartContractConfig(workdir)
 	initIntegrationsTests()
 
-	if config.Development() {
-		logging.InitLogging("development", workdir)
-	} else {
-		logging.InitLogging("production", workdir)
-	}
+	initLogging(workdir)
 
 	config.Configuration().ChainID = viper.GetString("server_chain.id")
 	transaction.SetTxnTimeout(int64(viper.GetInt("server_chain.transaction.timeout")))
@@ -219,6 +215,9 @@ func main() {
 	mux := http.NewServeMux()
 	http.DefaultServeMux = mux
 	common.ConfigRateLimits()
+	config.OnReload("network.user_handlers", common.ReloadRateLimits)
+	config.OnReload("logging.level", func() { initLogging(workdir) })
+	go config.WatchReloadSignal(ctx)
 
 	if config.Development() {
 		if viper.GetBool("development.pprof") {
@@ -550,3 +549,11 @@ func initProfHandlers(mux *http.ServeMux) {
 	mux.HandleFunc("/debug/pprof/symbol", common.UserRateLimit(pprof.Symbol))
 	mux.HandleFunc("/debug/pprof/trace", common.UserRateLimit(pprof.Trace))
 }
+
+func initLogging(workdir string) {
+	if config.Development() {
+		logging.InitLogging("development", workdir)
+	} else {
+		logging.InitLogging("production", workdir)
+	}
+}
@@ -3,6 +3,7 @@ package miner
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
@@ -20,11 +21,13 @@ import (
 	"0chain.net/chaincore/chain"
 	"0chain.net/chaincore/client"
 	"0chain.net/chaincore/node"
+	"0chain.net/chaincore/smartcontract"
 	"0chain.net/chaincore/transaction"
 	"0chain.net/core/common"
 	"0chain.net/core/datastore"
 	"0chain.net/core/encryption"
 	"0chain.net/smartcontract/minersc"
+	"0chain.net/smartcontract/schedulersc"
 	"0chain.net/smartcontract/storagesc"
 	"github.com/0chain/common/core/logging"
 	"github.com/0chain/common/core/statecache"
@@ -41,6 +44,9 @@ var (
 	ErrNotTimeTolerant = common.NewError("not_time_tolerant", "transaction is behind time tolerance")
 	FutureTransaction  = common.NewError("future_transaction", "transaction has future nonce")
 	PastTransaction    = common.NewError("past_transaction", "transaction has past nonce")
+
+	ErrFeePayerInsufficientBalance = common.NewError("fee_payer_insufficient_balance",
+		"fee payer balance not sufficient for the fee")
 )
 var (
 	bgTimer     metrics.Timer // block generation timer
@@ -140,11 +146,153 @@ func (mc *Chain) createBlockRewardTxn(b *block.Block) (*transaction.Transaction,
 	return brTxn, nil
 }
 
+// createExecuteScheduledTxns creates the transactions executing, or refunding
+// when expired, the scheduled calls due in the block. They are read from the
+// state of the previous block, not to pick the calls executed by the blocks
+// not finalized yet again.
+func (mc *Chain) createExecuteScheduledTxns(b *block.Block) ([]*transaction.Transaction, error) {
+	pb := b.PrevBlock
+	if pb == nil || pb.ClientState == nil {
+		return nil, nil
+	}
+
+	var (
+		qbc         = statecache.NewQueryBlockCache(mc.GetStateCache(), pb.Hash)
+		clientState = chain.CreateTxnMPT(pb.ClientState, statecache.NewTransactionCache(qbc))
+		sctx        = mc.NewStateContext(pb, clientState, &transaction.Transaction{}, nil)
+	)
+	ids, err := schedulersc.GetDueCalls(sctx, b.Round, b.CreationDate)
+	if err != nil {
+		return nil, err
+	}
+
+	txns := make([]*transaction.Transaction, 0, len(ids))
+	for _, id := range ids {
+		esTxn := transaction.Provider().(*transaction.Transaction)
+		esTxn.ClientID = node.Self.ID
+		esTxn.PublicKey = node.Self.PublicKey
+		esTxn.ToClientID = schedulersc.ADDRESS
+		esTxn.CreationDate = b.CreationDate
+		esTxn.TransactionType = transaction.TxnTypeSmartContract
+		esTxn.TransactionData = fmt.Sprintf(`{"name":"%s","input":{"id":"%s"}}`,
+			schedulersc.ExecuteScheduledFuncName, id)
+		esTxn.Fee = 0
+		if err := esTxn.ComputeProperties(); err != nil {
+			return nil, err
+		}
+		txns = append(txns, esTxn)
+	}
+	return txns, nil
+}
+
+// createFinalizeExpiredAllocationTxns creates the transactions finalizing the
+// allocations expired by the block, looked up in the state of the previous
+// block.
+func (mc *Chain) createFinalizeExpiredAllocationTxns(b *block.Block) ([]*transaction.Transaction, error) {
+	pb := b.PrevBlock
+	if pb == nil || pb.ClientState == nil {
+		return nil, nil
+	}
+
+	var (
+		qbc         = statecache.NewQueryBlockCache(mc.GetStateCache(), pb.Hash)
+		clientState = chain.CreateTxnMPT(pb.ClientState, statecache.NewTransactionCache(qbc))
+		sctx        = mc.NewStateContext(pb, clientState, &transaction.Transaction{}, nil)
+	)
+	ids, err := storagesc.GetExpiredAllocations(sctx, b.Round, b.CreationDate)
+	if err != nil {
+		return nil, err
+	}
+
+	txns := make([]*transaction.Transaction, 0, len(ids))
+	for _, id := range ids {
+		faTxn := transaction.Provider().(*transaction.Transaction)
+		faTxn.ClientID = node.Self.ID
+		faTxn.PublicKey = node.Self.PublicKey
+		faTxn.ToClientID = storagesc.ADDRESS
+		faTxn.CreationDate = b.CreationDate
+		faTxn.TransactionType = transaction.TxnTypeSmartContract
+		faTxn.TransactionData = fmt.Sprintf(`{"name":"%s","input":{"allocation_id":"%s"}}`,
+			storagesc.FinalizeExpiredAllocationFuncName, id)
+		faTxn.Fee = 0
+		if err := faTxn.ComputeProperties(); err != nil {
+			return nil, err
+		}
+		txns = append(txns, faTxn)
+	}
+	return txns, nil
+}
+
+// createBackfillAllocationExpiryTxn creates the transaction adding the next
+// batch of the allocations created before the expiry index to it, taken from
+// the event db once the hermes round is finalized. It returns nil if the
+// backfill is done or the miner has no event db.
+func (mc *Chain) createBackfillAllocationExpiryTxn(b *block.Block) (*transaction.Transaction, error) {
+	pb := b.PrevBlock
+	edb := mc.GetEventDb()
+	if pb == nil || pb.ClientState == nil || edb == nil {
+		return nil, nil
+	}
+
+	var (
+		qbc         = statecache.NewQueryBlockCache(mc.GetStateCache(), pb.Hash)
+		clientState = chain.CreateTxnMPT(pb.ClientState, statecache.NewTransactionCache(qbc))
+		sctx        = mc.NewStateContext(pb, clientState, &transaction.Transaction{}, nil)
+	)
+	cursor, done, err := storagesc.GetAllocationExpiryBackfillCursor(sctx)
+	if err != nil || done {
+		return nil, err
+	}
+
+	// the event db has all the allocations created before the hard fork
+	hermesRound, err := cstate.GetRoundByName(sctx, "hermes")
+	if err != nil {
+		return nil, err
+	}
+	if mc.GetLatestFinalizedBlock().Round < hermesRound {
+		return nil, nil
+	}
+
+	ids, err := edb.GetLiveAllocationIDs(cursor, storagesc.AllocationExpiryBackfillBatch)
+	if err != nil {
+		// retried by the next block
+		logging.Logger.Warn("backfill allocation expiry - getting allocations",
+			zap.Int64("round", b.Round), zap.Error(err))
+		return nil, nil
+	}
+	input, err := json.Marshal(map[string]interface{}{
+		"cursor":         cursor,
+		"allocation_ids": ids,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	bfTxn := transaction.Provider().(*transaction.Transaction)
+	bfTxn.ClientID = node.Self.ID
+	bfTxn.PublicKey = node.Self.PublicKey
+	bfTxn.ToClientID = storagesc.ADDRESS
+	bfTxn.CreationDate = b.CreationDate
+	bfTxn.TransactionType = transaction.TxnTypeSmartContract
+	bfTxn.TransactionData = fmt.Sprintf(`{"name":"%s","input":%s}`,
+		storagesc.BackfillAllocationExpiryFuncName, input)
+	bfTxn.Fee = 0
+	if err := bfTxn.ComputeProperties(); err != nil {
+		return nil, err
+	}
+	return bfTxn, nil
+}
+
 func (mc *Chain) validateTransaction(b *block.Block,
 	bState util.MerklePatriciaTrieI, txn *transaction.Transaction, waitC chan struct{}) (int64, error) {
 	if !common.WithinTime(int64(b.CreationDate), int64(txn.CreationDate), transaction.TXN_TIME_TOLERANCE) {
 		return 0, ErrNotTimeTolerant
 	}
+	if txn.IsSponsored() {
+		if err := mc.validateFeePayer(b, bState, txn, waitC); err != nil {
+			return 0, err
+		}
+	}
 	state, err := chain.GetStateById(bState, txn.ClientID)
 	if err != nil {
 		if err == util.ErrValueNotPresent {
@@ -173,6 +321,27 @@ func (mc *Chain) validateTransaction(b *block.Block,
 	return state.Nonce, nil
 }
 
+// validateFeePayer checks the fee payer of a sponsored transaction can pay
+// its fee, the nonce rules apply to the issuing client.
+func (mc *Chain) validateFeePayer(b *block.Block,
+	bState util.MerklePatriciaTrieI, txn *transaction.Transaction, waitC chan struct{}) error {
+	state, err := chain.GetStateById(bState, txn.FeePayerID)
+	if err != nil {
+		if err == util.ErrValueNotPresent {
+			return ErrFeePayerInsufficientBalance
+		}
+		if cstate.ErrInvalidState(err) {
+			mc.SyncMissingNodes(b.Round, bState.GetMissingNodeKeys(), waitC)
+		}
+		return err
+	}
+
+	if mc.ChainConfig.IsFeeEnabled() && state.Balance < txn.Fee {
+		return ErrFeePayerInsufficientBalance
+	}
+	return nil
+}
+
 // UpdatePendingBlock - updates the block that is generated and pending
 // rest of the process.
 func (mc *Chain) UpdatePendingBlock(ctx context.Context, b *block.Block, txns []datastore.Entity) {
@@ -192,7 +361,8 @@ func (mc *Chain) UpdatePendingBlock(ctx context.Context, b *block.Block, txns []
 
 func (mc *Chain) verifySmartContracts(ctx context.Context, b *block.Block) error {
 	for _, txn := range b.Txns {
-		if txn.TransactionType == transaction.TxnTypeSmartContract {
+		if txn.TransactionType == transaction.TxnTypeSmartContract ||
+			txn.TransactionType == transaction.TxnTypeMultiCall {
 			err := txn.VerifyOutputHash(ctx)
 			if err != nil {
 				logging.Logger.Error("Smart contract output verification failed", zap.Error(err), zap.String("output", txn.TransactionOutput))
@@ -767,6 +937,13 @@ func txnProcessorHandlerFunc(mc *Chain, b *block.Block) txnProcessorHandler {
 					zap.Int32("iterate count", tii.count))
 			}
 			return false, nil
+		case ErrFeePayerInsufficientBalance:
+			tii.invalidTxns = append(tii.invalidTxns, txn)
+			if debugTxn {
+				logging.Logger.Info("generate block (debug transaction) error - fee payer can't pay the fee",
+					zap.String("txn", txn.Hash), zap.String("fee_payer", txn.FeePayerID))
+			}
+			return false, nil
 		case ErrNotTimeTolerant:
 			tii.invalidTxns = append(tii.invalidTxns, txn)
 			if debugTxn {
@@ -1380,6 +1557,30 @@ func (mc *Chain) buildInTxns(ctx context.Context, lfb, b *block.Block) ([]*trans
 		txns = append(txns, cscTxn)
 	}
 
+	if smartcontract.GetSmartContract(schedulersc.ADDRESS) != nil {
+		esTxns, err := mc.createExecuteScheduledTxns(b)
+		if err != nil {
+			return nil, 0, err
+		}
+		txns = append(txns, esTxns...)
+	}
+
+	if globalNode.AutoFinalize.Enabled {
+		faTxns, err := mc.createFinalizeExpiredAllocationTxns(b)
+		if err != nil {
+			return nil, 0, err
+		}
+		txns = append(txns, faTxns...)
+
+		bfTxn, err := mc.createBackfillAllocationExpiryTxn(b)
+		if err != nil {
+			return nil, 0, err
+		}
+		if bfTxn != nil {
+			txns = append(txns, bfTxn)
+		}
+	}
+
 	var cost int
 	for _, txn := range txns {
 		c, err := mc.EstimateTransactionCost(ctx, lfb, txn, chain.WithSync())
@@ -7,21 +7,42 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"0chain.net/core/common"
-	"0chain.net/core/viper"
+	"0chain.net/core/config"
 )
 
 /*SetupHandlers - setup config related handlers */
 func SetupHandlers() {
 	http.HandleFunc("/v1/config/get", common.UserRateLimit(GetConfigHandler))
+	http.HandleFunc("/v1/config/reload", common.UserRateLimit(ReloadConfigHandler))
 }
 
-/*GetConfigHandler - display configuration */
+/*GetConfigHandler - display configuration, with the secrets redacted */
 func GetConfigHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "text/plain;charset=UTF-8")
-	c := viper.AllSettings()
+	c := config.RedactedSettings()
 	bs, err := yaml.Marshal(c)
 	if err != nil {
 		fmt.Fprint(w, err.Error())
 	}
 	fmt.Fprintf(w, "%v", string(bs))
 }
+
+/*ReloadConfigHandler - reload the node configuration file and apply the settings that can change live,
+* the request is authorized by the admin token of the node as a bearer token
+ */
+func ReloadConfigHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "405 Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !config.IsAdminRequest(r) {
+		http.Error(w, "401 Unauthorized - invalid admin token", http.StatusUnauthorized)
+		return
+	}
+	result, err := config.Reload()
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrBadRequest("reload config: "+err.Error()))
+		return
+	}
+	common.Respond(w, r, result, nil)
+}
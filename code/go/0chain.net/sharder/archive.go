@@ -0,0 +1,391 @@
+package sharder
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/chaincore/round"
+	"0chain.net/core/common"
+	"0chain.net/core/config"
+	"0chain.net/core/datastore"
+	"0chain.net/core/ememorystore"
+	"0chain.net/sharder/blockstore"
+	"github.com/0chain/common/core/logging"
+	"go.uber.org/zap"
+)
+
+// ArchiveVersion is the version of the chain history archive format.
+const ArchiveVersion = 1
+
+const archiveIndexFile = "index.json"
+
+// ArchiveFile is a file of a chain history archive.
+type ArchiveFile struct {
+	// Name is the path of the file in the archive directory.
+	Name string `json:"name"`
+	// Hash is the hex encoded sha256 of the file.
+	Hash string `json:"hash"`
+}
+
+// ArchiveRound is the content of the archive for a finalized round.
+type ArchiveRound struct {
+	Round        int64       `json:"round"`
+	BlockHash    string      `json:"block_hash"`
+	Block        ArchiveFile `json:"block"`
+	BlockSummary ArchiveFile `json:"block_summary"`
+	RoundEntry   ArchiveFile `json:"round_entry"`
+}
+
+// ArchiveMagicBlock is a magic block map of the archive, the magic block
+// itself is in the block starting it.
+type ArchiveMagicBlock struct {
+	MagicBlockNumber int64       `json:"magic_block_number"`
+	BlockRound       int64       `json:"block_round"`
+	MagicBlockMap    ArchiveFile `json:"magic_block_map"`
+}
+
+// ArchiveIndex describes a chain history archive: a directory with the
+// finalized blocks, block summaries, round entries and magic block maps of
+// a range of rounds, all in JSON, and this index with the hashes of the
+// files.
+type ArchiveIndex struct {
+	Version     int                 `json:"version"`
+	ChainID     string              `json:"chain_id"`
+	FromRound   int64               `json:"from_round"`
+	ToRound     int64               `json:"to_round"`
+	CreatedAt   common.Timestamp    `json:"created_at"`
+	Rounds      []ArchiveRound      `json:"rounds"`
+	MagicBlocks []ArchiveMagicBlock `json:"magic_blocks"`
+}
+
+func writeArchiveFile(dir, name string, v interface{}) (ArchiveFile, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ArchiveFile{}, err
+	}
+
+	p := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
+		return ArchiveFile{}, err
+	}
+	if err := os.WriteFile(p, data, 0600); err != nil {
+		return ArchiveFile{}, err
+	}
+
+	sum := sha256.Sum256(data)
+	return ArchiveFile{Name: name, Hash: hex.EncodeToString(sum[:])}, nil
+}
+
+// readArchiveFile reads the file into v, after checking its hash.
+func readArchiveFile(dir string, f ArchiveFile, v interface{}) error {
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(f.Name)))
+	if err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != f.Hash {
+		return fmt.Errorf("archive file %s: hash mismatch", f.Name)
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+func readArchiveIndex(dir string) (*ArchiveIndex, error) {
+	data, err := os.ReadFile(filepath.Join(dir, archiveIndexFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var index ArchiveIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("invalid archive index: %v", err)
+	}
+	if index.Version != ArchiveVersion {
+		return nil, fmt.Errorf("unsupported archive version: %d", index.Version)
+	}
+	return &index, nil
+}
+
+func archiveRoundDir(roundNum int64) string {
+	return "rounds/" + strconv.FormatInt(roundNum, 10)
+}
+
+// ExportArchive writes the finalized blocks, block summaries, round entries
+// and magic block maps of the rounds to a chain history archive in the
+// given directory. The index is written last, so an interrupted export
+// leaves no valid archive.
+func (sc *Chain) ExportArchive(ctx context.Context, dir string, from, to int64) (*ArchiveIndex, error) {
+	lfb := sc.GetLatestFinalizedBlock()
+	if lfb == nil {
+		return nil, common.NewError("export_archive", "no latest finalized block")
+	}
+	if to <= 0 || to > lfb.Round {
+		to = lfb.Round
+	}
+	if from < 0 || from > to {
+		return nil, common.NewErrorf("export_archive", "invalid round range: %d - %d", from, to)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, archiveIndexFile)); err == nil {
+		return nil, common.NewErrorf("export_archive", "archive exists already: %s", dir)
+	}
+
+	index := &ArchiveIndex{
+		Version:   ArchiveVersion,
+		ChainID:   config.GetServerChainID(),
+		FromRound: from,
+		ToRound:   to,
+	}
+
+	for r := from; r <= to; r++ {
+		entry, b, err := sc.exportArchiveRound(ctx, dir, r)
+		if err != nil {
+			return nil, common.NewErrorf("export_archive", "round %d: %v", r, err)
+		}
+		index.Rounds = append(index.Rounds, *entry)
+
+		if b.MagicBlock == nil || b.MagicBlock.StartingRound != b.Round {
+			continue
+		}
+		mb, err := sc.exportArchiveMagicBlock(ctx, dir, b.MagicBlock.MagicBlockNumber)
+		if err != nil {
+			return nil, common.NewErrorf("export_archive", "magic block %d: %v",
+				b.MagicBlock.MagicBlockNumber, err)
+		}
+		index.MagicBlocks = append(index.MagicBlocks, *mb)
+	}
+
+	index.CreatedAt = common.Now()
+	if _, err := writeArchiveFile(dir, archiveIndexFile, index); err != nil {
+		return nil, common.NewError("export_archive", err.Error())
+	}
+	return index, nil
+}
+
+func (sc *Chain) exportArchiveRound(ctx context.Context, dir string, roundNum int64) (*ArchiveRound, *block.Block, error) {
+	r, err := sc.GetRoundFromStore(ctx, roundNum)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading round: %v", err)
+	}
+
+	b, err := sc.GetBlockFromStore(r.BlockHash, roundNum)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading block: %v", err)
+	}
+
+	bSummaryEntityMetadata := datastore.GetEntityMetadata("block_summary")
+	bctx := ememorystore.WithEntityConnection(ctx, bSummaryEntityMetadata)
+	defer ememorystore.Close(bctx)
+	bs, err := sc.GetBlockSummary(bctx, r.BlockHash)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading block summary: %v", err)
+	}
+
+	rdir := archiveRoundDir(roundNum)
+	entry := &ArchiveRound{Round: roundNum, BlockHash: b.Hash}
+	if entry.Block, err = writeArchiveFile(dir, rdir+"/block.json", b); err != nil {
+		return nil, nil, err
+	}
+	if entry.BlockSummary, err = writeArchiveFile(dir, rdir+"/block_summary.json", bs); err != nil {
+		return nil, nil, err
+	}
+	if entry.RoundEntry, err = writeArchiveFile(dir, rdir+"/round.json", r); err != nil {
+		return nil, nil, err
+	}
+	return entry, b, nil
+}
+
+func (sc *Chain) exportArchiveMagicBlock(ctx context.Context, dir string, number int64) (*ArchiveMagicBlock, error) {
+	mbm, err := sc.GetMagicBlockMap(ctx, strconv.FormatInt(number, 10))
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := writeArchiveFile(dir, "magic_blocks/"+strconv.FormatInt(number, 10)+".json", mbm)
+	if err != nil {
+		return nil, err
+	}
+	return &ArchiveMagicBlock{
+		MagicBlockNumber: number,
+		BlockRound:       mbm.BlockRound,
+		MagicBlockMap:    f,
+	}, nil
+}
+
+// archivedRound is a round of an archive read back and verified.
+type archivedRound struct {
+	block   *block.Block
+	summary *block.BlockSummary
+	round   *round.Round
+}
+
+func readArchivedRound(dir string, entry *ArchiveRound) (*archivedRound, error) {
+	ar := &archivedRound{
+		block:   datastore.GetEntityMetadata("block").Instance().(*block.Block),
+		summary: datastore.GetEntityMetadata("block_summary").Instance().(*block.BlockSummary),
+		round:   round.NewRound(entry.Round),
+	}
+	if err := readArchiveFile(dir, entry.Block, ar.block); err != nil {
+		return nil, err
+	}
+	if err := readArchiveFile(dir, entry.BlockSummary, ar.summary); err != nil {
+		return nil, err
+	}
+	if err := readArchiveFile(dir, entry.RoundEntry, ar.round); err != nil {
+		return nil, err
+	}
+
+	b := ar.block
+	switch {
+	case b.Hash != entry.BlockHash || b.Round != entry.Round:
+		return nil, fmt.Errorf("block %s of round %d doesn't match the index", b.Hash, b.Round)
+	case b.ComputeHash() != b.Hash:
+		return nil, fmt.Errorf("invalid hash of block %s", b.Hash)
+	case ar.summary.Hash != b.Hash:
+		return nil, fmt.Errorf("block summary of %s doesn't match the block", ar.summary.Hash)
+	case ar.round.Number != b.Round || ar.round.BlockHash != b.Hash:
+		return nil, fmt.Errorf("round entry %d doesn't match the block", ar.round.Number)
+	}
+	return ar, nil
+}
+
+// ImportArchive loads a chain history archive into the block store and the
+// summaries of the sharder. The rounds are imported one at a time: the files
+// of a round are checked against the hashes of the index, and its block
+// against its notarization, before the round is stored, so an archive
+// failing the checks leaves the rounds before the failing one imported. The
+// magic blocks of the archive are checked against their maps and applied as
+// they come, so the notarizations of the following rounds can be verified.
+func (sc *Chain) ImportArchive(ctx context.Context, dir string) (*ArchiveIndex, error) {
+	index, err := readArchiveIndex(dir)
+	if err != nil {
+		return nil, common.NewError("import_archive", err.Error())
+	}
+	if chainID := config.GetServerChainID(); index.ChainID != chainID {
+		return nil, common.NewErrorf("import_archive", "archive of chain %s, not %s",
+			index.ChainID, chainID)
+	}
+
+	sort.Slice(index.Rounds, func(i, j int) bool {
+		return index.Rounds[i].Round < index.Rounds[j].Round
+	})
+
+	mbMaps, err := readArchiveMagicBlockMaps(dir, index)
+	if err != nil {
+		return nil, common.NewError("import_archive", err.Error())
+	}
+
+	for i := range index.Rounds {
+		ar, err := readArchivedRound(dir, &index.Rounds[i])
+		if err != nil {
+			return nil, common.NewErrorf("import_archive", "round %d: %v", index.Rounds[i].Round, err)
+		}
+
+		mbm := mbMaps[ar.block.Round]
+		if err := sc.verifyArchivedBlock(ctx, ar.block, mbm); err != nil {
+			return nil, common.NewErrorf("import_archive", "round %d: %v", ar.block.Round, err)
+		}
+
+		if err := blockstore.GetStore().Write(ar.block); err != nil {
+			return nil, common.NewErrorf("import_archive", "round %d: storing block: %v", ar.block.Round, err)
+		}
+		if err := sc.StoreBlockSummary(ctx, ar.summary); err != nil {
+			return nil, common.NewErrorf("import_archive", "round %d: storing block summary: %v", ar.block.Round, err)
+		}
+		if err := sc.StoreRound(ar.round); err != nil {
+			return nil, common.NewErrorf("import_archive", "round %d: storing round: %v", ar.block.Round, err)
+		}
+		if mbm != nil {
+			if err := sc.StoreMagicBlockMapFromBlock(mbm); err != nil {
+				return nil, common.NewErrorf("import_archive", "round %d: storing magic block map %s: %v",
+					ar.block.Round, mbm.ID, err)
+			}
+		}
+	}
+
+	logging.Logger.Info("import archive - done",
+		zap.Int64("from_round", index.FromRound),
+		zap.Int64("to_round", index.ToRound),
+		zap.Int("rounds", len(index.Rounds)))
+	return index, nil
+}
+
+// readArchiveMagicBlockMaps reads the magic block maps of the archive by the
+// round of the block starting their magic block, every one of them must be
+// of a round of the archive.
+func readArchiveMagicBlockMaps(dir string, index *ArchiveIndex) (map[int64]*block.MagicBlockMap, error) {
+	rounds := make(map[int64]struct{}, len(index.Rounds))
+	for _, r := range index.Rounds {
+		rounds[r.Round] = struct{}{}
+	}
+
+	mbMaps := make(map[int64]*block.MagicBlockMap, len(index.MagicBlocks))
+	for i := range index.MagicBlocks {
+		amb := &index.MagicBlocks[i]
+		if _, ok := rounds[amb.BlockRound]; !ok {
+			return nil, fmt.Errorf("magic block %d: round %d isn't in the archive",
+				amb.MagicBlockNumber, amb.BlockRound)
+		}
+		if _, ok := mbMaps[amb.BlockRound]; ok {
+			return nil, fmt.Errorf("magic block %d: another magic block of round %d",
+				amb.MagicBlockNumber, amb.BlockRound)
+		}
+
+		mbm := datastore.GetEntityMetadata("magic_block_map").Instance().(*block.MagicBlockMap)
+		if err := readArchiveFile(dir, amb.MagicBlockMap, mbm); err != nil {
+			return nil, fmt.Errorf("magic block %d: %v", amb.MagicBlockNumber, err)
+		}
+		if mbm.ID != strconv.FormatInt(amb.MagicBlockNumber, 10) || mbm.BlockRound != amb.BlockRound {
+			return nil, fmt.Errorf("magic block map %s doesn't match the index", mbm.ID)
+		}
+		mbMaps[amb.BlockRound] = mbm
+	}
+	return mbMaps, nil
+}
+
+// verifyArchivedBlock verifies the notarization of the block, and applies the
+// magic block it starts, if any, once it's checked against its map.
+func (sc *Chain) verifyArchivedBlock(ctx context.Context, b *block.Block, mbm *block.MagicBlockMap) error {
+	if b.Round > 0 {
+		if err := sc.VerifyNotarization(ctx, b.Hash, b.GetVerificationTickets(), b.Round); err != nil {
+			return err
+		}
+	}
+
+	if b.MagicBlock == nil || b.MagicBlock.StartingRound != b.Round {
+		if mbm != nil {
+			return fmt.Errorf("magic block map %s of a block not starting a magic block", mbm.ID)
+		}
+		return nil
+	}
+
+	mb := b.MagicBlock
+	switch {
+	case mbm == nil:
+		return fmt.Errorf("no magic block map of magic block %d", mb.MagicBlockNumber)
+	case mb.Hash != mb.GetHash():
+		return fmt.Errorf("invalid hash of magic block %d", mb.MagicBlockNumber)
+	case mbm.ID != strconv.FormatInt(mb.MagicBlockNumber, 10) ||
+		mbm.Hash != b.Hash || mbm.BlockRound != b.Round:
+		return fmt.Errorf("magic block map %s doesn't match magic block %d of block %s",
+			mbm.ID, mb.MagicBlockNumber, b.Hash)
+	}
+
+	if lmb := sc.GetLatestMagicBlock(); lmb != nil && lmb.MagicBlockNumber >= mb.MagicBlockNumber {
+		return nil // known already
+	}
+	if err := sc.UpdateMagicBlock(mb); err != nil {
+		return err
+	}
+	sc.SetLatestFinalizedMagicBlock(b)
+	return nil
+}
@@ -0,0 +1,108 @@
+package sharder
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/chaincore/node"
+	"0chain.net/core/datastore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveFileIntegrity(t *testing.T) {
+	dir := t.TempDir()
+
+	type entry struct {
+		Round int64  `json:"round"`
+		Hash  string `json:"hash"`
+	}
+	in := entry{Round: 10, Hash: "abc"}
+
+	f, err := writeArchiveFile(dir, archiveRoundDir(10)+"/entry.json", &in)
+	require.NoError(t, err)
+	require.Equal(t, "rounds/10/entry.json", f.Name)
+
+	var out entry
+	require.NoError(t, readArchiveFile(dir, f, &out))
+	require.Equal(t, in, out)
+
+	// tampered file
+	require.NoError(t, os.WriteFile(filepath.Join(dir, f.Name), []byte(`{"round":11,"hash":"abc"}`), 0600))
+	require.Error(t, readArchiveFile(dir, f, &out))
+}
+
+func TestReadArchiveIndex(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := readArchiveIndex(dir)
+	require.Error(t, err, "missing index")
+
+	_, err = writeArchiveFile(dir, archiveIndexFile, &ArchiveIndex{Version: ArchiveVersion + 1})
+	require.NoError(t, err)
+	_, err = readArchiveIndex(dir)
+	require.Error(t, err, "unsupported version")
+
+	_, err = writeArchiveFile(dir, archiveIndexFile, &ArchiveIndex{
+		Version:   ArchiveVersion,
+		ChainID:   "chain",
+		FromRound: 1,
+		ToRound:   2,
+		Rounds:    []ArchiveRound{{Round: 1}, {Round: 2}},
+	})
+	require.NoError(t, err)
+	index, err := readArchiveIndex(dir)
+	require.NoError(t, err)
+	require.Equal(t, "chain", index.ChainID)
+	require.Len(t, index.Rounds, 2)
+}
+
+func TestReadArchiveMagicBlockMaps(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := readArchiveMagicBlockMaps(dir, &ArchiveIndex{
+		Rounds:      []ArchiveRound{{Round: 1}, {Round: 2}},
+		MagicBlocks: []ArchiveMagicBlock{{MagicBlockNumber: 2, BlockRound: 3}},
+	})
+	require.Error(t, err, "magic block of a round out of the archive")
+
+	mbMaps, err := readArchiveMagicBlockMaps(dir, &ArchiveIndex{
+		Rounds: []ArchiveRound{{Round: 1}, {Round: 2}},
+	})
+	require.NoError(t, err)
+	require.Empty(t, mbMaps)
+}
+
+func TestVerifyArchivedMagicBlock(t *testing.T) {
+	mb := block.NewMagicBlock()
+	mb.Miners = node.NewPool(node.NodeTypeMiner)
+	mb.Sharders = node.NewPool(node.NodeTypeSharder)
+	mb.MagicBlockNumber = 2
+	mb.Hash = mb.GetHash()
+
+	b := block.NewBlock("", 0)
+	b.MagicBlock = mb
+	b.HashBlock()
+
+	mbMap := func(number, hash string, round int64) *block.MagicBlockMap {
+		return &block.MagicBlockMap{
+			IDField:    datastore.IDField{ID: number},
+			Hash:       hash,
+			BlockRound: round,
+		}
+	}
+
+	sc := &Chain{}
+	require.Error(t, sc.verifyArchivedBlock(context.Background(), b, nil), "no magic block map")
+	require.Error(t, sc.verifyArchivedBlock(context.Background(), b, mbMap("3", b.Hash, 0)), "another magic block")
+	require.Error(t, sc.verifyArchivedBlock(context.Background(), b, mbMap("2", "other", 0)), "another block")
+	require.Error(t, sc.verifyArchivedBlock(context.Background(), b, mbMap("2", b.Hash, 1)), "another round")
+
+	mb.T = 1 // no longer the magic block of the block
+	require.Error(t, sc.verifyArchivedBlock(context.Background(), b, mbMap("2", b.Hash, 0)), "invalid magic block")
+
+	b.MagicBlock = nil
+	require.Error(t, sc.verifyArchivedBlock(context.Background(), b, mbMap("2", b.Hash, 0)), "not starting a magic block")
+}
@@ -17,6 +17,7 @@ import (
 
 	"github.com/0chain/common/core/logging"
 	simpleLru "github.com/hashicorp/golang-lru/v2"
+	"go.uber.org/zap"
 
 	"0chain.net/chaincore/block"
 	"0chain.net/core/datastore"
@@ -148,6 +149,32 @@ func (c *cache) Add() {
 	}
 }
 
+// resize changes the number of blocks of the cache, the blocks evicted to
+// fit are removed from the cache path.
+func (c *cache) resize(totalBlocks int) {
+	keys := c.lru.Keys()
+	if excess := len(keys) - totalBlocks; excess > 0 {
+		for _, key := range keys[:excess] {
+			c.lru.Remove(key)
+			os.Remove(filepath.Join(c.path, key))
+		}
+	}
+	c.lru.Resize(totalBlocks)
+}
+
+// ResizeCache changes the number of blocks of the block store cache, if
+// there is a cache.
+func ResizeCache(totalBlocks int) {
+	bStore, ok := store.(*BlockStore)
+	if !ok || totalBlocks <= 0 {
+		return
+	}
+	if c, ok := bStore.cache.(*cache); ok {
+		c.resize(totalBlocks)
+		logging.Logger.Info("resized block cache", zap.Int("total_blocks", totalBlocks))
+	}
+}
+
 type cacheEntry struct {
 	key  string
 	data []byte
@@ -0,0 +1,187 @@
+package blockstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"0chain.net/core/viper"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+const (
+	coldStoreDir = "dir"
+	coldStoreS3  = "s3"
+
+	coldStoreTimeout = 30 * time.Second
+)
+
+// ErrNotInColdStore is returned by a cold store missing the block.
+var ErrNotInColdStore = errors.New("block not in cold store")
+
+// ColdStore is the archive the blocks older than the hot rounds of the
+// block store are moved to. The data of a block is the compressed block
+// file of the block store, the key is the path of the file.
+type ColdStore interface {
+	Put(key string, data []byte) error
+	Get(key string) ([]byte, error)
+	// Kind is the type of the store, to advertise it.
+	Kind() string
+}
+
+// dirColdStore keeps the archived blocks in a local directory, usually a
+// mounted network or slow drive.
+type dirColdStore struct {
+	path string
+}
+
+func newDirColdStore(path string) (*dirColdStore, error) {
+	if path == "" {
+		return nil, errors.New("missing cold store path")
+	}
+	if err := os.MkdirAll(path, 0700); err != nil {
+		return nil, err
+	}
+	return &dirColdStore{path: path}, nil
+}
+
+func (ds *dirColdStore) Put(key string, data []byte) error {
+	p := filepath.Join(ds.path, key)
+	if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
+		return err
+	}
+
+	// write the file aside and move it, so a crash doesn't leave a partial
+	// block in the archive
+	tmp := p + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, p)
+}
+
+func (ds *dirColdStore) Get(key string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(ds.path, key))
+	if os.IsNotExist(err) {
+		return nil, ErrNotInColdStore
+	}
+	return data, err
+}
+
+func (ds *dirColdStore) Kind() string {
+	return coldStoreDir
+}
+
+// s3ColdStore keeps the archived blocks in a bucket of an S3 compatible
+// endpoint, using path style addressing.
+type s3ColdStore struct {
+	endpoint    string
+	bucket      string
+	region      string
+	credentials aws.Credentials
+	signer      *v4.Signer
+	client      *http.Client
+}
+
+func newS3ColdStore(endpoint, bucket, region, accessKey, secretKey string) (*s3ColdStore, error) {
+	if endpoint == "" || bucket == "" {
+		return nil, errors.New("missing cold store endpoint or bucket")
+	}
+	if region == "" {
+		region = "us-east-1"
+	}
+	return &s3ColdStore{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		bucket:   bucket,
+		region:   region,
+		credentials: aws.Credentials{
+			AccessKeyID:     accessKey,
+			SecretAccessKey: secretKey,
+		},
+		signer: v4.NewSigner(),
+		client: &http.Client{Timeout: coldStoreTimeout},
+	}, nil
+}
+
+func (ss *s3ColdStore) do(method, key string, data []byte) (*http.Response, error) {
+	url := ss.endpoint + "/" + ss.bucket + "/" + filepath.ToSlash(key)
+	req, err := http.NewRequest(method, url, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(data)
+	payloadHash := hex.EncodeToString(sum[:])
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	ctx, cancel := context.WithTimeout(context.Background(), coldStoreTimeout)
+	defer cancel()
+	if err := ss.signer.SignHTTP(ctx, ss.credentials, req, payloadHash, "s3", ss.region, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	return ss.client.Do(req)
+}
+
+func (ss *s3ColdStore) Put(key string, data []byte) error {
+	resp, err := ss.do(http.MethodPut, key, data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("cold store put %s: %s: %s", key, resp.Status, body)
+	}
+	return nil
+}
+
+func (ss *s3ColdStore) Get(key string) ([]byte, error) {
+	resp, err := ss.do(http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return io.ReadAll(resp.Body)
+	case http.StatusNotFound:
+		return nil, ErrNotInColdStore
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("cold store get %s: %s: %s", key, resp.Status, body)
+	}
+}
+
+func (ss *s3ColdStore) Kind() string {
+	return coldStoreS3
+}
+
+// initColdStore sets up the cold store of the cold_store config.
+func initColdStore(v *viper.Viper) (ColdStore, error) {
+	switch kind := v.GetString("type"); kind {
+	case coldStoreDir:
+		return newDirColdStore(v.GetString("path"))
+	case coldStoreS3:
+		return newS3ColdStore(
+			v.GetString("endpoint"),
+			v.GetString("bucket"),
+			v.GetString("region"),
+			v.GetString("access_key"),
+			v.GetString("secret_key"),
+		)
+	default:
+		return nil, fmt.Errorf("unknown cold store type: %q", kind)
+	}
+}
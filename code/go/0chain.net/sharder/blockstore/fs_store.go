@@ -6,6 +6,7 @@ import (
 	"compress/zlib"
 	"context"
 	"fmt"
+	"io"
 	"math"
 	"os"
 	"path/filepath"
@@ -89,6 +90,9 @@ type BlockStore struct {
 	basePath              string
 	blockMetadataProvider datastore.EntityMetadata
 	cache                 cacher
+	// retention moves the old blocks to a cold store, nil if all the blocks
+	// are kept in basePath.
+	retention *retention
 }
 
 func (bStore *BlockStore) writeToDisk(hash string, b *block.Block) error {
@@ -166,6 +170,9 @@ func (bStore *BlockStore) Read(hash string) (*block.Block, error) {
 	}
 
 	b, err = bStore.readFromDisk(hash)
+	if os.IsNotExist(err) && bStore.retention != nil {
+		b, err = bStore.readArchived(hash)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -194,6 +201,20 @@ func (bStore *BlockStore) readFromDisk(hash string) (*block.Block, error) {
 	}
 	defer f.Close()
 
+	return bStore.decode(f)
+}
+
+// readArchived reads a block moved to the cold store.
+func (bStore *BlockStore) readArchived(hash string) (*block.Block, error) {
+	data, err := bStore.readFromColdStore(hash)
+	if err != nil {
+		return nil, err
+	}
+	return bStore.decode(bytes.NewReader(data))
+}
+
+// decode reads a block from its compressed file.
+func (bStore *BlockStore) decode(f io.Reader) (*block.Block, error) {
 	r, err := zlib.NewReader(f)
 	if err != nil {
 		return nil, err
@@ -242,6 +263,9 @@ func Init(workDir string, sViper *viper.Viper) {
 		if cViper != nil {
 			bStore.cache = initCache(cViper)
 		}
+		if rViper := sViper.Sub("retention"); rViper != nil {
+			bStore.retention = initRetention(rViper, basePath)
+		}
 	}
 	SetupStore(bStore)
 }
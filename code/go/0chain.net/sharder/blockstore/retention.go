@@ -0,0 +1,215 @@
+package blockstore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"0chain.net/core/viper"
+	"github.com/0chain/common/core/logging"
+	"go.uber.org/zap"
+)
+
+const (
+	retentionStateFile = "retention.json"
+	// offloadBatch is the max number of rounds moved to the cold store at
+	// once, so a sharder enabling the retention catches up gradually.
+	offloadBatch = 1000
+)
+
+// RetentionStatus tells the peers the rounds of blocks a sharder serves from
+// its local disk and from its cold store.
+type RetentionStatus struct {
+	// HotRounds is the number of the latest rounds kept on the local disk,
+	// 0 if all the blocks are kept.
+	HotRounds int64 `json:"hot_rounds"`
+	// ArchivedTo is the last round of the blocks moved to the cold store,
+	// the blocks of the following rounds are on the local disk.
+	ArchivedTo int64 `json:"archived_to"`
+	// ColdStore is the type of the cold store, if any; the archived blocks
+	// are fetched back from it on demand.
+	ColdStore string `json:"cold_store,omitempty"`
+}
+
+// retention moves the blocks older than the hot rounds to the cold store.
+type retention struct {
+	hotRounds int64
+	cold      ColdStore
+	statePath string
+
+	mutex      sync.RWMutex
+	archivedTo int64
+}
+
+type retentionState struct {
+	ArchivedTo int64 `json:"archived_to"`
+}
+
+func newRetention(hotRounds int64, cold ColdStore, basePath string) *retention {
+	rt := &retention{
+		hotRounds: hotRounds,
+		cold:      cold,
+		statePath: filepath.Join(basePath, retentionStateFile),
+	}
+
+	data, err := os.ReadFile(rt.statePath)
+	switch {
+	case err == nil:
+		var st retentionState
+		if err := json.Unmarshal(data, &st); err != nil {
+			logging.Logger.Error("retention: invalid state, starting over", zap.Error(err))
+			break
+		}
+		rt.archivedTo = st.ArchivedTo
+	case !os.IsNotExist(err):
+		logging.Logger.Error("retention: reading state", zap.Error(err))
+	}
+	return rt
+}
+
+func (rt *retention) getArchivedTo() int64 {
+	rt.mutex.RLock()
+	defer rt.mutex.RUnlock()
+	return rt.archivedTo
+}
+
+func (rt *retention) setArchivedTo(round int64) error {
+	rt.mutex.Lock()
+	rt.archivedTo = round
+	rt.mutex.Unlock()
+
+	data, err := json.Marshal(&retentionState{ArchivedTo: round})
+	if err != nil {
+		return err
+	}
+	tmp := rt.statePath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, rt.statePath)
+}
+
+func (rt *retention) status() RetentionStatus {
+	st := RetentionStatus{
+		HotRounds:  rt.hotRounds,
+		ArchivedTo: rt.getArchivedTo(),
+	}
+	if rt.cold != nil {
+		st.ColdStore = rt.cold.Kind()
+	}
+	return st
+}
+
+// initRetention sets up the retention of the retention config, nil if the
+// blocks are kept on the local disk.
+func initRetention(v *viper.Viper, basePath string) *retention {
+	hotRounds := v.GetInt64("hot_rounds")
+	if hotRounds <= 0 {
+		return nil
+	}
+
+	cv := v.Sub("cold_store")
+	if cv == nil {
+		panic("block retention requires a cold store")
+	}
+	cold, err := initColdStore(cv)
+	if err != nil {
+		panic(err)
+	}
+	return newRetention(hotRounds, cold, basePath)
+}
+
+// offload moves the file of the block to the cold store.
+func (bStore *BlockStore) offload(hash string) error {
+	bp, err := getBlockFilePath(hash)
+	if err != nil {
+		return err
+	}
+	bPath := filepath.Join(bStore.basePath, bp)
+
+	data, err := os.ReadFile(bPath)
+	if os.IsNotExist(err) {
+		return nil // moved already
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := bStore.retention.cold.Put(bp, data); err != nil {
+		return err
+	}
+	return os.Remove(bPath)
+}
+
+// readFromColdStore fetches an archived block back from the cold store.
+func (bStore *BlockStore) readFromColdStore(hash string) ([]byte, error) {
+	bp, err := getBlockFilePath(hash)
+	if err != nil {
+		return nil, err
+	}
+	return bStore.retention.cold.Get(bp)
+}
+
+func getRetention() (*BlockStore, *retention) {
+	bStore, ok := store.(*BlockStore)
+	if !ok || bStore.retention == nil {
+		return nil, nil
+	}
+	return bStore, bStore.retention
+}
+
+// GetRetentionStatus returns the retention status of the block store.
+func GetRetentionStatus() RetentionStatus {
+	if _, rt := getRetention(); rt != nil {
+		return rt.status()
+	}
+	return RetentionStatus{}
+}
+
+// HotRounds returns the number of the latest rounds of blocks kept on the
+// local disk, 0 if the blocks aren't moved to a cold store.
+func HotRounds() int64 {
+	if _, rt := getRetention(); rt != nil {
+		return rt.hotRounds
+	}
+	return 0
+}
+
+// OffloadRounds moves the blocks of the rounds after the archived ones, up
+// to the given round, to the cold store. The hashOf function returns the
+// hash of the block finalized in a round. The offloading stops at the first
+// round failed, only the rounds archived before it are marked as archived,
+// and the round is retried next time.
+func OffloadRounds(to int64, hashOf func(round int64) (string, error)) error {
+	bStore, rt := getRetention()
+	if rt == nil {
+		return errors.New("block retention is disabled")
+	}
+
+	from := rt.getArchivedTo() + 1
+	if to-from >= offloadBatch {
+		to = from + offloadBatch - 1
+	}
+	if to < from {
+		return nil
+	}
+
+	for r := from; r <= to; r++ {
+		hash, err := hashOf(r)
+		if err == nil {
+			err = bStore.offload(hash)
+		}
+		if err != nil {
+			// keep the progress, and retry the round later
+			if serr := rt.setArchivedTo(r - 1); serr != nil {
+				logging.Logger.Error("retention: saving state", zap.Error(serr))
+			}
+			return fmt.Errorf("archiving round %d: %v", r, err)
+		}
+	}
+
+	return rt.setArchivedTo(to)
+}
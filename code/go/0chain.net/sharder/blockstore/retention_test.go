@@ -0,0 +1,122 @@
+package blockstore
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockStoreOffloadRounds(t *testing.T) {
+	basePath := t.TempDir()
+	cold, err := newDirColdStore(t.TempDir())
+	require.NoError(t, err)
+
+	bStore := &BlockStore{
+		basePath:              basePath,
+		blockMetadataProvider: datastore.GetEntityMetadata("block"),
+		cache:                 noOpCache{},
+		retention:             newRetention(1, cold, basePath),
+	}
+	prev := store
+	SetupStore(bStore)
+	defer SetupStore(prev)
+
+	hashes := make(map[int64]string)
+	for r := int64(1); r <= 3; r++ {
+		b := new(block.Block)
+		b.Round = r
+		b.Hash = encryption.Hash(strconv.FormatInt(b.Round, 10))
+		require.NoError(t, bStore.writeToDisk(b.Hash, b))
+		hashes[r] = b.Hash
+	}
+	hashOf := func(r int64) (string, error) {
+		return hashes[r], nil
+	}
+
+	// a failed lookup stops the offloading before the round
+	require.Error(t, OffloadRounds(2, func(r int64) (string, error) {
+		if r == 2 {
+			return "", errors.New("no block")
+		}
+		return hashes[r], nil
+	}))
+	require.EqualValues(t, 1, GetRetentionStatus().ArchivedTo)
+
+	require.NoError(t, OffloadRounds(2, hashOf))
+	require.Equal(t, RetentionStatus{HotRounds: 1, ArchivedTo: 2, ColdStore: coldStoreDir}, GetRetentionStatus())
+
+	for r, hash := range hashes {
+		bp, err := getBlockFilePath(hash)
+		require.NoError(t, err)
+		_, err = os.Stat(filepath.Join(basePath, bp))
+		if r <= 2 {
+			require.True(t, os.IsNotExist(err), "round %d should be archived", r)
+		} else {
+			require.NoError(t, err, "round %d should be hot", r)
+		}
+
+		b, err := bStore.Read(hash)
+		require.NoError(t, err)
+		require.Equal(t, r, b.Round)
+	}
+
+	// offloading again is a no-op
+	require.NoError(t, OffloadRounds(2, hashOf))
+
+	// the progress survives a restart
+	require.EqualValues(t, 2, newRetention(1, cold, basePath).getArchivedTo())
+}
+
+func TestS3ColdStore(t *testing.T) {
+	var (
+		mutex   sync.Mutex
+		objects = make(map[string][]byte)
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		switch r.Method {
+		case http.MethodPut:
+			data, _ := io.ReadAll(r.Body)
+			objects[r.URL.Path] = data
+		case http.MethodGet:
+			data, ok := objects[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(data)
+		}
+	}))
+	defer srv.Close()
+
+	ss, err := newS3ColdStore(srv.URL, "blocks", "", "key", "secret")
+	require.NoError(t, err)
+
+	require.NoError(t, ss.Put("a/b/block.dat.zlib", []byte("data")))
+	require.Contains(t, objects, "/blocks/a/b/block.dat.zlib")
+
+	data, err := ss.Get("a/b/block.dat.zlib")
+	require.NoError(t, err)
+	require.Equal(t, []byte("data"), data)
+
+	_, err = ss.Get("missing")
+	require.Equal(t, ErrNotInColdStore, err)
+}
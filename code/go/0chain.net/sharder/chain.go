@@ -62,6 +62,8 @@ type Chain struct {
 	BlockTxnCache  *cache.LRU[string, *transaction.TransactionSummary]
 	SharderStats   Stats
 	BlockSyncStats *SyncStats
+	// initStates of the genesis block, used by the supply audit
+	initStates *state.InitStates
 }
 
 /*GetRoundChannel - get the round channel where the finalized rounds are put into for further processing */
@@ -71,6 +73,7 @@ func (sc *Chain) GetRoundChannel() chan *round.Round {
 
 /*SetupGenesisBlock - setup the genesis block for this chain */
 func (sc *Chain) SetupGenesisBlock(hash string, magicBlock *block.MagicBlock, initStates *state.InitStates) *block.Block {
+	sc.initStates = initStates
 	gr, gb := sc.GenerateGenesisBlock(hash, magicBlock, initStates)
 	sc.AddRound(gr)
 	sc.AddGenesisBlock(gb)
@@ -0,0 +1,204 @@
+package sharder
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/core/ememorystore"
+	"github.com/0chain/common/core/logging"
+	"go.uber.org/zap"
+)
+
+// ClientNonceInfo is the nonce of a client by the client transactions index.
+//
+// swagger:model ClientNonceInfo
+type ClientNonceInfo struct {
+	ClientID string `json:"client_id"`
+	// Nonce is the highest nonce of the stored transactions of the client.
+	Nonce int64 `json:"nonce"`
+	// NextNonce is the nonce of the next transaction of the client.
+	NextNonce int64 `json:"next_nonce"`
+	// Round of the transaction with the highest nonce.
+	Round int64 `json:"round"`
+	// Gaps are the missing nonces between the lowest and the highest nonces
+	// of the stored transactions of the client.
+	Gaps []transaction.NonceGap `json:"gaps,omitempty"`
+}
+
+// ErrClientTxnsIndex is returned when the transactions of a block can't be
+// added to the client transactions index.
+var ErrClientTxnsIndex = errors.New("client transactions index")
+
+// clientNoncesMutex serializes the updates of the client nonces, blocks are
+// stored by the finalization and by the health check at the same time
+var clientNoncesMutex sync.Mutex
+
+// storeClientTxnsRetry stores the client transactions of the block, retrying
+// on failures.
+func (sc *Chain) storeClientTxnsRetry(b *block.Block) (err error) {
+	delay := time.Millisecond
+	for tries := 1; tries <= 9; tries++ {
+		if err = sc.storeClientTxns(b); err == nil {
+			return nil
+		}
+		delay = 2 * delay
+		logging.Logger.Error("save client transactions index error",
+			zap.Int64("round", b.Round),
+			zap.String("block", b.Hash),
+			zap.Int("retry", tries),
+			zap.Duration("delay", delay),
+			zap.Error(err))
+		time.Sleep(delay)
+	}
+	return err
+}
+
+// storeClientTxns adds the transactions of the block to the client
+// transactions index and updates the nonces of their clients. Storing the
+// same block again doesn't change the index.
+func (sc *Chain) storeClientTxns(b *block.Block) error {
+	clientNoncesMutex.Lock()
+	defer clientNoncesMutex.Unlock()
+
+	var (
+		ctmd = datastore.GetEntityMetadata(transaction.ClientTxnMetaName)
+		cnmd = datastore.GetEntityMetadata(transaction.ClientNonceMetaName)
+		tctx = ememorystore.WithEntityConnection(common.GetRootContext(), ctmd)
+	)
+	defer ememorystore.Close(tctx)
+
+	var (
+		cTxns  = make([]datastore.Entity, 0, len(b.Txns))
+		nonces = make(map[string][]int64)
+	)
+	for _, txn := range b.Txns {
+		cTxns = append(cTxns, transaction.NewClientTxn(txn, b.Round))
+		nonces[txn.ClientID] = append(nonces[txn.ClientID], txn.Nonce)
+	}
+
+	if err := ctmd.GetStore().MultiWrite(tctx, ctmd, cTxns); err != nil {
+		return err
+	}
+
+	// the blocks can be stored out of order, the nonces of the blocks not
+	// stored yet are the gaps
+	for clientID, ns := range nonces {
+		cn, err := getClientNonce(tctx, clientID)
+		if err != nil {
+			return err
+		}
+		if cn == nil {
+			cn = &transaction.ClientNonce{
+				Key:      datastore.ToString(transaction.BuildClientNonceKey(clientID)),
+				ClientID: clientID,
+			}
+		}
+		for _, nonce := range ns {
+			cn.Add(nonce, b.Round)
+		}
+		if err := cnmd.GetStore().Write(tctx, cn); err != nil {
+			return err
+		}
+	}
+
+	return ememorystore.GetEntityCon(tctx, ctmd).Commit()
+}
+
+// getClientNonce returns the stored nonce of the client, nil if the client
+// has no transactions in the index.
+func getClientNonce(ctx context.Context, clientID string) (*transaction.ClientNonce, error) {
+	var (
+		cnmd = datastore.GetEntityMetadata(transaction.ClientNonceMetaName)
+		con  = ememorystore.GetEntityCon(ctx, cnmd)
+		key  = transaction.BuildClientNonceKey(clientID)
+	)
+	data, err := con.Conn.Get(con.ReadOptions, []byte(key))
+	if err != nil {
+		return nil, err
+	}
+	defer data.Free()
+
+	if !data.Exists() {
+		return nil, nil
+	}
+
+	cn := cnmd.Instance().(*transaction.ClientNonce)
+	if err := datastore.FromJSON(data.Data(), cn); err != nil {
+		return nil, err
+	}
+	return cn, nil
+}
+
+// GetClientNonce returns the nonce of the client by the client transactions
+// index.
+func (sc *Chain) GetClientNonce(ctx context.Context, clientID string) (*ClientNonceInfo, error) {
+	cnmd := datastore.GetEntityMetadata(transaction.ClientNonceMetaName)
+	ctx = ememorystore.WithEntityConnection(ctx, cnmd)
+	defer ememorystore.Close(ctx)
+
+	info := &ClientNonceInfo{ClientID: clientID, NextNonce: 1}
+	cn, err := getClientNonce(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if cn != nil {
+		info.Nonce, info.Round = cn.Nonce, cn.Round
+		info.NextNonce = cn.Nonce + 1
+		info.Gaps = cn.Gaps
+	}
+	return info, nil
+}
+
+// GetClientTxns returns a page of the transactions of the client by the client
+// transactions index, by round and nonce.
+func (sc *Chain) GetClientTxns(ctx context.Context, clientID string,
+	offset, limit int, desc bool) ([]*transaction.ClientTxn, error) {
+
+	ctmd := datastore.GetEntityMetadata(transaction.ClientTxnMetaName)
+	ctx = ememorystore.WithEntityConnection(ctx, ctmd)
+	defer ememorystore.Close(ctx)
+
+	var (
+		con    = ememorystore.GetEntityCon(ctx, ctmd)
+		prefix = []byte(transaction.ClientTxnPrefix(clientID))
+		iter   = con.Conn.NewIterator(con.ReadOptions)
+	)
+	defer iter.Close()
+
+	if desc {
+		// the keys of the client are below the prefix with its trailing
+		// ':' replaced by the next byte, ';'
+		upper := append([]byte(nil), prefix...)
+		upper[len(upper)-1] = ';'
+		iter.SeekForPrev(upper)
+	} else {
+		iter.Seek(prefix)
+	}
+
+	txns := make([]*transaction.ClientTxn, 0, limit)
+	for ; iter.ValidForPrefix(prefix) && len(txns) < limit; offset-- {
+		if offset <= 0 {
+			ct := ctmd.Instance().(*transaction.ClientTxn)
+			if err := datastore.FromJSON(iter.Value().Data(), ct); err != nil {
+				return nil, err
+			}
+			txns = append(txns, ct)
+		}
+
+		if desc {
+			iter.Prev()
+		} else {
+			iter.Next()
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+	return txns, nil
+}
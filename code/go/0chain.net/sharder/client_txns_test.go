@@ -0,0 +1,143 @@
+package sharder
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/core/ememorystore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientTxnsIndex(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "clienttxndb")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	db, err := ememorystore.CreateDB(tmpDir)
+	require.NoError(t, err)
+	ememorystore.AddPool(transaction.ClientTxnDBName, db)
+	transaction.SetupClientTxnEntity(ememorystore.GetStorageProvider())
+
+	newTxn := func(clientID string, nonce int64) *transaction.Transaction {
+		return &transaction.Transaction{
+			ClientID: clientID,
+			Nonce:    nonce,
+			Status:   transaction.TxnSuccess,
+			HashIDField: datastore.HashIDField{
+				Hash: fmt.Sprintf("%s:%d", clientID, nonce),
+			},
+		}
+	}
+
+	chain := Chain{}
+	// stored out of order, the nonce keeps the highest one
+	for _, r := range []int64{11, 10} {
+		b := block.NewBlock("", r)
+		nonce := (r - 10) * 2
+		b.Txns = []*transaction.Transaction{
+			newTxn("alice", nonce+1),
+			newTxn("alice", nonce+2),
+			newTxn("bob", r),
+		}
+		require.NoError(t, chain.storeClientTxns(b))
+	}
+
+	ctx := common.GetRootContext()
+	nonce, err := chain.GetClientNonce(ctx, "alice")
+	require.NoError(t, err)
+	require.Equal(t, &ClientNonceInfo{
+		ClientID:  "alice",
+		Nonce:     4,
+		NextNonce: 5,
+		Round:     11,
+	}, nonce)
+
+	// the gaps of the blocks not stored yet
+	for _, b := range []struct {
+		round  int64
+		nonces []int64
+	}{
+		{round: 22, nonces: []int64{7, 8}},
+		{round: 20, nonces: []int64{2}},
+		{round: 21, nonces: []int64{4}},
+	} {
+		blk := block.NewBlock("", b.round)
+		for _, n := range b.nonces {
+			blk.Txns = append(blk.Txns, newTxn("dave", n))
+		}
+		require.NoError(t, chain.storeClientTxns(blk))
+	}
+
+	nonce, err = chain.GetClientNonce(ctx, "dave")
+	require.NoError(t, err)
+	require.Equal(t, int64(9), nonce.NextNonce)
+	require.Equal(t, int64(22), nonce.Round)
+	require.Equal(t, []transaction.NonceGap{
+		{From: 3, To: 3},
+		{From: 5, To: 6},
+	}, nonce.Gaps)
+
+	nonce, err = chain.GetClientNonce(ctx, "carol")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), nonce.NextNonce)
+
+	nonces := func(txns []*transaction.ClientTxn) (ns []int64) {
+		for _, ct := range txns {
+			require.Equal(t, "alice", ct.ClientID)
+			ns = append(ns, ct.Nonce)
+		}
+		return
+	}
+
+	txns, err := chain.GetClientTxns(ctx, "alice", 0, 3, true)
+	require.NoError(t, err)
+	require.Equal(t, []int64{4, 3, 2}, nonces(txns))
+
+	txns, err = chain.GetClientTxns(ctx, "alice", 3, 3, true)
+	require.NoError(t, err)
+	require.Equal(t, []int64{1}, nonces(txns))
+
+	txns, err = chain.GetClientTxns(ctx, "alice", 1, 2, false)
+	require.NoError(t, err)
+	require.Equal(t, []int64{2, 3}, nonces(txns))
+	require.Equal(t, int64(10), txns[0].Round)
+	require.Equal(t, int64(11), txns[1].Round)
+
+	txns, err = chain.GetClientTxns(ctx, "carol", 0, 3, true)
+	require.NoError(t, err)
+	require.Empty(t, txns)
+
+	// the finalization and the health check store blocks at the same time,
+	// and the same block twice
+	var (
+		wg   sync.WaitGroup
+		errs = make(chan error, 20)
+	)
+	for r := int64(30); r < 40; r++ {
+		for i := 0; i < 2; i++ {
+			wg.Add(1)
+			go func(r int64) {
+				defer wg.Done()
+				blk := block.NewBlock("", r)
+				blk.Txns = []*transaction.Transaction{newTxn("erin", r-29)}
+				errs <- chain.storeClientTxns(blk)
+			}(r)
+		}
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	nonce, err = chain.GetClientNonce(ctx, "erin")
+	require.NoError(t, err)
+	require.Equal(t, int64(11), nonce.NextNonce)
+	require.Empty(t, nonce.Gaps)
+}
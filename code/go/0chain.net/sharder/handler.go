@@ -13,16 +13,22 @@ import (
 	"0chain.net/chaincore/chain"
 	"0chain.net/chaincore/diagnostics"
 	"0chain.net/chaincore/node"
+	"0chain.net/chaincore/transaction"
 	"0chain.net/core/build"
 	"0chain.net/core/common"
 	"0chain.net/core/config"
+	"0chain.net/sharder/blockstore"
+	commonsc "0chain.net/smartcontract/common"
 )
 
 func handlersMap() map[string]func(http.ResponseWriter, *http.Request) {
 	reqRespHandlers := map[string]common.ReqRespHandlerf{
 		"/v1/block/get":                    common.ToJSONResponse(BlockHandler),
 		"/v1/block/magic/get":              common.ToJSONResponse(MagicBlockHandler),
+		"/v1/block/retention":              common.ToJSONResponse(BlockRetentionHandler),
 		"/v1/transaction/get/confirmation": common.ToJSONResponse(TransactionConfirmationHandler),
+		"/v1/client/get/transactions":      common.ToJSONResponse(ClientTxnsHandler),
+		"/v1/client/get/nonce":             common.ToJSONResponse(ClientNonceHandler),
 		"/v1/healthcheck":                  common.ToJSONResponse(HealthcheckHandler),
 		"/v1/chain/get/stats":              common.ToJSONResponse(ChainStatsHandler),
 		"/_chain_stats":                    ChainStatsWriter,
@@ -31,6 +37,8 @@ func handlersMap() map[string]func(http.ResponseWriter, *http.Request) {
 		"/v1/state/nodes":                  common.ToJSONResponse(chain.StateNodesHandler),
 		"/v1/block/state_change":           common.ToJSONResponse(BlockStateChangeHandler),
 		"/_transaction_errors":             TransactionErrorWriter,
+		"/_diagnostics/replay_block":       config.AdminOnly(common.ToJSONResponse(ReplayBlockHandler)),
+		"/_diagnostics/supply_audit":       config.AdminOnly(common.ToJSONResponse(SupplyAuditHandler)),
 	}
 
 	handlers := make(map[string]func(http.ResponseWriter, *http.Request))
@@ -40,6 +48,89 @@ func handlersMap() map[string]func(http.ResponseWriter, *http.Request) {
 	return handlers
 }
 
+// swagger:route GET /v1/block/retention sharder GetBlockRetention
+// Block retention.
+// Retrieve the rounds of blocks the sharder keeps on its local disk and in
+// its cold store, both served by the block endpoints.
+//
+// responses:
+//  200: RetentionStatus
+func BlockRetentionHandler(ctx context.Context, r *http.Request) (interface{}, error) {
+	return blockstore.GetRetentionStatus(), nil
+}
+
+var errClientTxnIndexDisabled = common.NewError("client_txns_disabled",
+	"the client transactions index is not enabled on this sharder")
+
+// swagger:route GET /v1/client/get/transactions sharder GetClientTransactions
+// Client transactions.
+// Retrieve the transactions of a client stored by the sharder, by round and
+// nonce, from the client transactions index. Newest first by default.
+//
+// parameters:
+//   +name: client_id
+//	 in: query
+//	 type: string
+//	 required: true
+//	 description: Id of the client.
+//   +name: offset
+//	 in: query
+//	 type: string
+//	 description: Offset of the page.
+//   +name: limit
+//	 in: query
+//	 type: string
+//	 description: Size of the page, at most 50.
+//   +name: sort
+//	 in: query
+//	 type: string
+//	 description: desc (default) or asc.
+//
+// responses:
+//  200: []ClientTxn
+//  400:
+func ClientTxnsHandler(ctx context.Context, r *http.Request) (interface{}, error) {
+	if !transaction.ClientTxnIndexEnabled() {
+		return nil, errClientTxnIndexDisabled
+	}
+	clientID := r.FormValue("client_id")
+	if clientID == "" {
+		return nil, common.InvalidRequest("client id (parameter client_id) is required")
+	}
+	pagination, err := commonsc.GetPaginationParamsDefaultDesc(r.URL.Query())
+	if err != nil {
+		return nil, err
+	}
+	return GetSharderChain().GetClientTxns(ctx, clientID,
+		pagination.Offset, pagination.Limit, pagination.IsDescending)
+}
+
+// swagger:route GET /v1/client/get/nonce sharder GetClientNonce
+// Client nonce.
+// Retrieve the highest nonce of the stored transactions of a client and the
+// nonce of its next transaction, from the client transactions index.
+//
+// parameters:
+//   +name: client_id
+//	 in: query
+//	 type: string
+//	 required: true
+//	 description: Id of the client.
+//
+// responses:
+//  200: ClientNonceInfo
+//  400:
+func ClientNonceHandler(ctx context.Context, r *http.Request) (interface{}, error) {
+	if !transaction.ClientTxnIndexEnabled() {
+		return nil, errClientTxnIndexDisabled
+	}
+	clientID := r.FormValue("client_id")
+	if clientID == "" {
+		return nil, common.InvalidRequest("client id (parameter client_id) is required")
+	}
+	return GetSharderChain().GetClientNonce(ctx, clientID)
+}
+
 func BlockStateChangeHandler(ctx context.Context, r *http.Request) (interface{}, error) {
 	c := chain.GetServerChain()
 	return c.BlockStateChangeHandler(ctx, r)
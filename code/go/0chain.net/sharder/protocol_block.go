@@ -2,6 +2,7 @@ package sharder
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"net/url"
@@ -75,6 +76,12 @@ func (sc *Chain) UpdateFinalizedBlock(ctx context.Context, b *block.Block) {
 
 	wg.Run("store transactions", b.Round, func() error {
 		if err := sc.StoreTransactions(b); err != nil {
+			if errors.Is(err, ErrClientTxnsIndex) {
+				// the health check stores the transactions of the round again
+				Logger.Error("db store transaction failed",
+					zap.Int64("round", b.Round), zap.Error(err))
+				return nil
+			}
 			Logger.Panic(fmt.Sprintf("db store transaction failed. Error: %v", err))
 		}
 		return nil
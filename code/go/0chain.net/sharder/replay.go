@@ -0,0 +1,82 @@
+package sharder
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/chaincore/chain"
+	"0chain.net/core/common"
+	"github.com/0chain/common/core/logging"
+	"go.uber.org/zap"
+)
+
+// ReplayBlockFromStore loads the finalized block of the given round and its
+// previous block from the block store and re-executes the block on top of
+// the previous state root. The replayed state changes are diffed against
+// the block state change kept in memory or, if fetchStateChange is set and
+// it is not available locally, requested from the miners.
+func (sc *Chain) ReplayBlockFromStore(ctx context.Context, roundNum int64, fetchStateChange bool) (*chain.BlockReplay, error) {
+	if roundNum <= 0 {
+		return nil, common.InvalidRequest("round must be greater than zero")
+	}
+	if lfb := sc.GetLatestFinalizedBlock(); lfb != nil && roundNum > lfb.Round {
+		return nil, common.InvalidRequest("block is not finalized yet")
+	}
+
+	b, err := sc.getFinalizedBlockFromStore(ctx, roundNum)
+	if err != nil {
+		return nil, err
+	}
+	pb, err := sc.getFinalizedBlockFromStore(ctx, roundNum-1)
+	if err != nil {
+		return nil, err
+	}
+	if pb.Hash != b.PrevHash {
+		return nil, common.NewErrorf("replay_block", "previous block mismatch, want: %s, got: %s",
+			b.PrevHash, pb.Hash)
+	}
+
+	bsc := sc.LocalBlockStateChange(ctx, b.Hash)
+	if bsc == nil && fetchStateChange {
+		if bsc, err = sc.FetchBlockStateChange(b); err != nil {
+			logging.Logger.Warn("replay block - fetch block state change",
+				zap.Int64("round", roundNum), zap.Error(err))
+		}
+	}
+
+	return sc.ReplayBlock(ctx, b, pb, bsc)
+}
+
+func (sc *Chain) getFinalizedBlockFromStore(ctx context.Context, roundNum int64) (*block.Block, error) {
+	hash, err := sc.GetBlockHash(ctx, roundNum)
+	if err != nil {
+		return nil, err
+	}
+	return sc.GetBlockFromStore(hash, roundNum)
+}
+
+// ReplayBlockHandler re-executes a finalized block and reports the
+// per transaction outputs, events and state changes. Replaying is expensive,
+// the request needs the admin token of the node as a bearer token.
+//
+// parameters:
+//
+//	+name: round
+//	 in: query
+//	 type: string
+//	 required: true
+//	 description: Round of the finalized block to replay.
+//	+name: fetch_state_change
+//	 in: query
+//	 type: string
+//	 description: Request the block state change from the miners when it is not held locally.
+func ReplayBlockHandler(ctx context.Context, r *http.Request) (interface{}, error) {
+	roundNum, err := strconv.ParseInt(r.FormValue("round"), 10, 64)
+	if err != nil {
+		return nil, common.InvalidRequest("invalid round: " + err.Error())
+	}
+	fetch, _ := strconv.ParseBool(r.FormValue("fetch_state_change"))
+	return GetSharderChain().ReplayBlockFromStore(ctx, roundNum, fetch)
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"bufio"
 	"context"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
@@ -50,6 +51,12 @@ func main() {
 	magicBlockFile := flag.String("magic_block_file", "", "magic_block_file")
 	initialStatesFile := flag.String("initial_states", "", "initial_states")
 	flag.String("nodes_file", "", "nodes_file (deprecated)")
+	replayRound := flag.Int64("replay_round", 0, "replay the finalized block of the round, print the report and exit")
+	auditSupplyRound := flag.Int64("audit_supply_round", -1, "audit the token supply at the finalized round, 0 for the latest, print the report and exit")
+	exportArchive := flag.String("export_archive", "", "export the chain history of the rounds to an archive in the directory, print the summary and exit")
+	exportFromRound := flag.Int64("export_from_round", 0, "first round of the exported chain history")
+	exportToRound := flag.Int64("export_to_round", 0, "last round of the exported chain history, 0 for the latest finalized")
+	importArchive := flag.String("import_archive", "", "import the chain history archive of the directory, print the summary and exit")
 	workdir := ""
 	flag.StringVar(&workdir, "work_dir", "", "work_dir")
 
@@ -60,11 +67,7 @@ func main() {
 	config.SetupSmartContractConfig(workdir)
 	initIntegrationsTests()
 
-	if config.Development() {
-		logging.InitLogging("development", workdir)
-	} else {
-		logging.InitLogging("production", workdir)
-	}
+	initLogging(workdir)
 
 	config.Configuration().ChainID = viper.GetString("server_chain.id")
 	transaction.SetTxnTimeout(int64(viper.GetInt("server_chain.transaction.timeout")))
@@ -212,6 +215,12 @@ func main() {
 	sc.SetupHealthyRound()
 
 	common.ConfigRateLimits()
+	config.OnReload("network.user_handlers", common.ReloadRateLimits)
+	config.OnReload("logging.level", func() { initLogging(workdir) })
+	config.OnReload("storage.cache.total_blocks", func() {
+		blockstore.ResizeCache(viper.GetInt("storage.cache.total_blocks"))
+	})
+	go config.WatchReloadSignal(ctx)
 	initN2NHandlers(sc)
 	initWorkers(ctx)
 
@@ -223,6 +232,28 @@ func main() {
 
 	Logger.Info("finish load latest blocks from store")
 
+	if *replayRound > 0 {
+		replayBlock(ctx, sc, *replayRound)
+		return
+	}
+
+	if *auditSupplyRound >= 0 {
+		auditSupply(ctx, sc, *auditSupplyRound)
+		return
+	}
+
+	if *exportArchive != "" {
+		index, err := sc.ExportArchive(ctx, *exportArchive, *exportFromRound, *exportToRound)
+		printArchiveIndex("export archive", index, err)
+		return
+	}
+
+	if *importArchive != "" {
+		index, err := sc.ImportArchive(ctx, *importArchive)
+		printArchiveIndex("import archive", index, err)
+		return
+	}
+
 	sharder.SetupWorkers(ctx)
 
 	startBlocksInfoLogs(sc)
@@ -262,6 +293,57 @@ func main() {
 
 }
 
+// replayBlock re-executes the finalized block of the round and writes the
+// report to stdout.
+func replayBlock(ctx context.Context, sc *sharder.Chain, roundNum int64) {
+	report, err := sc.ReplayBlockFromStore(ctx, roundNum, true)
+	if err != nil {
+		Logger.Error("replay block", zap.Int64("round", roundNum), zap.Error(err))
+		return
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		Logger.Error("replay block - encode report", zap.Error(err))
+	}
+}
+
+// auditSupply audits the token supply at the finalized round and writes the
+// report to stdout.
+func auditSupply(ctx context.Context, sc *sharder.Chain, roundNum int64) {
+	report, err := sc.AuditSupply(ctx, roundNum)
+	if err != nil {
+		Logger.Error("audit supply", zap.Int64("round", roundNum), zap.Error(err))
+		return
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		Logger.Error("audit supply - encode report", zap.Error(err))
+	}
+}
+
+// printArchiveIndex writes the summary of an exported or imported chain
+// history archive to stdout, the full index is in the archive.
+func printArchiveIndex(op string, index *sharder.ArchiveIndex, err error) {
+	if err != nil {
+		Logger.Error(op, zap.Error(err))
+		return
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(map[string]interface{}{
+		"version":      index.Version,
+		"chain_id":     index.ChainID,
+		"from_round":   index.FromRound,
+		"to_round":     index.ToRound,
+		"rounds":       len(index.Rounds),
+		"magic_blocks": len(index.MagicBlocks),
+	}); err != nil {
+		Logger.Error(op+" - encode index", zap.Error(err))
+	}
+}
+
 func initScheme(signatureScheme encryption.SignatureScheme, reader io.Reader) {
 	err2 := signatureScheme.ReadKeys(reader)
 	if err2 != nil {
@@ -404,6 +486,9 @@ func initEntities(workdir string) {
 	block.SetupBlockEventDB(workdir)
 
 	transaction.SetupTxnSummaryDB(workdir)
+	if viper.GetBool("storage.client_txns.enabled") {
+		transaction.SetupClientTxnDB(workdir)
+	}
 	ememoryStorage := ememorystore.GetStorageProvider()
 	block.SetupBlockSummaryEntity(ememoryStorage)
 	block.SetupBlockEventEntity(ememoryStorage)
@@ -416,6 +501,9 @@ func initEntities(workdir string) {
 	transaction.SetupEntity(memoryStorage)
 
 	transaction.SetupTxnSummaryEntity(ememoryStorage)
+	if viper.GetBool("storage.client_txns.enabled") {
+		transaction.SetupClientTxnEntity(ememoryStorage)
+	}
 	block.SetupMagicBlockMapEntity(ememoryStorage)
 
 	sharder.SetupBlockSummaries()
@@ -441,3 +529,11 @@ func initWorkers(ctx context.Context) {
 	serverChain := chain.GetServerChain()
 	serverChain.SetupWorkers(ctx)
 }
+
+func initLogging(workdir string) {
+	if config.Development() {
+		logging.InitLogging("development", workdir)
+	} else {
+		logging.InitLogging("production", workdir)
+	}
+}
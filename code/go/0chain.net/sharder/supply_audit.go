@@ -0,0 +1,65 @@
+package sharder
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/core/common"
+	"0chain.net/core/config"
+	"0chain.net/smartcontract/supply"
+	"github.com/0chain/common/core/currency"
+)
+
+// AuditSupply walks the state of the finalized block of the given round,
+// or of the latest finalized block if the round is zero, and reports the
+// token supply breakdown and the discrepancies found. The mint and burn
+// records are taken from the event database when it is enabled.
+func (sc *Chain) AuditSupply(ctx context.Context, roundNum int64) (*supply.Report, error) {
+	lfb := sc.GetLatestFinalizedBlock()
+	if lfb == nil {
+		return nil, common.NewError("audit_supply", "no finalized block")
+	}
+	if roundNum < 0 {
+		return nil, common.InvalidRequest("round must not be negative")
+	}
+	if roundNum == 0 {
+		roundNum = lfb.Round
+	}
+	if roundNum > lfb.Round {
+		return nil, common.InvalidRequest("block is not finalized yet")
+	}
+
+	b, err := sc.getFinalizedBlockFromStore(ctx, roundNum)
+	if err != nil {
+		return nil, err
+	}
+
+	return supply.Audit(ctx, block.CreateState(sc.GetStateDB(), b.Round, b.ClientStateHash), supply.Options{
+		Round:     b.Round,
+		MaxSupply: currency.Coin(config.MaxTokenSupply),
+		Genesis:   sc.initStates,
+		EventDb:   sc.GetEventDb(),
+	})
+}
+
+// SupplyAuditHandler audits the token supply in the state of a finalized
+// round.
+//
+// parameters:
+//
+//	+name: round
+//	 in: query
+//	 type: string
+//	 description: Round of the finalized block to audit, the latest finalized block by default.
+func SupplyAuditHandler(ctx context.Context, r *http.Request) (interface{}, error) {
+	var roundNum int64
+	if v := r.FormValue("round"); v != "" {
+		var err error
+		if roundNum, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return nil, common.InvalidRequest("invalid round: " + err.Error())
+		}
+	}
+	return GetSharderChain().AuditSupply(ctx, roundNum)
+}
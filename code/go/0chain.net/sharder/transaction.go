@@ -3,6 +3,7 @@ package sharder
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math"
 	"time"
 
@@ -112,8 +113,18 @@ func (sc *Chain) StoreTransactions(b *block.Block) error {
 		}
 	}
 
-	delay := time.Millisecond
 	ts := time.Now()
+
+	// the client transactions index is stored before the summaries, the
+	// health check stores the block again, index included, when the
+	// summaries of the round are missing
+	if transaction.ClientTxnIndexEnabled() {
+		if err := sc.storeClientTxnsRetry(b); err != nil {
+			return fmt.Errorf("%w: %v", ErrClientTxnsIndex, err)
+		}
+	}
+
+	delay := time.Millisecond
 	var success bool
 	for tries := 1; tries <= 9; tries++ {
 		err := sc.storeTransactions(sTxns, b.Round)
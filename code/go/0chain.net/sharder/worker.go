@@ -14,6 +14,7 @@ import (
 	"0chain.net/core/datastore"
 	"0chain.net/core/ememorystore"
 	"0chain.net/core/viper"
+	"0chain.net/sharder/blockstore"
 	"0chain.net/smartcontract/minersc"
 
 	"github.com/0chain/common/core/logging"
@@ -39,6 +40,7 @@ func SetupWorkers(ctx context.Context) {
 	go sc.UpdateMagicBlockWorker(ctx)
 	go sc.RegisterSharderKeepWorker(ctx)
 	go sc.SharderHealthCheck(ctx)
+	go sc.BlockRetentionWorker(ctx)
 
 	go sc.TrackTransactionErrors(ctx)
 }
@@ -83,6 +85,36 @@ func (sc *Chain) hasBlockTransactions(ctx context.Context, b *block.Block) bool
 	return true
 }
 
+// BlockRetentionWorker moves the blocks older than the hot rounds of the
+// block store to its cold store.
+func (sc *Chain) BlockRetentionWorker(ctx context.Context) {
+	hotRounds := blockstore.HotRounds()
+	if hotRounds <= 0 {
+		return // all the blocks are kept on the local disk
+	}
+
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			lfb := sc.GetLatestFinalizedBlock()
+			if lfb == nil || lfb.Round <= hotRounds {
+				continue
+			}
+			if err := blockstore.OffloadRounds(lfb.Round-hotRounds, func(r int64) (string, error) {
+				return sc.GetBlockHash(ctx, r)
+			}); err != nil {
+				logging.Logger.Error("block retention: moving blocks to the cold store failed",
+					zap.Error(err))
+			}
+		}
+	}
+}
+
 func (sc *Chain) RegisterSharderKeepWorker(ctx context.Context) {
 	if !sc.ChainConfig.IsViewChangeEnabled() {
 		return // don't send sharder_keep if view_change is false
@@ -9,6 +9,8 @@ type BenchDataMpt struct {
 	PublicKeys           []string         `json:"public_keys"`
 	PrivateKeys          []string         `json:"private_keys"`
 	Miners               []string         `json:"miners"`
+	MinerKeys            []string         `json:"miner_keys"`
+	MinerPrivateKeys     []string         `json:"miner_private_keys"`
 	Sharders             []string         `json:"sharders"`
 	SharderKeys          []string         `json:"sharder_keys"`
 	ValidatorIds         []string         `json:"validator_ids"`
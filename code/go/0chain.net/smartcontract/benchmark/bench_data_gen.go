@@ -9,9 +9,9 @@ import (
 // MarshalMsg implements msgp.Marshaler
 func (z *BenchDataMpt) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 12
+	// map header, size 14
 	// string "Clients"
-	o = append(o, 0x8c, 0xa7, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73)
+	o = append(o, 0x8e, 0xa7, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73)
 	o = msgp.AppendArrayHeader(o, uint32(len(z.Clients)))
 	for za0001 := range z.Clients {
 		o = msgp.AppendString(o, z.Clients[za0001])
@@ -34,35 +34,47 @@ func (z *BenchDataMpt) MarshalMsg(b []byte) (o []byte, err error) {
 	for za0004 := range z.Miners {
 		o = msgp.AppendString(o, z.Miners[za0004])
 	}
+	// string "MinerKeys"
+	o = append(o, 0xa9, 0x4d, 0x69, 0x6e, 0x65, 0x72, 0x4b, 0x65, 0x79, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.MinerKeys)))
+	for za0005 := range z.MinerKeys {
+		o = msgp.AppendString(o, z.MinerKeys[za0005])
+	}
+	// string "MinerPrivateKeys"
+	o = append(o, 0xb0, 0x4d, 0x69, 0x6e, 0x65, 0x72, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.MinerPrivateKeys)))
+	for za0006 := range z.MinerPrivateKeys {
+		o = msgp.AppendString(o, z.MinerPrivateKeys[za0006])
+	}
 	// string "Sharders"
 	o = append(o, 0xa8, 0x53, 0x68, 0x61, 0x72, 0x64, 0x65, 0x72, 0x73)
 	o = msgp.AppendArrayHeader(o, uint32(len(z.Sharders)))
-	for za0005 := range z.Sharders {
-		o = msgp.AppendString(o, z.Sharders[za0005])
+	for za0007 := range z.Sharders {
+		o = msgp.AppendString(o, z.Sharders[za0007])
 	}
 	// string "SharderKeys"
 	o = append(o, 0xab, 0x53, 0x68, 0x61, 0x72, 0x64, 0x65, 0x72, 0x4b, 0x65, 0x79, 0x73)
 	o = msgp.AppendArrayHeader(o, uint32(len(z.SharderKeys)))
-	for za0006 := range z.SharderKeys {
-		o = msgp.AppendString(o, z.SharderKeys[za0006])
+	for za0008 := range z.SharderKeys {
+		o = msgp.AppendString(o, z.SharderKeys[za0008])
 	}
 	// string "ValidatorIds"
 	o = append(o, 0xac, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x49, 0x64, 0x73)
 	o = msgp.AppendArrayHeader(o, uint32(len(z.ValidatorIds)))
-	for za0007 := range z.ValidatorIds {
-		o = msgp.AppendString(o, z.ValidatorIds[za0007])
+	for za0009 := range z.ValidatorIds {
+		o = msgp.AppendString(o, z.ValidatorIds[za0009])
 	}
 	// string "ValidatorPublicKeys"
 	o = append(o, 0xb3, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x73)
 	o = msgp.AppendArrayHeader(o, uint32(len(z.ValidatorPublicKeys)))
-	for za0008 := range z.ValidatorPublicKeys {
-		o = msgp.AppendString(o, z.ValidatorPublicKeys[za0008])
+	for za0010 := range z.ValidatorPublicKeys {
+		o = msgp.AppendString(o, z.ValidatorPublicKeys[za0010])
 	}
 	// string "ValidatorPrivateKeys"
 	o = append(o, 0xb4, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x73)
 	o = msgp.AppendArrayHeader(o, uint32(len(z.ValidatorPrivateKeys)))
-	for za0009 := range z.ValidatorPrivateKeys {
-		o = msgp.AppendString(o, z.ValidatorPrivateKeys[za0009])
+	for za0011 := range z.ValidatorPrivateKeys {
+		o = msgp.AppendString(o, z.ValidatorPrivateKeys[za0011])
 	}
 	// string "InactiveSharder"
 	o = append(o, 0xaf, 0x49, 0x6e, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x53, 0x68, 0x61, 0x72, 0x64, 0x65, 0x72)
@@ -174,98 +186,136 @@ func (z *BenchDataMpt) UnmarshalMsg(bts []byte) (o []byte, err error) {
 					return
 				}
 			}
-		case "Sharders":
+		case "MinerKeys":
 			var zb0006 uint32
 			zb0006, bts, err = msgp.ReadArrayHeaderBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "Sharders")
+				err = msgp.WrapError(err, "MinerKeys")
 				return
 			}
-			if cap(z.Sharders) >= int(zb0006) {
-				z.Sharders = (z.Sharders)[:zb0006]
+			if cap(z.MinerKeys) >= int(zb0006) {
+				z.MinerKeys = (z.MinerKeys)[:zb0006]
 			} else {
-				z.Sharders = make([]string, zb0006)
+				z.MinerKeys = make([]string, zb0006)
 			}
-			for za0005 := range z.Sharders {
-				z.Sharders[za0005], bts, err = msgp.ReadStringBytes(bts)
+			for za0005 := range z.MinerKeys {
+				z.MinerKeys[za0005], bts, err = msgp.ReadStringBytes(bts)
 				if err != nil {
-					err = msgp.WrapError(err, "Sharders", za0005)
+					err = msgp.WrapError(err, "MinerKeys", za0005)
 					return
 				}
 			}
-		case "SharderKeys":
+		case "MinerPrivateKeys":
 			var zb0007 uint32
 			zb0007, bts, err = msgp.ReadArrayHeaderBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "SharderKeys")
+				err = msgp.WrapError(err, "MinerPrivateKeys")
 				return
 			}
-			if cap(z.SharderKeys) >= int(zb0007) {
-				z.SharderKeys = (z.SharderKeys)[:zb0007]
+			if cap(z.MinerPrivateKeys) >= int(zb0007) {
+				z.MinerPrivateKeys = (z.MinerPrivateKeys)[:zb0007]
 			} else {
-				z.SharderKeys = make([]string, zb0007)
+				z.MinerPrivateKeys = make([]string, zb0007)
 			}
-			for za0006 := range z.SharderKeys {
-				z.SharderKeys[za0006], bts, err = msgp.ReadStringBytes(bts)
+			for za0006 := range z.MinerPrivateKeys {
+				z.MinerPrivateKeys[za0006], bts, err = msgp.ReadStringBytes(bts)
 				if err != nil {
-					err = msgp.WrapError(err, "SharderKeys", za0006)
+					err = msgp.WrapError(err, "MinerPrivateKeys", za0006)
 					return
 				}
 			}
-		case "ValidatorIds":
+		case "Sharders":
 			var zb0008 uint32
 			zb0008, bts, err = msgp.ReadArrayHeaderBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "ValidatorIds")
+				err = msgp.WrapError(err, "Sharders")
 				return
 			}
-			if cap(z.ValidatorIds) >= int(zb0008) {
-				z.ValidatorIds = (z.ValidatorIds)[:zb0008]
+			if cap(z.Sharders) >= int(zb0008) {
+				z.Sharders = (z.Sharders)[:zb0008]
 			} else {
-				z.ValidatorIds = make([]string, zb0008)
+				z.Sharders = make([]string, zb0008)
 			}
-			for za0007 := range z.ValidatorIds {
-				z.ValidatorIds[za0007], bts, err = msgp.ReadStringBytes(bts)
+			for za0007 := range z.Sharders {
+				z.Sharders[za0007], bts, err = msgp.ReadStringBytes(bts)
 				if err != nil {
-					err = msgp.WrapError(err, "ValidatorIds", za0007)
+					err = msgp.WrapError(err, "Sharders", za0007)
 					return
 				}
 			}
-		case "ValidatorPublicKeys":
+		case "SharderKeys":
 			var zb0009 uint32
 			zb0009, bts, err = msgp.ReadArrayHeaderBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "ValidatorPublicKeys")
+				err = msgp.WrapError(err, "SharderKeys")
 				return
 			}
-			if cap(z.ValidatorPublicKeys) >= int(zb0009) {
-				z.ValidatorPublicKeys = (z.ValidatorPublicKeys)[:zb0009]
+			if cap(z.SharderKeys) >= int(zb0009) {
+				z.SharderKeys = (z.SharderKeys)[:zb0009]
 			} else {
-				z.ValidatorPublicKeys = make([]string, zb0009)
+				z.SharderKeys = make([]string, zb0009)
 			}
-			for za0008 := range z.ValidatorPublicKeys {
-				z.ValidatorPublicKeys[za0008], bts, err = msgp.ReadStringBytes(bts)
+			for za0008 := range z.SharderKeys {
+				z.SharderKeys[za0008], bts, err = msgp.ReadStringBytes(bts)
 				if err != nil {
-					err = msgp.WrapError(err, "ValidatorPublicKeys", za0008)
+					err = msgp.WrapError(err, "SharderKeys", za0008)
 					return
 				}
 			}
-		case "ValidatorPrivateKeys":
+		case "ValidatorIds":
 			var zb0010 uint32
 			zb0010, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ValidatorIds")
+				return
+			}
+			if cap(z.ValidatorIds) >= int(zb0010) {
+				z.ValidatorIds = (z.ValidatorIds)[:zb0010]
+			} else {
+				z.ValidatorIds = make([]string, zb0010)
+			}
+			for za0009 := range z.ValidatorIds {
+				z.ValidatorIds[za0009], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "ValidatorIds", za0009)
+					return
+				}
+			}
+		case "ValidatorPublicKeys":
+			var zb0011 uint32
+			zb0011, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ValidatorPublicKeys")
+				return
+			}
+			if cap(z.ValidatorPublicKeys) >= int(zb0011) {
+				z.ValidatorPublicKeys = (z.ValidatorPublicKeys)[:zb0011]
+			} else {
+				z.ValidatorPublicKeys = make([]string, zb0011)
+			}
+			for za0010 := range z.ValidatorPublicKeys {
+				z.ValidatorPublicKeys[za0010], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "ValidatorPublicKeys", za0010)
+					return
+				}
+			}
+		case "ValidatorPrivateKeys":
+			var zb0012 uint32
+			zb0012, bts, err = msgp.ReadArrayHeaderBytes(bts)
 			if err != nil {
 				err = msgp.WrapError(err, "ValidatorPrivateKeys")
 				return
 			}
-			if cap(z.ValidatorPrivateKeys) >= int(zb0010) {
-				z.ValidatorPrivateKeys = (z.ValidatorPrivateKeys)[:zb0010]
+			if cap(z.ValidatorPrivateKeys) >= int(zb0012) {
+				z.ValidatorPrivateKeys = (z.ValidatorPrivateKeys)[:zb0012]
 			} else {
-				z.ValidatorPrivateKeys = make([]string, zb0010)
+				z.ValidatorPrivateKeys = make([]string, zb0012)
 			}
-			for za0009 := range z.ValidatorPrivateKeys {
-				z.ValidatorPrivateKeys[za0009], bts, err = msgp.ReadStringBytes(bts)
+			for za0011 := range z.ValidatorPrivateKeys {
+				z.ValidatorPrivateKeys[za0011], bts, err = msgp.ReadStringBytes(bts)
 				if err != nil {
-					err = msgp.WrapError(err, "ValidatorPrivateKeys", za0009)
+					err = msgp.WrapError(err, "ValidatorPrivateKeys", za0011)
 					return
 				}
 			}
@@ -317,25 +367,33 @@ func (z *BenchDataMpt) Msgsize() (s int) {
 	for za0004 := range z.Miners {
 		s += msgp.StringPrefixSize + len(z.Miners[za0004])
 	}
+	s += 10 + msgp.ArrayHeaderSize
+	for za0005 := range z.MinerKeys {
+		s += msgp.StringPrefixSize + len(z.MinerKeys[za0005])
+	}
+	s += 17 + msgp.ArrayHeaderSize
+	for za0006 := range z.MinerPrivateKeys {
+		s += msgp.StringPrefixSize + len(z.MinerPrivateKeys[za0006])
+	}
 	s += 9 + msgp.ArrayHeaderSize
-	for za0005 := range z.Sharders {
-		s += msgp.StringPrefixSize + len(z.Sharders[za0005])
+	for za0007 := range z.Sharders {
+		s += msgp.StringPrefixSize + len(z.Sharders[za0007])
 	}
 	s += 12 + msgp.ArrayHeaderSize
-	for za0006 := range z.SharderKeys {
-		s += msgp.StringPrefixSize + len(z.SharderKeys[za0006])
+	for za0008 := range z.SharderKeys {
+		s += msgp.StringPrefixSize + len(z.SharderKeys[za0008])
 	}
 	s += 13 + msgp.ArrayHeaderSize
-	for za0007 := range z.ValidatorIds {
-		s += msgp.StringPrefixSize + len(z.ValidatorIds[za0007])
+	for za0009 := range z.ValidatorIds {
+		s += msgp.StringPrefixSize + len(z.ValidatorIds[za0009])
 	}
 	s += 20 + msgp.ArrayHeaderSize
-	for za0008 := range z.ValidatorPublicKeys {
-		s += msgp.StringPrefixSize + len(z.ValidatorPublicKeys[za0008])
+	for za0010 := range z.ValidatorPublicKeys {
+		s += msgp.StringPrefixSize + len(z.ValidatorPublicKeys[za0010])
 	}
 	s += 21 + msgp.ArrayHeaderSize
-	for za0009 := range z.ValidatorPrivateKeys {
-		s += msgp.StringPrefixSize + len(z.ValidatorPrivateKeys[za0009])
+	for za0011 := range z.ValidatorPrivateKeys {
+		s += msgp.StringPrefixSize + len(z.ValidatorPrivateKeys[za0011])
 	}
 	s += 16 + msgp.StringPrefixSize + len(z.InactiveSharder) + 18 + msgp.StringPrefixSize + len(z.InactiveSharderPK) + 4 + z.Now.Msgsize()
 	return
@@ -159,6 +159,14 @@ const (
 	OptionsSmartContractEventFile  = Options + "smart_contract_event_file"
 	OptionsEventDatabaseEventFile  = Options + "event_database_event_file"
 	OptionVerifyBurnedTokens       = Options + "verify_burned_tokens"
+	OptionReportFormat             = Options + "report_format"
+	OptionReportFile               = Options + "report_file"
+	OptionBaselineFile             = Options + "baseline_file"
+	OptionRegressionThreshold      = Options + "regression_threshold"
+	OptionRegressionThresholds     = Options + "regression_thresholds"
+	OptionCostTableFile            = Options + "cost_table_file"
+	OptionCostReferenceTest        = Options + "cost_reference_test"
+	OptionCostReferenceValue       = Options + "cost_reference_value"
 
 	MinerMOwner       = SmartContract + MinerSc + "owner_id"
 	MinerMaxDelegates = SmartContract + MinerSc + "max_delegates"
@@ -447,6 +455,8 @@ var MockBenchData = BenchData{
 		PublicKeys:           make([]string, 100),
 		PrivateKeys:          make([]string, 100),
 		Miners:               make([]string, 100),
+		MinerKeys:            make([]string, 100),
+		MinerPrivateKeys:     make([]string, 100),
 		Sharders:             make([]string, 100),
 		SharderKeys:          make([]string, 100),
 		ValidatorIds:         make([]string, 100),
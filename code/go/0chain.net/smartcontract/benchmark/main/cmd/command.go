@@ -37,6 +37,10 @@ func init() {
 	pflag.StringSlice("tests", nil, "comma delimited list of test suites")
 	pflag.Bool("verbose", true, "verbose")
 	pflag.StringSlice("omit", nil, "comma delimited list of tests to ommit")
+	pflag.String("format", "", "results format: text, json or csv")
+	pflag.String("report", "", "path to write the results report to")
+	pflag.String("baseline", "", "path to a json report to check for regressions against")
+	pflag.String("costs", "", "path to write a proposed cost table to")
 
 	//	pflag.Parse()
 	//err := viper.BindPFlags(pflag.CommandLine)
@@ -50,6 +54,14 @@ func init() {
 	_ = viper.BindEnv(bk.OptionOmittedTests, "OMIT")
 	_ = viper.BindPFlag(bk.OptionVerbose, pflag.Lookup("verbose"))
 	_ = viper.BindEnv(bk.OptionVerbose, "VERBOSE")
+	_ = viper.BindPFlag(bk.OptionReportFormat, pflag.Lookup("format"))
+	_ = viper.BindEnv(bk.OptionReportFormat, "FORMAT")
+	_ = viper.BindPFlag(bk.OptionReportFile, pflag.Lookup("report"))
+	_ = viper.BindEnv(bk.OptionReportFile, "REPORT")
+	_ = viper.BindPFlag(bk.OptionBaselineFile, pflag.Lookup("baseline"))
+	_ = viper.BindEnv(bk.OptionBaselineFile, "BASELINE")
+	_ = viper.BindPFlag(bk.OptionCostTableFile, pflag.Lookup("costs"))
+	_ = viper.BindEnv(bk.OptionCostTableFile, "COSTS")
 
 	impl := chain.NewConfigImpl(&chain.ConfigData{})
 	config.Configuration().ChainConfig = impl
@@ -105,8 +117,14 @@ var rootCmd = &cobra.Command{
 		log.Println()
 		log.Println("tests took", time.Since(testsTimer))
 		log.Println("benchmark took", time.Since(totalTimer))
-		printTimings(results)
-		printResults(results)
+		format := viper.GetString(bk.OptionReportFormat)
+		if format == "" || format == reportFormatText || viper.GetString(bk.OptionReportFile) != "" {
+			printTimings(results)
+			printResults(results)
+		}
+		if err := reportResults(results); err != nil {
+			log.Fatal(err)
+		}
 	},
 }
 
@@ -87,6 +87,18 @@ func getBalances(
 	if data != nil {
 		bk.MinerID = data.Miners[0]
 		node.Self.Underlying().SetKey(data.Miners[0])
+		for i := range data.MinerKeys {
+			var n = node.Provider()
+			if err := n.SetID(data.Miners[i]); err != nil {
+				log.Fatal(err)
+			}
+			n.PublicKey = data.MinerKeys[i]
+			n.Type = node.NodeTypeMiner
+			n.SetSignatureSchemeType(encryption.SignatureSchemeBls0chain)
+			if err := magicBlock.Miners.AddNode(n); err != nil {
+				log.Fatal(err)
+			}
+		}
 		for i := range data.Sharders {
 			var n = node.Provider()
 			if err := n.SetID(data.Sharders[i]); err != nil {
@@ -261,8 +273,9 @@ func setUpMpt(
 
 	var wg sync.WaitGroup
 	var (
-		blobbers                                                                             []*storagesc.StorageNode
-		miners, sharders, sharderKeys, validators, validatorPublicKeys, ValidatorPrivateKeys []string
+		blobbers                                                   []*storagesc.StorageNode
+		miners, minerKeys, minerPrivateKeys, sharders, sharderKeys []string
+		validators, validatorPublicKeys, ValidatorPrivateKeys      []string
 	)
 
 	wg.Add(1)
@@ -303,7 +316,12 @@ func setUpMpt(
 	go func() {
 		defer wg.Done()
 		timer := time.Now()
-		miners, _ = minersc.AddMockMiners(clients, eventDb, balances, getMockIdKeyPair)
+		miners, minerKeys = minersc.AddMockMiners(clients, eventDb, balances,
+			func() (string, string, error) {
+				id, public, private, err := createKey()
+				minerPrivateKeys = append(minerPrivateKeys, private)
+				return id, public, err
+			})
 		log.Println("added miners\t", time.Since(timer))
 	}()
 
@@ -530,6 +548,13 @@ func setUpMpt(
 		} else {
 			benchData.Miners = miners[:listLength]
 		}
+		if len(minerKeys) < listLength {
+			benchData.MinerKeys = minerKeys
+			benchData.MinerPrivateKeys = minerPrivateKeys
+		} else {
+			benchData.MinerKeys = minerKeys[:listLength]
+			benchData.MinerPrivateKeys = minerPrivateKeys[:listLength]
+		}
 		if len(sharders) < listLength {
 			benchData.Sharders = sharders
 		} else {
@@ -0,0 +1,313 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	bk "0chain.net/smartcontract/benchmark"
+	"0chain.net/smartcontract/benchmark/main/cmd/log"
+	"github.com/spf13/viper"
+)
+
+const (
+	reportFormatText = "text"
+	reportFormatJSON = "json"
+	reportFormatCSV  = "csv"
+
+	defaultRegressionThreshold = 0.2
+	defaultCostReferenceValue  = 100
+)
+
+// costSections maps the smart contract test suites onto the section of
+// the smart contract config that holds their cost table.
+var costSections = map[string]string{
+	bk.SourceNames[bk.Storage]:     bk.StorageSc,
+	bk.SourceNames[bk.Miner]:       bk.MinerSc,
+	bk.SourceNames[bk.Faucet]:      bk.FaucetSc,
+	bk.SourceNames[bk.Vesting]:     bk.VestingSc,
+	bk.SourceNames[bk.ZCNSCBridge]: bk.ZcnSc,
+}
+
+// reportEntry is the machine-readable result of a single benchmark test.
+type reportEntry struct {
+	Suite     string  `json:"suite"`
+	Name      string  `json:"name"`
+	Runs      int     `json:"runs"`
+	MsPerOp   float64 `json:"ms_per_op"`
+	NumEvents int     `json:"num_events,omitempty"`
+	Error     string  `json:"error,omitempty"`
+}
+
+type benchmarkReport struct {
+	Created time.Time     `json:"created"`
+	Results []reportEntry `json:"results"`
+}
+
+type regression struct {
+	Name      string
+	Baseline  float64
+	Current   float64
+	Threshold float64
+}
+
+func (r regression) String() string {
+	return fmt.Sprintf("%s: %fms -> %fms (+%.1f%%, allowed +%.1f%%)",
+		r.Name, r.Baseline, r.Current,
+		(r.Current/r.Baseline-1)*100, r.Threshold*100)
+}
+
+func buildReport(results []suiteResults) []reportEntry {
+	var entries []reportEntry
+	for _, sr := range results {
+		for _, br := range sr.results {
+			entry := reportEntry{
+				Suite:     sr.name,
+				Name:      br.test.Name(),
+				Runs:      br.result.N,
+				NumEvents: br.numEvents,
+			}
+			if br.result.N > 0 {
+				entry.MsPerOp = float64(br.result.T.Nanoseconds()) / float64(br.result.N) / float64(time.Millisecond)
+			}
+			if br.error != nil {
+				entry.Error = br.error.Error()
+			}
+			entries = append(entries, entry)
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Suite != entries[j].Suite {
+			return entries[i].Suite < entries[j].Suite
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries
+}
+
+func writeReport(w io.Writer, format string, entries []reportEntry) error {
+	switch format {
+	case reportFormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", " ")
+		return enc.Encode(benchmarkReport{
+			Created: time.Now().UTC(),
+			Results: entries,
+		})
+	case reportFormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write([]string{"suite", "name", "runs", "ms_per_op", "num_events", "error"}); err != nil {
+			return err
+		}
+		for _, e := range entries {
+			if err := cw.Write([]string{
+				e.Suite,
+				e.Name,
+				strconv.Itoa(e.Runs),
+				strconv.FormatFloat(e.MsPerOp, 'f', 6, 64),
+				strconv.Itoa(e.NumEvents),
+				e.Error,
+			}); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	default:
+		return fmt.Errorf("unknown report format %q", format)
+	}
+}
+
+func readReport(filename string) ([]reportEntry, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var report benchmarkReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("decoding baseline %s: %v", filename, err)
+	}
+	return report.Results, nil
+}
+
+// findRegressions compares the current results against a baseline. A test
+// regresses when its time per operation grows by more than its threshold,
+// given as a fraction of the baseline time. Thresholds are looked up by
+// test name, then by suite name, falling back to the default.
+func findRegressions(
+	baseline, current []reportEntry,
+	defaultThreshold float64,
+	thresholds map[string]float64,
+) []regression {
+	base := make(map[string]reportEntry, len(baseline))
+	for _, e := range baseline {
+		base[e.Name] = e
+	}
+
+	var regressions []regression
+	for _, e := range current {
+		b, ok := base[e.Name]
+		if !ok || b.Error != "" || e.Error != "" || b.MsPerOp <= 0 {
+			continue
+		}
+		threshold := defaultThreshold
+		if t, ok := thresholds[e.Name]; ok {
+			threshold = t
+		} else if t, ok := thresholds[e.Suite]; ok {
+			threshold = t
+		}
+		if e.MsPerOp > b.MsPerOp*(1+threshold) {
+			regressions = append(regressions, regression{
+				Name:      e.Name,
+				Baseline:  b.MsPerOp,
+				Current:   e.MsPerOp,
+				Threshold: threshold,
+			})
+		}
+	}
+	return regressions
+}
+
+// proposeCostTable derives a cost table for every measured smart contract
+// function, scaling each timing so that the reference test costs
+// referenceCost. The result is keyed by smart contract config section.
+func proposeCostTable(
+	entries []reportEntry,
+	referenceTest string,
+	referenceCost int,
+) (map[string]map[string]int, error) {
+	var refMs float64
+	for _, e := range entries {
+		if e.Name == referenceTest && e.Error == "" {
+			refMs = e.MsPerOp
+		}
+	}
+	if refMs <= 0 {
+		return nil, fmt.Errorf("reference test %q has no successful timing", referenceTest)
+	}
+
+	table := make(map[string]map[string]int)
+	for _, e := range entries {
+		section, ok := costSections[e.Suite]
+		if !ok || e.Error != "" {
+			continue
+		}
+		idx := strings.IndexByte(e.Name, '.')
+		if idx < 0 {
+			continue
+		}
+		cost := int(math.Round(float64(referenceCost) * e.MsPerOp / refMs))
+		if cost < 1 {
+			cost = 1
+		}
+		if table[section] == nil {
+			table[section] = make(map[string]int)
+		}
+		table[section][e.Name[idx+1:]] = cost
+	}
+	return table, nil
+}
+
+func writeCostTable(filename string, table map[string]map[string]int) error {
+	v := viper.New()
+	for section, costs := range table {
+		v.Set(bk.SmartContract+section+"cost", costs)
+	}
+	return v.WriteConfigAs(filename)
+}
+
+// flattenThresholds undoes viper's splitting of dotted test names into
+// nested maps, so thresholds can be set for both suites and single tests.
+func flattenThresholds(prefix string, in map[string]interface{}, out map[string]float64) error {
+	for key, value := range in {
+		name := key
+		if prefix != "" {
+			name = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			if err := flattenThresholds(name, nested, out); err != nil {
+				return err
+			}
+			continue
+		}
+		f, err := strconv.ParseFloat(fmt.Sprint(value), 64)
+		if err != nil {
+			return fmt.Errorf("invalid regression threshold for %s: %v", name, err)
+		}
+		out[name] = f
+	}
+	return nil
+}
+
+// reportResults writes the machine-readable report, checks for regressions
+// against the baseline and proposes a cost table, as set in the options.
+// It returns an error if any test regressed.
+func reportResults(results []suiteResults) error {
+	entries := buildReport(results)
+
+	if format := viper.GetString(bk.OptionReportFormat); format != "" && format != reportFormatText {
+		w := os.Stdout
+		if filename := viper.GetString(bk.OptionReportFile); filename != "" {
+			f, err := os.Create(filename)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			w = f
+		}
+		if err := writeReport(w, format, entries); err != nil {
+			return err
+		}
+	}
+
+	if filename := viper.GetString(bk.OptionCostTableFile); filename != "" {
+		refCost := viper.GetInt(bk.OptionCostReferenceValue)
+		if refCost <= 0 {
+			refCost = defaultCostReferenceValue
+		}
+		table, err := proposeCostTable(entries, viper.GetString(bk.OptionCostReferenceTest), refCost)
+		if err != nil {
+			return err
+		}
+		if err := writeCostTable(filename, table); err != nil {
+			return err
+		}
+		log.Println("proposed cost table written to", filename)
+	}
+
+	filename := viper.GetString(bk.OptionBaselineFile)
+	if filename == "" {
+		return nil
+	}
+	baseline, err := readReport(filename)
+	if err != nil {
+		return err
+	}
+	threshold := defaultRegressionThreshold
+	if viper.IsSet(bk.OptionRegressionThreshold) {
+		threshold = viper.GetFloat64(bk.OptionRegressionThreshold)
+	}
+	thresholds := make(map[string]float64)
+	if err := flattenThresholds("", viper.GetStringMap(bk.OptionRegressionThresholds), thresholds); err != nil {
+		return err
+	}
+
+	regressions := findRegressions(baseline, entries, threshold, thresholds)
+	if len(regressions) == 0 {
+		log.Println("no regressions against baseline", filename)
+		return nil
+	}
+	fmt.Fprintln(os.Stderr, "\nRegressions against baseline", filename)
+	for _, r := range regressions {
+		fmt.Fprintln(os.Stderr, r.String())
+	}
+	return errors.New(strconv.Itoa(len(regressions)) + " benchmark regressions found")
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	bk "0chain.net/smartcontract/benchmark"
+	"github.com/stretchr/testify/require"
+)
+
+func testEntries() []reportEntry {
+	return []reportEntry{
+		{Suite: "storage", Name: "storage.read_pool_lock", Runs: 100, MsPerOp: 0.5},
+		{Suite: "storage", Name: "storage.new_allocation_request", Runs: 10, MsPerOp: 5},
+		{Suite: "storage", Name: "storage.broken", Runs: 1, MsPerOp: 1, Error: "failed"},
+		{Suite: "miner", Name: "miner.add_miner", Runs: 50, MsPerOp: 0.1},
+		{Suite: "storage_rest", Name: "storage_rest.allocation", Runs: 50, MsPerOp: 2},
+	}
+}
+
+func TestFindRegressions(t *testing.T) {
+	baseline := testEntries()
+	current := testEntries()
+	current[0].MsPerOp = 0.55 // +10%
+	current[1].MsPerOp = 7    // +40%
+	current[3].MsPerOp = 0.2  // +100%
+
+	tests := []struct {
+		name       string
+		threshold  float64
+		thresholds map[string]float64
+		want       []string
+	}{
+		{
+			name:      "default threshold",
+			threshold: 0.2,
+			want:      []string{"storage.new_allocation_request", "miner.add_miner"},
+		},
+		{
+			name:       "per suite threshold",
+			threshold:  0.2,
+			thresholds: map[string]float64{"miner": 1.5},
+			want:       []string{"storage.new_allocation_request"},
+		},
+		{
+			name:      "per test threshold overrides suite",
+			threshold: 0.05,
+			thresholds: map[string]float64{
+				"storage":                        1,
+				"storage.new_allocation_request": 0.3,
+			},
+			want: []string{"storage.new_allocation_request", "miner.add_miner"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, r := range findRegressions(baseline, current, tt.threshold, tt.thresholds) {
+				got = append(got, r.Name)
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestProposeCostTable(t *testing.T) {
+	table, err := proposeCostTable(testEntries(), "storage.read_pool_lock", 100)
+	require.NoError(t, err)
+	require.Equal(t, map[string]map[string]int{
+		bk.StorageSc: {
+			"read_pool_lock":         100,
+			"new_allocation_request": 1000,
+		},
+		bk.MinerSc: {
+			"add_miner": 20,
+		},
+	}, table)
+
+	_, err = proposeCostTable(testEntries(), "storage.broken", 100)
+	require.Error(t, err)
+}
+
+func TestFlattenThresholds(t *testing.T) {
+	out := make(map[string]float64)
+	err := flattenThresholds("", map[string]interface{}{
+		"miner": 0.5,
+		"storage": map[string]interface{}{
+			"new_allocation_request": "0.3",
+		},
+	}, out)
+	require.NoError(t, err)
+	require.Equal(t, map[string]float64{
+		"miner":                          0.5,
+		"storage.new_allocation_request": 0.3,
+	}, out)
+}
+
+func TestWriteReportCSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeReport(&buf, reportFormatCSV, testEntries()))
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, len(testEntries())+1)
+	require.Equal(t, []string{"storage", "storage.broken", "1", "1.000000", "0", "failed"}, records[3])
+
+	require.Error(t, writeReport(&buf, "xml", testEntries()))
+}
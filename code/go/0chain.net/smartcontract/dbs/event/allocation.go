@@ -95,6 +95,21 @@ func (edb *EventDb) GetClientsAllocation(clientID string, limit common.Paginatio
 	return allocs, nil
 }
 
+// GetLiveAllocationIDs returns the ids of the allocations neither finalized
+// nor cancelled, following the given allocation id, by id.
+func (edb *EventDb) GetLiveAllocationIDs(after string, limit int) ([]string, error) {
+	var ids []string
+	err := edb.Store.Get().Model(&Allocation{}).
+		Where("allocation_id > ? AND finalized = ? AND cancelled = ?", after, false, false).
+		Order("allocation_id").
+		Limit(limit).
+		Pluck("allocation_id", &ids).Error
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving live allocations: %v", err)
+	}
+	return ids, nil
+}
+
 func (edb *EventDb) GetExpiredAllocation(blobberID string) ([]string, error) {
 	db := edb.Store.Get()
 
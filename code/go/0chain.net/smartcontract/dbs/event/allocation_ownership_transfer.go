@@ -0,0 +1,54 @@
+package event
+
+import (
+	common2 "0chain.net/smartcontract/common"
+	"0chain.net/smartcontract/dbs/model"
+	"github.com/0chain/common/core/currency"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// OwnershipTransferProposed is recorded when the owner proposes a new
+	// owner of the allocation.
+	OwnershipTransferProposed = "proposed"
+	// OwnershipTransferAccepted is recorded when the proposed owner takes
+	// the allocation over.
+	OwnershipTransferAccepted = "accepted"
+	// OwnershipTransferCancelled is recorded when either side drops the
+	// pending transfer.
+	OwnershipTransferCancelled = "cancelled"
+)
+
+// AllocationOwnershipTransfer is a step of handing an allocation over to
+// another owner.
+type AllocationOwnershipTransfer struct {
+	model.UpdatableModel
+	AllocationID string        `json:"allocation_id" gorm:"index"`
+	FromOwnerID  string        `json:"from_owner_id" gorm:"index"`
+	ToOwnerID    string        `json:"to_owner_id" gorm:"index"`
+	Status       string        `json:"status"` // proposed, accepted or cancelled
+	ExpiresAt    int64         `json:"expires_at"`
+	WritePool    currency.Coin `json:"write_pool"` // write pool of the allocation at the step
+	Round        int64         `json:"round"`
+}
+
+func (edb *EventDb) addAllocationOwnershipTransfer(transfer AllocationOwnershipTransfer) error {
+	return edb.Store.Get().Create(&transfer).Error
+}
+
+// GetAllocationOwnershipTransfers returns the ownership transfers of the
+// allocation.
+func (edb *EventDb) GetAllocationOwnershipTransfers(allocationID string, limit common2.Pagination) ([]AllocationOwnershipTransfer, error) {
+	var transfers []AllocationOwnershipTransfer
+	err := edb.Store.Get().
+		Model(&AllocationOwnershipTransfer{}).
+		Where("allocation_id = ?", allocationID).
+		Offset(limit.Offset).
+		Limit(limit.Limit).
+		Order(clause.OrderByColumn{
+			Column: clause.Column{Name: "round"},
+			Desc:   limit.IsDescending,
+		}).
+		Find(&transfers).Error
+	return transfers, err
+}
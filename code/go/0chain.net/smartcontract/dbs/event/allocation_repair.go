@@ -0,0 +1,48 @@
+package event
+
+import (
+	common2 "0chain.net/smartcontract/common"
+	"0chain.net/smartcontract/dbs/model"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// AllocationRepairDegraded is recorded when a degraded blobber of an
+	// auto repaired allocation is noticed.
+	AllocationRepairDegraded = "degraded"
+	// AllocationRepairRepaired is recorded when the degraded blobber has
+	// been replaced and its shards have to be re-uploaded.
+	AllocationRepairRepaired = "repaired"
+)
+
+// AllocationRepair is a degraded blobber of an allocation and its
+// replacement, if any.
+type AllocationRepair struct {
+	model.UpdatableModel
+	AllocationID     string `json:"allocation_id" gorm:"index"`
+	RemovedBlobberID string `json:"removed_blobber_id"`
+	AddedBlobberID   string `json:"added_blobber_id"` // empty while degraded
+	Reason           string `json:"reason"`           // killed, shutdown or low_reputation
+	Status           string `json:"status"`           // degraded or repaired
+	Round            int64  `json:"round"`
+}
+
+func (edb *EventDb) addAllocationRepair(repair AllocationRepair) error {
+	return edb.Store.Get().Create(&repair).Error
+}
+
+// GetAllocationRepairs returns the repair history of the allocation.
+func (edb *EventDb) GetAllocationRepairs(allocationID string, limit common2.Pagination) ([]AllocationRepair, error) {
+	var repairs []AllocationRepair
+	err := edb.Store.Get().
+		Model(&AllocationRepair{}).
+		Where("allocation_id = ?", allocationID).
+		Offset(limit.Offset).
+		Limit(limit.Limit).
+		Order(clause.OrderByColumn{
+			Column: clause.Column{Name: "round"},
+			Desc:   limit.IsDescending,
+		}).
+		Find(&repairs).Error
+	return repairs, err
+}
@@ -34,6 +34,10 @@ type Blobber struct {
 	NotAvailable bool  `json:"not_available"`
 	IsRestricted bool  `json:"is_restricted"`
 
+	// placement labels
+	Region   string `json:"region" gorm:"index"`
+	Operator string `json:"operator" gorm:"index"`
+
 	OffersTotal currency.Coin `json:"offers_total"`
 	// todo update
 	TotalServiceCharge currency.Coin `json:"total_service_charge"`
@@ -42,6 +46,7 @@ type Blobber struct {
 	ChallengesCompleted uint64        `json:"challenges_completed"`
 	OpenChallenges      uint64        `json:"open_challenges"`
 	RankMetric          float64       `json:"rank_metric"` // currently ChallengesPassed / ChallengesCompleted
+	Reputation          float64       `json:"reputation" gorm:"index;default:1"`
 	TotalBlockRewards   currency.Coin `json:"total_block_rewards"`
 	TotalStorageIncome  currency.Coin `json:"total_storage_income"`
 	TotalReadIncome     currency.Coin `json:"total_read_income"`
@@ -214,6 +219,23 @@ func (edb *EventDb) updateBlobbersAllocatedSavedAndHealth(blobbers []Blobber) er
 
 }
 
+func (edb *EventDb) updateBlobbersReputation(blobbers []Blobber) error {
+	var ids []string
+	var reputation []float64
+	for _, b := range blobbers {
+		ids = append(ids, b.ID)
+		reputation = append(reputation, b.Reputation)
+	}
+
+	return CreateBuilder("blobbers", "id", ids).
+		AddUpdate("reputation", reputation).
+		Exec(edb).Error
+}
+
+func mergeUpdateBlobberReputationEvents() *eventsMergerImpl[Blobber] {
+	return newEventsMerger[Blobber](TagUpdateBlobberReputation, withUniqueEventOverwrite())
+}
+
 func mergeUpdateBlobbersEvents() *eventsMergerImpl[Blobber] {
 	return newEventsMerger[Blobber](TagUpdateBlobberAllocatedSavedHealth, withUniqueEventOverwrite())
 }
@@ -232,6 +254,8 @@ type AllocationQuery struct {
 	AllocationSizeInGB float64
 	NumberOfDataShards int
 	IsRestricted       int
+	Regions            []string
+	MinReputation      float64
 }
 
 func (edb *EventDb) GetBlobberIdsFromUrls(urls []string, data common2.Pagination) ([]string, error) {
@@ -259,6 +283,12 @@ func (edb *EventDb) GetBlobbersFromParams(allocation AllocationQuery, limit comm
 	} else if allocation.IsRestricted == 2 {
 		dbStore = dbStore.Where("is_restricted = false")
 	}
+	if len(allocation.Regions) > 0 {
+		dbStore = dbStore.Where("region IN ?", allocation.Regions)
+	}
+	if allocation.MinReputation > 0 {
+		dbStore = dbStore.Where("reputation >= ?", allocation.MinReputation)
+	}
 	dbStore = dbStore.Where("is_killed = false")
 	dbStore = dbStore.Where("is_shutdown = false")
 	dbStore = dbStore.Where("not_available = false")
@@ -286,6 +316,15 @@ func (edb *EventDb) GetBlobbersFromParams(allocation AllocationQuery, limit comm
 	return blobebrIds, nil
 }
 
+// GetBlobberLabels returns the region and operator of the given blobbers.
+func (edb *EventDb) GetBlobberLabels(ids []string) ([]Blobber, error) {
+	var blobbers []Blobber
+	return blobbers, edb.Store.Get().Model(&Blobber{}).
+		Select("id", "region", "operator").
+		Where("id IN ?", ids).
+		Find(&blobbers).Error
+}
+
 type Result struct {
 	Id          string
 	Unallocated int64
@@ -309,6 +348,8 @@ func (edb *EventDb) updateBlobber(blobbers []Blobber) error {
 		"saved_data",
 		"not_available",
 		"is_restricted",
+		"region",
+		"operator",
 		"offers_total",
 		"delegate_wallet",
 		"num_delegates",
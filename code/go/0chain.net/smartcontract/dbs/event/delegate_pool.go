@@ -30,6 +30,8 @@ type DelegatePool struct {
 	RoundCreated         int64             `json:"round_created"`
 	RoundPoolLastUpdated int64             `json:"round_pool_last_updated"`
 	StakedAt             common.Timestamp  `json:"staked_at"`
+	AutoCompound         bool              `json:"auto_compound"`
+	TotalCompounded      currency.Coin     `json:"total_compounded"` // total reward added to the balance
 }
 
 func (edb *EventDb) GetDelegatePools(id string) ([]DelegatePool, error) {
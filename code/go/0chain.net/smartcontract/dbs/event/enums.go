@@ -113,6 +113,11 @@ const (
 	TagShutdownProvider
 	TagInsertReadpool
 	TagUpdateReadpool
+	TagCompoundReward
+	TagUpdateBlobberReputation
+	TagAllocationRepair
+	TagAllocationOwnershipTransfer
+	TagProviderJail
 	NumberOfTags
 )
 
@@ -203,6 +208,11 @@ func initTagString() {
 	TagString[TagShutdownProvider] = "TagShutdownProvider"
 	TagString[TagInsertReadpool] = "TagInsertReadpool"
 	TagString[TagUpdateReadpool] = "TagUpdateReadpool"
+	TagString[TagCompoundReward] = "TagCompoundReward"
+	TagString[TagUpdateBlobberReputation] = "TagUpdateBlobberReputation"
+	TagString[TagAllocationRepair] = "TagAllocationRepair"
+	TagString[TagAllocationOwnershipTransfer] = "TagAllocationOwnershipTransfer"
+	TagString[TagProviderJail] = "TagProviderJail"
 	TagString[NumberOfTags] = "invalid"
 }
 
@@ -249,6 +249,10 @@ func (edb *EventDb) AutoMigrate() error {
 		&DelegatePool{},
 		&Allocation{},
 		&RewardMint{},
+		&RewardCompound{},
+		&AllocationRepair{},
+		&AllocationOwnershipTransfer{},
+		&ProviderJail{},
 		&Authorizer{},
 		&Challenge{},
 		&AllocationBlobberTerm{},
@@ -222,6 +222,7 @@ func mergeEvents(round int64, block string, events []Event) ([]Event, error) {
 			mergeUpdateBlobbersEvents(),
 			mergeUpdateBlobberTotalStakesEvents(),
 			mergeUpdateBlobberTotalOffersEvents(),
+			mergeUpdateBlobberReputationEvents(),
 			mergeStakePoolRewardsEvents(),
 			mergeStakePoolPenaltyEvents(),
 			mergeAddDelegatePoolsEvents(),
@@ -701,6 +702,12 @@ func (edb *EventDb) addStat(event Event) (err error) {
 			return ErrInvalidEventData
 		}
 		return edb.updateBlobbersAllocatedSavedAndHealth(*blobbers)
+	case TagUpdateBlobberReputation:
+		blobbers, ok := fromEvent[[]Blobber](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		return edb.updateBlobbersReputation(*blobbers)
 	case TagUpdateBlobberTotalStake:
 		bs, ok := fromEvent[[]Blobber](event.Data)
 		if !ok {
@@ -923,6 +930,30 @@ func (edb *EventDb) addStat(event Event) (err error) {
 			return ErrInvalidEventData
 		}
 		return edb.addRewardMint(*reward)
+	case TagCompoundReward:
+		reward, ok := fromEvent[RewardCompound](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		return edb.addRewardCompound(*reward)
+	case TagAllocationRepair:
+		repair, ok := fromEvent[AllocationRepair](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		return edb.addAllocationRepair(*repair)
+	case TagAllocationOwnershipTransfer:
+		transfer, ok := fromEvent[AllocationOwnershipTransfer](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		return edb.addAllocationOwnershipTransfer(*transfer)
+	case TagProviderJail:
+		jail, ok := fromEvent[ProviderJail](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		return edb.addProviderJail(*jail)
 	case TagAddChallenge:
 		challenges, ok := fromEvent[[]Challenge](event.Data)
 		if !ok {
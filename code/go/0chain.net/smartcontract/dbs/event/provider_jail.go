@@ -0,0 +1,58 @@
+package event
+
+import (
+	common2 "0chain.net/smartcontract/common"
+	"0chain.net/smartcontract/dbs/model"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// ProviderJailed is recorded when a node is jailed.
+	ProviderJailed = "jailed"
+	// ProviderUnjailed is recorded when a jailed node is released.
+	ProviderUnjailed = "unjailed"
+)
+
+// reasons a node is jailed for
+const (
+	JailReasonMissedProposals    = "missed_proposals"
+	JailReasonMissedTickets      = "missed_tickets"
+	JailReasonMissedHealthChecks = "missed_health_checks"
+	JailReasonEquivocation       = "equivocation"
+)
+
+// ProviderJail is a miner or a sharder being jailed or unjailed.
+type ProviderJail struct {
+	model.UpdatableModel
+	ProviderID         string          `json:"provider_id" gorm:"index"`
+	ProviderType       spenum.Provider `json:"provider_type"`
+	Status             string          `json:"status"` // jailed or unjailed
+	Reason             string          `json:"reason,omitempty"`
+	MissedProposals    int64           `json:"missed_proposals"`
+	MissedTickets      int64           `json:"missed_tickets"`
+	MissedHealthChecks int64           `json:"missed_health_checks"`
+	Penalty            currency.Coin   `json:"penalty"` // stake slashed
+	Round              int64           `json:"round"`
+}
+
+func (edb *EventDb) addProviderJail(jail ProviderJail) error {
+	return edb.Store.Get().Create(&jail).Error
+}
+
+// GetProviderJails returns the jailing history of the provider.
+func (edb *EventDb) GetProviderJails(providerID string, limit common2.Pagination) ([]ProviderJail, error) {
+	var jails []ProviderJail
+	err := edb.Store.Get().
+		Model(&ProviderJail{}).
+		Where("provider_id = ?", providerID).
+		Offset(limit.Offset).
+		Limit(limit.Limit).
+		Order(clause.OrderByColumn{
+			Column: clause.Column{Name: "round"},
+			Desc:   limit.IsDescending,
+		}).
+		Find(&jails).Error
+	return jails, err
+}
@@ -0,0 +1,32 @@
+package event
+
+import (
+	"0chain.net/smartcontract/dbs/model"
+	"gorm.io/gorm"
+)
+
+// RewardCompound is a delegate pool reward added to the pool balance
+// instead of being collected.
+type RewardCompound struct {
+	model.UpdatableModel
+	Amount       int64  `json:"amount"`
+	BlockNumber  int64  `json:"block_number"`
+	ClientID     string `json:"client_id"`     // wallet ID
+	PoolID       string `json:"pool_id"`       // stake pool ID
+	ProviderType string `json:"provider_type"` // blobber, validator, miner, sharder or authorizer
+	ProviderID   string `json:"provider_id"`
+}
+
+func (edb *EventDb) addRewardCompound(reward RewardCompound) error {
+	if err := edb.Store.Get().Create(&reward).Error; err != nil {
+		return err
+	}
+
+	return edb.Store.Get().Model(&DelegatePool{}).
+		Where("provider_id = ? AND pool_id = ?", reward.ProviderID, reward.PoolID).
+		Updates(map[string]interface{}{
+			"balance":          gorm.Expr("balance + ?", reward.Amount),
+			"reward":           gorm.Expr("reward - ?", reward.Amount),
+			"total_compounded": gorm.Expr("total_compounded + ?", reward.Amount),
+		}).Error
+}
@@ -2,6 +2,7 @@ package event
 
 import (
 	"0chain.net/smartcontract/dbs/model"
+	"0chain.net/smartcontract/stakepool/spenum"
 	"github.com/0chain/common/core/currency"
 )
 
@@ -21,3 +22,21 @@ func (edb *EventDb) collectRewards(providerId string) error {
 			"rewards": currency.Coin(0),
 		}).Error
 }
+
+// GetRewardsTotal returns the sum of the rewards of the given types paid to
+// providers and to delegates up to and including the given round.
+func (edb *EventDb) GetRewardsTotal(round int64, rewardTypes ...spenum.Reward) (currency.Coin, error) {
+	var total currency.Coin
+	for _, m := range []interface{}{&RewardProvider{}, &RewardDelegate{}} {
+		var sum int64
+		err := edb.Get().Model(m).
+			Select("COALESCE(SUM(amount), 0)").
+			Where("block_number <= ? AND reward_type IN ?", round, rewardTypes).
+			Scan(&sum).Error
+		if err != nil {
+			return 0, err
+		}
+		total += currency.Coin(sum)
+	}
+	return total, nil
+}
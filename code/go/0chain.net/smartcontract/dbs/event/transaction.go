@@ -12,6 +12,7 @@ import (
 	"github.com/0chain/common/core/currency"
 	"github.com/0chain/common/core/logging"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
@@ -115,6 +116,32 @@ func (edb *EventDb) GetTransactionByToClientId(toClientID string, limit common.P
 	return tr, res.Error
 }
 
+// FindTransactionsToClient calls fn with batches of the transactions with the
+// given status sent to toClientID up to and including the given round.
+// Used Index: idx_tto_client_id
+func (edb *EventDb) FindTransactionsToClient(toClientID string, round int64, status, batchSize int, fn func([]Transaction) error) error {
+	var batch []Transaction
+	return edb.Store.
+		Get().
+		Model(&Transaction{}).
+		Where("to_client_id = ? AND round <= ? AND status = ?", toClientID, round, status).
+		FindInBatches(&batch, batchSize, func(_ *gorm.DB, _ int) error {
+			return fn(batch)
+		}).Error
+}
+
+// GetTransactionFeesTotal returns the sum of the fees of the transactions up
+// to and including the given round.
+func (edb *EventDb) GetTransactionFeesTotal(round int64) (currency.Coin, error) {
+	var total int64
+	err := edb.Store.Get().
+		Model(&Transaction{}).
+		Select("COALESCE(SUM(fee), 0)").
+		Where("round <= ?", round).
+		Scan(&total).Error
+	return currency.Coin(total), err
+}
+
 // GetTransactionByClientIDAndToClientID searches for transaction by clientID and toClientID
 // Used Index: idx_tclient_id
 func (edb *EventDb) GetTransactionByClientIDAndToClientID(clientID, toClientID string, limit common.Pagination) ([]Transaction, error) {
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE delegate_pools ADD COLUMN IF NOT EXISTS auto_compound boolean DEFAULT false;
+ALTER TABLE delegate_pools ADD COLUMN IF NOT EXISTS total_compounded bigint DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS reward_compounds (
+    id bigserial PRIMARY KEY,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    amount bigint,
+    block_number bigint,
+    client_id text,
+    pool_id text,
+    provider_type text,
+    provider_id text
+);
+CREATE INDEX IF NOT EXISTS idx_reward_compounds_client_id ON reward_compounds USING btree (client_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS reward_compounds;
+ALTER TABLE delegate_pools DROP COLUMN IF EXISTS total_compounded;
+ALTER TABLE delegate_pools DROP COLUMN IF EXISTS auto_compound;
+-- +goose StatementEnd
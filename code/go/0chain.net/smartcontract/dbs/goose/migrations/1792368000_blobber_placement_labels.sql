@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE blobbers ADD COLUMN IF NOT EXISTS region text DEFAULT '';
+ALTER TABLE blobbers ADD COLUMN IF NOT EXISTS operator text DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_blobbers_region ON blobbers USING btree (region);
+CREATE INDEX IF NOT EXISTS idx_blobbers_operator ON blobbers USING btree (operator);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_blobbers_operator;
+DROP INDEX IF EXISTS idx_blobbers_region;
+ALTER TABLE blobbers DROP COLUMN IF EXISTS operator;
+ALTER TABLE blobbers DROP COLUMN IF EXISTS region;
+-- +goose StatementEnd
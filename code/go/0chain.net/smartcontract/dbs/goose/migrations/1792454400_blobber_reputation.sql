@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE blobbers ADD COLUMN IF NOT EXISTS reputation numeric DEFAULT 1;
+CREATE INDEX IF NOT EXISTS idx_blobbers_reputation ON blobbers USING btree (reputation);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_blobbers_reputation;
+ALTER TABLE blobbers DROP COLUMN IF EXISTS reputation;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS allocation_repairs (
+    id bigserial PRIMARY KEY,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    allocation_id text,
+    removed_blobber_id text,
+    added_blobber_id text,
+    reason text,
+    status text,
+    round bigint
+);
+CREATE INDEX IF NOT EXISTS idx_allocation_repairs_allocation_id ON allocation_repairs USING btree (allocation_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS allocation_repairs;
+-- +goose StatementEnd
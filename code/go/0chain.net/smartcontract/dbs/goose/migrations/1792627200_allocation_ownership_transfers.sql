@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS allocation_ownership_transfers (
+    id bigserial PRIMARY KEY,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    allocation_id text,
+    from_owner_id text,
+    to_owner_id text,
+    status text,
+    expires_at bigint,
+    write_pool bigint,
+    round bigint
+);
+CREATE INDEX IF NOT EXISTS idx_allocation_ownership_transfers_allocation_id ON allocation_ownership_transfers USING btree (allocation_id);
+CREATE INDEX IF NOT EXISTS idx_allocation_ownership_transfers_from_owner_id ON allocation_ownership_transfers USING btree (from_owner_id);
+CREATE INDEX IF NOT EXISTS idx_allocation_ownership_transfers_to_owner_id ON allocation_ownership_transfers USING btree (to_owner_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS allocation_ownership_transfers;
+-- +goose StatementEnd
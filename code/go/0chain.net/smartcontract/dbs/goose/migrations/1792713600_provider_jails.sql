@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS provider_jails (
+    id bigserial PRIMARY KEY,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    provider_id text,
+    provider_type bigint,
+    status text,
+    reason text,
+    missed_proposals bigint,
+    missed_tickets bigint,
+    missed_health_checks bigint,
+    penalty bigint,
+    round bigint
+);
+CREATE INDEX IF NOT EXISTS idx_provider_jails_provider_id ON provider_jails USING btree (provider_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS provider_jails;
+-- +goose StatementEnd
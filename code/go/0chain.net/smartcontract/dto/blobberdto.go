@@ -23,6 +23,8 @@ type StorageDtoNode struct {
 	RewardRound             *RewardRound `json:"reward_round,omitempty"`
 	NotAvailable            *bool        `json:"not_available,omitempty"`
 	IsRestricted            *bool        `json:"is_restricted,omitempty"`
+	Region                  *string      `json:"region,omitempty"`
+	Operator                *string      `json:"operator,omitempty"`
 }
 
 type RewardRound struct {
@@ -0,0 +1,7 @@
+package faucetsc
+
+import "0chain.net/smartcontract/supply"
+
+func init() {
+	supply.RegisterContract(supply.Contract{Name: "faucet", Address: ADDRESS})
+}
@@ -0,0 +1,165 @@
+package graphql
+
+import "gorm.io/gorm"
+
+type blockFilter struct {
+	MinerId   *string
+	FromRound *Int64
+	ToRound   *Int64
+}
+
+func (f *blockFilter) apply(db *gorm.DB) *gorm.DB {
+	if f == nil {
+		return db
+	}
+	db = whereEq(db, "miner_id", f.MinerId)
+	return whereRange(db, "round", f.FromRound, f.ToRound)
+}
+
+type transactionFilter struct {
+	ClientId   *string
+	ToClientId *string
+	BlockHash  *string
+	FromRound  *Int64
+	ToRound    *Int64
+	Status     *int32
+}
+
+func (f *transactionFilter) apply(db *gorm.DB) *gorm.DB {
+	if f == nil {
+		return db
+	}
+	db = whereEq(db, "client_id", f.ClientId)
+	db = whereEq(db, "to_client_id", f.ToClientId)
+	db = whereEq(db, "block_hash", f.BlockHash)
+	db = whereEq(db, "status", f.Status)
+	return whereRange(db, "round", f.FromRound, f.ToRound)
+}
+
+type allocationFilter struct {
+	Owner         *string
+	Finalized     *bool
+	Cancelled     *bool
+	ExpiredBefore *Int64
+	ExpiredAfter  *Int64
+}
+
+func (f *allocationFilter) apply(db *gorm.DB) *gorm.DB {
+	if f == nil {
+		return db
+	}
+	db = whereEq(db, "owner", f.Owner)
+	db = whereEq(db, "finalized", f.Finalized)
+	db = whereEq(db, "cancelled", f.Cancelled)
+	return whereRange(db, "expiration", f.ExpiredAfter, f.ExpiredBefore)
+}
+
+type blobberFilter struct {
+	IsKilled      *bool
+	IsShutdown    *bool
+	IsRestricted  *bool
+	NotAvailable  *bool
+	Region        *string
+	Operator      *string
+	MinReputation *float64
+}
+
+func (f *blobberFilter) apply(db *gorm.DB) *gorm.DB {
+	if f == nil {
+		return db
+	}
+	db = whereEq(db, "is_killed", f.IsKilled)
+	db = whereEq(db, "is_shutdown", f.IsShutdown)
+	db = whereEq(db, "is_restricted", f.IsRestricted)
+	db = whereEq(db, "not_available", f.NotAvailable)
+	db = whereEq(db, "region", f.Region)
+	db = whereEq(db, "operator", f.Operator)
+	if f.MinReputation != nil {
+		db = db.Where("reputation >= ?", *f.MinReputation)
+	}
+	return db
+}
+
+type challengeFilter struct {
+	AllocationId *string
+	BlobberId    *string
+	Passed       *bool
+	Responded    *bool
+	FromRound    *Int64
+	ToRound      *Int64
+}
+
+func (f *challengeFilter) apply(db *gorm.DB) *gorm.DB {
+	if f == nil {
+		return db
+	}
+	db = whereEq(db, "allocation_id", f.AllocationId)
+	db = whereEq(db, "blobber_id", f.BlobberId)
+	db = whereEq(db, "passed", f.Passed)
+	if f.Responded != nil {
+		if *f.Responded {
+			db = db.Where("responded > 0")
+		} else {
+			db = db.Where("responded = 0")
+		}
+	}
+	return whereRange(db, "round_created_at", f.FromRound, f.ToRound)
+}
+
+type rewardFilter struct {
+	ProviderId   *string
+	AllocationId *string
+	PoolId       *string
+	RewardType   *int32
+	FromRound    *Int64
+	ToRound      *Int64
+}
+
+func (f *rewardFilter) apply(db *gorm.DB, withPool bool) *gorm.DB {
+	if f == nil {
+		return db
+	}
+	db = whereEq(db, "provider_id", f.ProviderId)
+	db = whereEq(db, "allocation_id", f.AllocationId)
+	if withPool {
+		db = whereEq(db, "pool_id", f.PoolId)
+	}
+	db = whereEq(db, "reward_type", f.RewardType)
+	return whereRange(db, "block_number", f.FromRound, f.ToRound)
+}
+
+type delegatePoolFilter struct {
+	ProviderId   *string
+	ProviderType *int32
+	DelegateId   *string
+	Status       *int32
+}
+
+func (f *delegatePoolFilter) apply(db *gorm.DB) *gorm.DB {
+	if f == nil {
+		return db
+	}
+	db = whereEq(db, "provider_id", f.ProviderId)
+	db = whereEq(db, "provider_type", f.ProviderType)
+	db = whereEq(db, "delegate_id", f.DelegateId)
+	return whereEq(db, "status", f.Status)
+}
+
+func whereEq[T any](db *gorm.DB, column string, v *T) *gorm.DB {
+	if v == nil {
+		return db
+	}
+	return db.Where(column+" = ?", *v)
+}
+
+// whereRange filters the column to the inclusive range, either bound is
+// optional.
+func whereRange(db *gorm.DB, column string, from, to *Int64) *gorm.DB {
+	if from != nil {
+		db = db.Where(column+" >= ?", int64(*from))
+	}
+	if to != nil {
+		db = db.Where(column+" <= ?", int64(*to))
+	}
+	return db
+}
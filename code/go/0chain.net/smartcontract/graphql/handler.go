@@ -0,0 +1,116 @@
+// Package graphql serves a read only GraphQL API over the event database
+// of a sharder.
+package graphql
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"0chain.net/core/common"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/rest"
+	graphqlgo "github.com/graph-gophers/graphql-go"
+)
+
+//go:embed schema.graphql
+var schema string
+
+const (
+	maxQueryLength = 8 << 10
+	maxDepth       = 8
+	maxParallelism = 10
+)
+
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Handler executes GraphQL queries sent as a JSON body in a POST request or
+// as the query, operationName and variables parameters of a GET request.
+type Handler struct {
+	schema *graphqlgo.Schema
+}
+
+func NewHandler(getEventDb func() *event.EventDb) *Handler {
+	return &Handler{
+		schema: graphqlgo.MustParseSchema(schema, NewResolver(getEventDb),
+			graphqlgo.MaxDepth(maxDepth),
+			graphqlgo.MaxParallelism(maxParallelism),
+		),
+	}
+}
+
+func SetupRestHandler(rh rest.RestHandlerI) {
+	rh.Register(GetEndpoints(rh))
+}
+
+func GetEndpoints(rh rest.RestHandlerI) []rest.Endpoint {
+	h := NewHandler(func() *event.EventDb {
+		sctx := rh.GetQueryStateContext()
+		if sctx == nil {
+			return nil
+		}
+		return sctx.GetEventDB()
+	})
+	return []rest.Endpoint{
+		rest.MakeEndpoint("/v1/graphql", common.UserRateLimit(h.ServeHTTP)),
+	}
+}
+
+// swagger:route POST /v1/graphql graphql GraphQL
+// Query the event database with GraphQL.
+// The schema is available through introspection. Every connection costs its page size
+// and every single object costs one, queries over the cost limit fail.
+//
+// responses:
+//
+//	200:
+//	400:
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	req, err := parseRequest(r)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	ctx := withCostBudget(r.Context(), maxQueryCost)
+	resp := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func parseRequest(r *http.Request) (*request, error) {
+	var req request
+	switch r.Method {
+	case http.MethodGet:
+		req.Query = r.URL.Query().Get("query")
+		req.OperationName = r.URL.Query().Get("operationName")
+		if v := r.URL.Query().Get("variables"); v != "" {
+			if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+				return nil, fmt.Errorf("invalid variables: %v", err)
+			}
+		}
+	case http.MethodPost:
+		body := http.MaxBytesReader(nil, r.Body, 2*maxQueryLength)
+		if err := json.NewDecoder(body).Decode(&req); err != nil {
+			return nil, fmt.Errorf("invalid request: %v", err)
+		}
+	default:
+		return nil, fmt.Errorf("method %s not allowed", r.Method)
+	}
+
+	if req.Query == "" {
+		return nil, fmt.Errorf("missing query")
+	}
+	if len(req.Query) > maxQueryLength {
+		return nil, fmt.Errorf("query longer than %d bytes", maxQueryLength)
+	}
+	return &req, nil
+}
@@ -0,0 +1,159 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"0chain.net/core/common"
+	"0chain.net/core/config"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/logging"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func init() {
+	logging.Logger = zap.NewNop()
+	common.SetupRootContext(context.Background())
+}
+
+func newTestHandler(t *testing.T) (*Handler, *event.EventDb) {
+	edb, err := event.NewInMemoryEventDb(config.DbAccess{}, config.DbSettings{
+		Debug:                 true,
+		PartitionChangePeriod: 1,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, edb.Drop())
+		edb.Close()
+	})
+	return NewHandler(func() *event.EventDb { return edb }), edb
+}
+
+type response struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func query(t *testing.T, h *Handler, q string) response {
+	body, err := json.Marshal(request{Query: q})
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/graphql", bytes.NewReader(body)))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp response
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return resp
+}
+
+func TestGraphQLRelations(t *testing.T) {
+	h, edb := newTestHandler(t)
+	db := edb.Get()
+
+	require.NoError(t, db.Create(&event.User{UserID: "owner", Balance: 10}).Error)
+	require.NoError(t, db.Create(&event.Blobber{Provider: event.Provider{ID: "b1"}, BaseURL: "http://b1"}).Error)
+	alloc := &event.Allocation{AllocationID: "a1", Owner: "owner", DataShards: 1}
+	require.NoError(t, db.Create(alloc).Error)
+	require.NoError(t, db.Create(&event.AllocationBlobberTerm{AllocationID: int64(alloc.ID), BlobberID: "b1"}).Error)
+	require.NoError(t, db.Create(&event.RewardProvider{
+		Amount: 5, BlockNumber: 7, ProviderId: "b1", RewardType: spenum.ChallengePassReward, AllocationID: "a1",
+	}).Error)
+	require.NoError(t, db.Create(&event.RewardProvider{
+		Amount: 3, BlockNumber: 8, ProviderId: "b1", RewardType: spenum.BlockRewardBlobber,
+	}).Error)
+
+	resp := query(t, h, `{
+		allocation(id: "a1") {
+			owner { id balance }
+			blobbers { id url }
+			providerRewards { nodes { amount round rewardType } }
+		}
+		blobber(id: "b1") {
+			rewards(filter: {rewardType: 8}) { nodes { allocation { id } } }
+		}
+	}`)
+	require.Empty(t, resp.Errors)
+	require.JSONEq(t, `{
+		"allocation": {
+			"owner": {"id": "owner", "balance": 10},
+			"blobbers": [{"id": "b1", "url": "http://b1"}],
+			"providerRewards": {"nodes": [{"amount": 5, "round": 7, "rewardType": "challenge_pass_reward"}]}
+		},
+		"blobber": {"rewards": {"nodes": [{"allocation": {"id": "a1"}}]}}
+	}`, string(resp.Data))
+}
+
+func TestGraphQLPagination(t *testing.T) {
+	h, edb := newTestHandler(t)
+	for i := 1; i <= 5; i++ {
+		require.NoError(t, edb.Get().Create(&event.Block{Hash: fmt.Sprintf("h%d", i), Round: int64(i)}).Error)
+	}
+
+	type page struct {
+		Blocks struct {
+			Nodes []struct {
+				Round int64 `json:"round"`
+			} `json:"nodes"`
+			PageInfo struct {
+				HasNextPage bool   `json:"hasNextPage"`
+				EndCursor   string `json:"endCursor"`
+			} `json:"pageInfo"`
+		} `json:"blocks"`
+	}
+
+	var (
+		rounds []int64
+		after  string
+	)
+	for {
+		args := `first: 2, filter: {fromRound: 2}`
+		if after != "" {
+			args += fmt.Sprintf(`, after: %q`, after)
+		}
+		resp := query(t, h, `{ blocks(`+args+`) { nodes { round } pageInfo { hasNextPage endCursor } } }`)
+		require.Empty(t, resp.Errors)
+
+		var p page
+		require.NoError(t, json.Unmarshal(resp.Data, &p))
+		for _, n := range p.Blocks.Nodes {
+			rounds = append(rounds, n.Round)
+		}
+		if !p.Blocks.PageInfo.HasNextPage {
+			break
+		}
+		after = p.Blocks.PageInfo.EndCursor
+	}
+	require.Equal(t, []int64{2, 3, 4, 5}, rounds)
+
+	resp := query(t, h, `{ blocks(first: 1, order: DESC) { nodes { round } } }`)
+	require.Empty(t, resp.Errors)
+	require.JSONEq(t, `{"blocks": {"nodes": [{"round": 5}]}}`, string(resp.Data))
+}
+
+func TestGraphQLLimits(t *testing.T) {
+	h, edb := newTestHandler(t)
+	for i := 0; i < 30; i++ {
+		require.NoError(t, edb.Get().Create(&event.User{UserID: fmt.Sprintf("u%d", i)}).Error)
+	}
+
+	resp := query(t, h, `{ users(first: 30) { nodes { transactions(first: 100) { nodes { hash } } } } }`)
+	require.NotEmpty(t, resp.Errors)
+	require.Contains(t, resp.Errors[0].Message, ErrQueryCostExceeded.Error())
+
+	resp = query(t, h, `{ user(id: "u1") { allocations { nodes { owner { allocations { nodes { owner {
+		allocations { nodes { owner { id } } } } } } } } } } }`)
+	require.NotEmpty(t, resp.Errors)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/graphql", bytes.NewReader([]byte(`{}`))))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,148 @@
+package graphql
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// Int64 is the GraphQL scalar used for rounds, sizes and token amounts,
+// the builtin Int is only 32 bits.
+type Int64 int64
+
+func (Int64) ImplementsGraphQLType(name string) bool {
+	return name == "Int64"
+}
+
+func (i *Int64) UnmarshalGraphQL(input interface{}) error {
+	switch v := input.(type) {
+	case int32:
+		*i = Int64(v)
+	case int64:
+		*i = Int64(v)
+	case float64:
+		*i = Int64(v)
+	case string:
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return err
+		}
+		*i = Int64(n)
+	default:
+		return fmt.Errorf("wrong type for Int64: %T", v)
+	}
+	return nil
+}
+
+func (i Int64) MarshalJSON() ([]byte, error) {
+	return json.Marshal(int64(i))
+}
+
+type pageInfo struct {
+	hasNextPage bool
+	endCursor   *string
+}
+
+func (p pageInfo) HasNextPage() bool {
+	return p.hasNextPage
+}
+
+func (p pageInfo) EndCursor() *string {
+	return p.endCursor
+}
+
+// PageArgs are the arguments of every connection field. Pages are ordered
+// by the primary key of the table and the cursor is the opaque primary key
+// of the last node of the previous page.
+type PageArgs struct {
+	First int32
+	After *string
+	Order string
+}
+
+func (p PageArgs) limit() int {
+	if p.First <= 0 {
+		return defaultPageSize
+	}
+	if p.First > maxPageSize {
+		return maxPageSize
+	}
+	return int(p.First)
+}
+
+func (p PageArgs) desc() bool {
+	return p.Order == "DESC"
+}
+
+func encodeCursor(key string) *string {
+	c := base64.RawURLEncoding.EncodeToString([]byte(key))
+	return &c
+}
+
+func decodeCursor(cursor string) (string, error) {
+	key, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", errors.New("invalid cursor")
+	}
+	return string(key), nil
+}
+
+// findPage loads a page of rows of the query ordered by the key column.
+// keyOf returns the cursor key of a row.
+func findPage[T any](ctx context.Context, db *gorm.DB, column string, args PageArgs, keyOf func(*T) string) ([]T, pageInfo, error) {
+	limit := args.limit()
+	if err := charge(ctx, limit); err != nil {
+		return nil, pageInfo{}, err
+	}
+
+	op, order := ">", column+" ASC"
+	if args.desc() {
+		op, order = "<", column+" DESC"
+	}
+	if args.After != nil {
+		key, err := decodeCursor(*args.After)
+		if err != nil {
+			return nil, pageInfo{}, err
+		}
+		db = db.Where(column+" "+op+" ?", key)
+	}
+
+	var rows []T
+	if err := db.Order(order).Limit(limit + 1).Find(&rows).Error; err != nil {
+		return nil, pageInfo{}, err
+	}
+
+	var pi pageInfo
+	if len(rows) > limit {
+		rows = rows[:limit]
+		pi.hasNextPage = true
+	}
+	if len(rows) > 0 {
+		pi.endCursor = encodeCursor(keyOf(&rows[len(rows)-1]))
+	}
+	return rows, pi, nil
+}
+
+// findOne loads the first row of the query, nil if there is none.
+func findOne[T any](ctx context.Context, db *gorm.DB) (*T, error) {
+	if err := charge(ctx, 1); err != nil {
+		return nil, err
+	}
+	var rows []T
+	if err := db.Limit(1).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return &rows[0], nil
+}
+
+func idKey(id uint) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
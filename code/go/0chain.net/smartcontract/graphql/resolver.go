@@ -0,0 +1,234 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	"0chain.net/smartcontract/dbs/event"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+	// maxQueryCost bounds the rows a single query can load, every connection
+	// costs its page size and every single object costs one, nested
+	// connections are charged once per parent.
+	maxQueryCost = 2000
+)
+
+var ErrQueryCostExceeded = errors.New("query cost limit exceeded")
+
+type costKey struct{}
+
+// withCostBudget returns a context that limits the rows the resolvers can
+// load while executing a single query.
+func withCostBudget(ctx context.Context, budget int64) context.Context {
+	left := new(atomic.Int64)
+	left.Store(budget)
+	return context.WithValue(ctx, costKey{}, left)
+}
+
+func charge(ctx context.Context, cost int) error {
+	left, ok := ctx.Value(costKey{}).(*atomic.Int64)
+	if !ok {
+		return nil
+	}
+	if left.Add(-int64(cost)) < 0 {
+		return ErrQueryCostExceeded
+	}
+	return nil
+}
+
+// Resolver is the root resolver of the schema, all queries are read only
+// and go to the event database.
+type Resolver struct {
+	getEventDb func() *event.EventDb
+}
+
+func NewResolver(getEventDb func() *event.EventDb) *Resolver {
+	return &Resolver{getEventDb: getEventDb}
+}
+
+func (r *Resolver) db(ctx context.Context) (*gorm.DB, error) {
+	edb := r.getEventDb()
+	if edb == nil {
+		return nil, errors.New("event database not available")
+	}
+	return edb.Get().WithContext(ctx), nil
+}
+
+func (r *Resolver) model(ctx context.Context, m interface{}) (*gorm.DB, error) {
+	db, err := r.db(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return db.Model(m), nil
+}
+
+func (r *Resolver) Block(ctx context.Context, args struct {
+	Hash  *string
+	Round *Int64
+}) (*blockResolver, error) {
+	if args.Hash == nil && args.Round == nil {
+		return nil, errors.New("hash or round is required")
+	}
+	db, err := r.model(ctx, &event.Block{})
+	if err != nil {
+		return nil, err
+	}
+	db = whereEq(db, "hash", args.Hash)
+	if args.Round != nil {
+		db = db.Where("round = ?", int64(*args.Round))
+	}
+	return r.findBlock(ctx, db)
+}
+
+func (r *Resolver) Blocks(ctx context.Context, args struct {
+	PageArgs
+	Filter *blockFilter
+}) (*blockConnection, error) {
+	db, err := r.model(ctx, &event.Block{})
+	if err != nil {
+		return nil, err
+	}
+	return r.blocks(ctx, args.Filter.apply(db), args.PageArgs)
+}
+
+func (r *Resolver) Transaction(ctx context.Context, args struct{ Hash string }) (*transactionResolver, error) {
+	db, err := r.model(ctx, &event.Transaction{})
+	if err != nil {
+		return nil, err
+	}
+	return r.findTransaction(ctx, db.Where("hash = ?", args.Hash))
+}
+
+func (r *Resolver) Transactions(ctx context.Context, args struct {
+	PageArgs
+	Filter *transactionFilter
+}) (*transactionConnection, error) {
+	db, err := r.model(ctx, &event.Transaction{})
+	if err != nil {
+		return nil, err
+	}
+	return r.transactions(ctx, args.Filter.apply(db), args.PageArgs)
+}
+
+func (r *Resolver) Allocation(ctx context.Context, args struct{ Id string }) (*allocationResolver, error) {
+	db, err := r.model(ctx, &event.Allocation{})
+	if err != nil {
+		return nil, err
+	}
+	return r.findAllocation(ctx, db.Where("allocation_id = ?", args.Id))
+}
+
+func (r *Resolver) Allocations(ctx context.Context, args struct {
+	PageArgs
+	Filter *allocationFilter
+}) (*allocationConnection, error) {
+	db, err := r.model(ctx, &event.Allocation{})
+	if err != nil {
+		return nil, err
+	}
+	return r.allocations(ctx, args.Filter.apply(db), args.PageArgs)
+}
+
+func (r *Resolver) Blobber(ctx context.Context, args struct{ Id string }) (*blobberResolver, error) {
+	db, err := r.model(ctx, &event.Blobber{})
+	if err != nil {
+		return nil, err
+	}
+	return r.findBlobber(ctx, db.Where("id = ?", args.Id))
+}
+
+func (r *Resolver) Blobbers(ctx context.Context, args struct {
+	PageArgs
+	Filter *blobberFilter
+}) (*blobberConnection, error) {
+	db, err := r.model(ctx, &event.Blobber{})
+	if err != nil {
+		return nil, err
+	}
+	return r.blobbers(ctx, args.Filter.apply(db), args.PageArgs)
+}
+
+func (r *Resolver) Challenge(ctx context.Context, args struct{ Id string }) (*challengeResolver, error) {
+	db, err := r.model(ctx, &event.Challenge{})
+	if err != nil {
+		return nil, err
+	}
+	c, err := findOne[event.Challenge](ctx, db.Where("challenge_id = ?", args.Id))
+	if err != nil || c == nil {
+		return nil, err
+	}
+	return &challengeResolver{r: r, c: c}, nil
+}
+
+func (r *Resolver) Challenges(ctx context.Context, args struct {
+	PageArgs
+	Filter *challengeFilter
+}) (*challengeConnection, error) {
+	db, err := r.model(ctx, &event.Challenge{})
+	if err != nil {
+		return nil, err
+	}
+	return r.challenges(ctx, args.Filter.apply(db), args.PageArgs)
+}
+
+func (r *Resolver) ProviderRewards(ctx context.Context, args struct {
+	PageArgs
+	Filter *rewardFilter
+}) (*providerRewardConnection, error) {
+	db, err := r.model(ctx, &event.RewardProvider{})
+	if err != nil {
+		return nil, err
+	}
+	return r.providerRewards(ctx, args.Filter.apply(db, false), args.PageArgs)
+}
+
+func (r *Resolver) DelegateRewards(ctx context.Context, args struct {
+	PageArgs
+	Filter *rewardFilter
+}) (*delegateRewardConnection, error) {
+	db, err := r.model(ctx, &event.RewardDelegate{})
+	if err != nil {
+		return nil, err
+	}
+	return r.delegateRewards(ctx, args.Filter.apply(db, true), args.PageArgs)
+}
+
+func (r *Resolver) DelegatePools(ctx context.Context, args struct {
+	PageArgs
+	Filter *delegatePoolFilter
+}) (*delegatePoolConnection, error) {
+	db, err := r.model(ctx, &event.DelegatePool{})
+	if err != nil {
+		return nil, err
+	}
+	return r.delegatePools(ctx, args.Filter.apply(db), args.PageArgs)
+}
+
+func (r *Resolver) User(ctx context.Context, args struct{ Id string }) (*userResolver, error) {
+	db, err := r.model(ctx, &event.User{})
+	if err != nil {
+		return nil, err
+	}
+	return r.findUser(ctx, db.Where("user_id = ?", args.Id))
+}
+
+func (r *Resolver) Users(ctx context.Context, args struct{ PageArgs }) (*userConnection, error) {
+	db, err := r.model(ctx, &event.User{})
+	if err != nil {
+		return nil, err
+	}
+	rows, pi, err := findPage(ctx, db, "id", args.PageArgs, func(u *event.User) string { return idKey(u.ID) })
+	if err != nil {
+		return nil, err
+	}
+	conn := &userConnection{nodes: []*userResolver{}, pageInfo: pi}
+	for i := range rows {
+		conn.nodes = append(conn.nodes, &userResolver{r: r, u: &rows[i]})
+	}
+	return conn, nil
+}
@@ -0,0 +1,594 @@
+package graphql
+
+import (
+	"context"
+
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"gorm.io/gorm"
+)
+
+type blockResolver struct {
+	r *Resolver
+	b *event.Block
+}
+
+func (b *blockResolver) Hash() string           { return b.b.Hash }
+func (b *blockResolver) Round() Int64           { return Int64(b.b.Round) }
+func (b *blockResolver) Version() string        { return b.b.Version }
+func (b *blockResolver) CreationDate() Int64    { return Int64(b.b.CreationDate) }
+func (b *blockResolver) MinerId() string        { return b.b.MinerID }
+func (b *blockResolver) PrevHash() string       { return b.b.PrevHash }
+func (b *blockResolver) StateHash() string      { return b.b.StateHash }
+func (b *blockResolver) MerkleTreeRoot() string { return b.b.MerkleTreeRoot }
+func (b *blockResolver) MagicBlockHash() string { return b.b.MagicBlockHash }
+func (b *blockResolver) NumTxns() int32         { return int32(b.b.NumTxns) }
+
+func (b *blockResolver) Transactions(ctx context.Context, args struct{ PageArgs }) (*transactionConnection, error) {
+	db, err := b.r.model(ctx, &event.Transaction{})
+	if err != nil {
+		return nil, err
+	}
+	return b.r.transactions(ctx, db.Where("block_hash = ?", b.b.Hash), args.PageArgs)
+}
+
+type blockConnection struct {
+	nodes    []*blockResolver
+	pageInfo pageInfo
+}
+
+func (c *blockConnection) Nodes() []*blockResolver { return c.nodes }
+func (c *blockConnection) PageInfo() pageInfo      { return c.pageInfo }
+
+func (r *Resolver) findBlock(ctx context.Context, db *gorm.DB) (*blockResolver, error) {
+	b, err := findOne[event.Block](ctx, db)
+	if err != nil || b == nil {
+		return nil, err
+	}
+	return &blockResolver{r: r, b: b}, nil
+}
+
+func (r *Resolver) blocks(ctx context.Context, db *gorm.DB, args PageArgs) (*blockConnection, error) {
+	rows, pi, err := findPage(ctx, db, "id", args, func(b *event.Block) string { return idKey(b.ID) })
+	if err != nil {
+		return nil, err
+	}
+	conn := &blockConnection{nodes: []*blockResolver{}, pageInfo: pi}
+	for i := range rows {
+		conn.nodes = append(conn.nodes, &blockResolver{r: r, b: &rows[i]})
+	}
+	return conn, nil
+}
+
+type transactionResolver struct {
+	r *Resolver
+	t *event.Transaction
+}
+
+func (t *transactionResolver) Hash() string              { return t.t.Hash }
+func (t *transactionResolver) BlockHash() string         { return t.t.BlockHash }
+func (t *transactionResolver) Round() Int64              { return Int64(t.t.Round) }
+func (t *transactionResolver) ClientId() string          { return t.t.ClientId }
+func (t *transactionResolver) ToClientId() string        { return t.t.ToClientId }
+func (t *transactionResolver) TransactionData() string   { return t.t.TransactionData }
+func (t *transactionResolver) TransactionOutput() string { return t.t.TransactionOutput }
+func (t *transactionResolver) Value() Int64              { return Int64(t.t.Value) }
+func (t *transactionResolver) Fee() Int64                { return Int64(t.t.Fee) }
+func (t *transactionResolver) Nonce() Int64              { return Int64(t.t.Nonce) }
+func (t *transactionResolver) CreationDate() Int64       { return Int64(t.t.CreationDate) }
+func (t *transactionResolver) TransactionType() int32    { return int32(t.t.TransactionType) }
+func (t *transactionResolver) Status() int32             { return int32(t.t.Status) }
+
+func (t *transactionResolver) Block(ctx context.Context) (*blockResolver, error) {
+	db, err := t.r.model(ctx, &event.Block{})
+	if err != nil {
+		return nil, err
+	}
+	return t.r.findBlock(ctx, db.Where("hash = ?", t.t.BlockHash))
+}
+
+func (t *transactionResolver) Client(ctx context.Context) (*userResolver, error) {
+	db, err := t.r.model(ctx, &event.User{})
+	if err != nil {
+		return nil, err
+	}
+	return t.r.findUser(ctx, db.Where("user_id = ?", t.t.ClientId))
+}
+
+type transactionConnection struct {
+	nodes    []*transactionResolver
+	pageInfo pageInfo
+}
+
+func (c *transactionConnection) Nodes() []*transactionResolver { return c.nodes }
+func (c *transactionConnection) PageInfo() pageInfo            { return c.pageInfo }
+
+func (r *Resolver) findTransaction(ctx context.Context, db *gorm.DB) (*transactionResolver, error) {
+	t, err := findOne[event.Transaction](ctx, db)
+	if err != nil || t == nil {
+		return nil, err
+	}
+	return &transactionResolver{r: r, t: t}, nil
+}
+
+func (r *Resolver) transactions(ctx context.Context, db *gorm.DB, args PageArgs) (*transactionConnection, error) {
+	rows, pi, err := findPage(ctx, db, "id", args, func(t *event.Transaction) string { return idKey(t.ID) })
+	if err != nil {
+		return nil, err
+	}
+	conn := &transactionConnection{nodes: []*transactionResolver{}, pageInfo: pi}
+	for i := range rows {
+		conn.nodes = append(conn.nodes, &transactionResolver{r: r, t: &rows[i]})
+	}
+	return conn, nil
+}
+
+type allocationResolver struct {
+	r *Resolver
+	a *event.Allocation
+}
+
+func (a *allocationResolver) Id() string                  { return a.a.AllocationID }
+func (a *allocationResolver) TransactionId() string       { return a.a.TransactionID }
+func (a *allocationResolver) DataShards() int32           { return int32(a.a.DataShards) }
+func (a *allocationResolver) ParityShards() int32         { return int32(a.a.ParityShards) }
+func (a *allocationResolver) Size() Int64                 { return Int64(a.a.Size) }
+func (a *allocationResolver) UsedSize() Int64             { return Int64(a.a.UsedSize) }
+func (a *allocationResolver) Expiration() Int64           { return Int64(a.a.Expiration) }
+func (a *allocationResolver) StartTime() Int64            { return Int64(a.a.StartTime) }
+func (a *allocationResolver) Finalized() bool             { return a.a.Finalized }
+func (a *allocationResolver) Cancelled() bool             { return a.a.Cancelled }
+func (a *allocationResolver) WritePool() Int64            { return Int64(a.a.WritePool) }
+func (a *allocationResolver) MovedToChallenge() Int64     { return Int64(a.a.MovedToChallenge) }
+func (a *allocationResolver) MovedBack() Int64            { return Int64(a.a.MovedBack) }
+func (a *allocationResolver) MovedToValidators() Int64    { return Int64(a.a.MovedToValidators) }
+func (a *allocationResolver) TotalChallenges() Int64      { return Int64(a.a.TotalChallenges) }
+func (a *allocationResolver) OpenChallenges() Int64       { return Int64(a.a.OpenChallenges) }
+func (a *allocationResolver) SuccessfulChallenges() Int64 { return Int64(a.a.SuccessfulChallenges) }
+func (a *allocationResolver) FailedChallenges() Int64     { return Int64(a.a.FailedChallenges) }
+
+func (a *allocationResolver) Owner(ctx context.Context) (*userResolver, error) {
+	db, err := a.r.model(ctx, &event.User{})
+	if err != nil {
+		return nil, err
+	}
+	return a.r.findUser(ctx, db.Where("user_id = ?", a.a.Owner))
+}
+
+func (a *allocationResolver) Blobbers(ctx context.Context) ([]*blobberResolver, error) {
+	if err := charge(ctx, a.a.DataShards+a.a.ParityShards); err != nil {
+		return nil, err
+	}
+	db, err := a.r.model(ctx, &event.Blobber{})
+	if err != nil {
+		return nil, err
+	}
+	var rows []event.Blobber
+	err = db.Joins("JOIN allocation_blobber_terms ON allocation_blobber_terms.blobber_id = blobbers.id").
+		Where("allocation_blobber_terms.alloc_id = ?", a.a.ID).
+		Order("allocation_blobber_terms.alloc_blobber_idx").
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	blobbers := make([]*blobberResolver, 0, len(rows))
+	for i := range rows {
+		blobbers = append(blobbers, &blobberResolver{r: a.r, b: &rows[i]})
+	}
+	return blobbers, nil
+}
+
+func (a *allocationResolver) Challenges(ctx context.Context, args struct {
+	PageArgs
+	Filter *challengeFilter
+}) (*challengeConnection, error) {
+	db, err := a.r.model(ctx, &event.Challenge{})
+	if err != nil {
+		return nil, err
+	}
+	db = args.Filter.apply(db.Where("allocation_id = ?", a.a.AllocationID))
+	return a.r.challenges(ctx, db, args.PageArgs)
+}
+
+func (a *allocationResolver) ProviderRewards(ctx context.Context, args struct {
+	PageArgs
+	Filter *rewardFilter
+}) (*providerRewardConnection, error) {
+	db, err := a.r.model(ctx, &event.RewardProvider{})
+	if err != nil {
+		return nil, err
+	}
+	db = args.Filter.apply(db.Where("allocation_id = ?", a.a.AllocationID), false)
+	return a.r.providerRewards(ctx, db, args.PageArgs)
+}
+
+func (a *allocationResolver) DelegateRewards(ctx context.Context, args struct {
+	PageArgs
+	Filter *rewardFilter
+}) (*delegateRewardConnection, error) {
+	db, err := a.r.model(ctx, &event.RewardDelegate{})
+	if err != nil {
+		return nil, err
+	}
+	db = args.Filter.apply(db.Where("allocation_id = ?", a.a.AllocationID), true)
+	return a.r.delegateRewards(ctx, db, args.PageArgs)
+}
+
+type allocationConnection struct {
+	nodes    []*allocationResolver
+	pageInfo pageInfo
+}
+
+func (c *allocationConnection) Nodes() []*allocationResolver { return c.nodes }
+func (c *allocationConnection) PageInfo() pageInfo           { return c.pageInfo }
+
+func (r *Resolver) findAllocation(ctx context.Context, db *gorm.DB) (*allocationResolver, error) {
+	a, err := findOne[event.Allocation](ctx, db)
+	if err != nil || a == nil {
+		return nil, err
+	}
+	return &allocationResolver{r: r, a: a}, nil
+}
+
+func (r *Resolver) allocations(ctx context.Context, db *gorm.DB, args PageArgs) (*allocationConnection, error) {
+	rows, pi, err := findPage(ctx, db, "id", args, func(a *event.Allocation) string { return idKey(a.ID) })
+	if err != nil {
+		return nil, err
+	}
+	conn := &allocationConnection{nodes: []*allocationResolver{}, pageInfo: pi}
+	for i := range rows {
+		conn.nodes = append(conn.nodes, &allocationResolver{r: r, a: &rows[i]})
+	}
+	return conn, nil
+}
+
+type blobberResolver struct {
+	r *Resolver
+	b *event.Blobber
+}
+
+func (b *blobberResolver) Id() string                 { return b.b.ID }
+func (b *blobberResolver) Url() string                { return b.b.BaseURL }
+func (b *blobberResolver) DelegateWallet() string     { return b.b.DelegateWallet }
+func (b *blobberResolver) ReadPrice() Int64           { return Int64(b.b.ReadPrice) }
+func (b *blobberResolver) WritePrice() Int64          { return Int64(b.b.WritePrice) }
+func (b *blobberResolver) Capacity() Int64            { return Int64(b.b.Capacity) }
+func (b *blobberResolver) Allocated() Int64           { return Int64(b.b.Allocated) }
+func (b *blobberResolver) SavedData() Int64           { return Int64(b.b.SavedData) }
+func (b *blobberResolver) ReadData() Int64            { return Int64(b.b.ReadData) }
+func (b *blobberResolver) TotalStake() Int64          { return Int64(b.b.TotalStake) }
+func (b *blobberResolver) NumDelegates() int32        { return int32(b.b.NumDelegates) }
+func (b *blobberResolver) ServiceCharge() float64     { return b.b.ServiceCharge }
+func (b *blobberResolver) NotAvailable() bool         { return b.b.NotAvailable }
+func (b *blobberResolver) IsRestricted() bool         { return b.b.IsRestricted }
+func (b *blobberResolver) Region() string             { return b.b.Region }
+func (b *blobberResolver) Operator() string           { return b.b.Operator }
+func (b *blobberResolver) Reputation() float64        { return b.b.Reputation }
+func (b *blobberResolver) IsKilled() bool             { return b.b.IsKilled }
+func (b *blobberResolver) IsShutdown() bool           { return b.b.IsShutdown }
+func (b *blobberResolver) ChallengesPassed() Int64    { return Int64(b.b.ChallengesPassed) }
+func (b *blobberResolver) ChallengesCompleted() Int64 { return Int64(b.b.ChallengesCompleted) }
+func (b *blobberResolver) OpenChallenges() Int64      { return Int64(b.b.OpenChallenges) }
+func (b *blobberResolver) TotalBlockRewards() Int64   { return Int64(b.b.TotalBlockRewards) }
+func (b *blobberResolver) TotalStorageIncome() Int64  { return Int64(b.b.TotalStorageIncome) }
+func (b *blobberResolver) TotalReadIncome() Int64     { return Int64(b.b.TotalReadIncome) }
+func (b *blobberResolver) TotalSlashedStake() Int64   { return Int64(b.b.TotalSlashedStake) }
+func (b *blobberResolver) CreationRound() Int64       { return Int64(b.b.CreationRound) }
+
+func (b *blobberResolver) Challenges(ctx context.Context, args struct {
+	PageArgs
+	Filter *challengeFilter
+}) (*challengeConnection, error) {
+	db, err := b.r.model(ctx, &event.Challenge{})
+	if err != nil {
+		return nil, err
+	}
+	db = args.Filter.apply(db.Where("blobber_id = ?", b.b.ID))
+	return b.r.challenges(ctx, db, args.PageArgs)
+}
+
+func (b *blobberResolver) Rewards(ctx context.Context, args struct {
+	PageArgs
+	Filter *rewardFilter
+}) (*providerRewardConnection, error) {
+	db, err := b.r.model(ctx, &event.RewardProvider{})
+	if err != nil {
+		return nil, err
+	}
+	db = args.Filter.apply(db.Where("provider_id = ?", b.b.ID), false)
+	return b.r.providerRewards(ctx, db, args.PageArgs)
+}
+
+func (b *blobberResolver) DelegatePools(ctx context.Context, args struct {
+	PageArgs
+	Filter *delegatePoolFilter
+}) (*delegatePoolConnection, error) {
+	db, err := b.r.model(ctx, &event.DelegatePool{})
+	if err != nil {
+		return nil, err
+	}
+	db = args.Filter.apply(db.Where("provider_id = ? AND provider_type = ?", b.b.ID, spenum.Blobber))
+	return b.r.delegatePools(ctx, db, args.PageArgs)
+}
+
+type blobberConnection struct {
+	nodes    []*blobberResolver
+	pageInfo pageInfo
+}
+
+func (c *blobberConnection) Nodes() []*blobberResolver { return c.nodes }
+func (c *blobberConnection) PageInfo() pageInfo        { return c.pageInfo }
+
+func (r *Resolver) findBlobber(ctx context.Context, db *gorm.DB) (*blobberResolver, error) {
+	b, err := findOne[event.Blobber](ctx, db)
+	if err != nil || b == nil {
+		return nil, err
+	}
+	return &blobberResolver{r: r, b: b}, nil
+}
+
+func (r *Resolver) blobbers(ctx context.Context, db *gorm.DB, args PageArgs) (*blobberConnection, error) {
+	rows, pi, err := findPage(ctx, db, "id", args, func(b *event.Blobber) string { return b.ID })
+	if err != nil {
+		return nil, err
+	}
+	conn := &blobberConnection{nodes: []*blobberResolver{}, pageInfo: pi}
+	for i := range rows {
+		conn.nodes = append(conn.nodes, &blobberResolver{r: r, b: &rows[i]})
+	}
+	return conn, nil
+}
+
+type challengeResolver struct {
+	r *Resolver
+	c *event.Challenge
+}
+
+func (c *challengeResolver) Id() string             { return c.c.ChallengeID }
+func (c *challengeResolver) AllocationId() string   { return c.c.AllocationID }
+func (c *challengeResolver) BlobberId() string      { return c.c.BlobberID }
+func (c *challengeResolver) ValidatorsId() string   { return c.c.ValidatorsID }
+func (c *challengeResolver) Seed() Int64            { return Int64(c.c.Seed) }
+func (c *challengeResolver) AllocationRoot() string { return c.c.AllocationRoot }
+func (c *challengeResolver) Responded() bool        { return c.c.Responded > 0 }
+func (c *challengeResolver) Passed() bool           { return c.c.Passed }
+func (c *challengeResolver) RoundCreatedAt() Int64  { return Int64(c.c.RoundCreatedAt) }
+func (c *challengeResolver) RoundResponded() Int64  { return Int64(c.c.RoundResponded) }
+func (c *challengeResolver) CreatedAt() Int64       { return Int64(c.c.CreatedAt) }
+
+func (c *challengeResolver) Allocation(ctx context.Context) (*allocationResolver, error) {
+	db, err := c.r.model(ctx, &event.Allocation{})
+	if err != nil {
+		return nil, err
+	}
+	return c.r.findAllocation(ctx, db.Where("allocation_id = ?", c.c.AllocationID))
+}
+
+func (c *challengeResolver) Blobber(ctx context.Context) (*blobberResolver, error) {
+	db, err := c.r.model(ctx, &event.Blobber{})
+	if err != nil {
+		return nil, err
+	}
+	return c.r.findBlobber(ctx, db.Where("id = ?", c.c.BlobberID))
+}
+
+type challengeConnection struct {
+	nodes    []*challengeResolver
+	pageInfo pageInfo
+}
+
+func (c *challengeConnection) Nodes() []*challengeResolver { return c.nodes }
+func (c *challengeConnection) PageInfo() pageInfo          { return c.pageInfo }
+
+func (r *Resolver) challenges(ctx context.Context, db *gorm.DB, args PageArgs) (*challengeConnection, error) {
+	rows, pi, err := findPage(ctx, db, "id", args, func(c *event.Challenge) string { return idKey(c.ID) })
+	if err != nil {
+		return nil, err
+	}
+	conn := &challengeConnection{nodes: []*challengeResolver{}, pageInfo: pi}
+	for i := range rows {
+		conn.nodes = append(conn.nodes, &challengeResolver{r: r, c: &rows[i]})
+	}
+	return conn, nil
+}
+
+type providerRewardResolver struct {
+	r  *Resolver
+	rp *event.RewardProvider
+}
+
+func (p *providerRewardResolver) Amount() Int64        { return Int64(p.rp.Amount) }
+func (p *providerRewardResolver) Round() Int64         { return Int64(p.rp.BlockNumber) }
+func (p *providerRewardResolver) ProviderId() string   { return p.rp.ProviderId }
+func (p *providerRewardResolver) RewardType() string   { return p.rp.RewardType.String() }
+func (p *providerRewardResolver) AllocationId() string { return p.rp.AllocationID }
+
+func (p *providerRewardResolver) Allocation(ctx context.Context) (*allocationResolver, error) {
+	if p.rp.AllocationID == "" {
+		return nil, nil
+	}
+	db, err := p.r.model(ctx, &event.Allocation{})
+	if err != nil {
+		return nil, err
+	}
+	return p.r.findAllocation(ctx, db.Where("allocation_id = ?", p.rp.AllocationID))
+}
+
+type providerRewardConnection struct {
+	nodes    []*providerRewardResolver
+	pageInfo pageInfo
+}
+
+func (c *providerRewardConnection) Nodes() []*providerRewardResolver { return c.nodes }
+func (c *providerRewardConnection) PageInfo() pageInfo               { return c.pageInfo }
+
+func (r *Resolver) providerRewards(ctx context.Context, db *gorm.DB, args PageArgs) (*providerRewardConnection, error) {
+	rows, pi, err := findPage(ctx, db, "id", args, func(rp *event.RewardProvider) string { return idKey(rp.ID) })
+	if err != nil {
+		return nil, err
+	}
+	conn := &providerRewardConnection{nodes: []*providerRewardResolver{}, pageInfo: pi}
+	for i := range rows {
+		conn.nodes = append(conn.nodes, &providerRewardResolver{r: r, rp: &rows[i]})
+	}
+	return conn, nil
+}
+
+type delegateRewardResolver struct {
+	r  *Resolver
+	rd *event.RewardDelegate
+}
+
+func (d *delegateRewardResolver) Amount() Int64        { return Int64(d.rd.Amount) }
+func (d *delegateRewardResolver) Round() Int64         { return Int64(d.rd.BlockNumber) }
+func (d *delegateRewardResolver) PoolId() string       { return d.rd.PoolID }
+func (d *delegateRewardResolver) ProviderId() string   { return d.rd.ProviderID }
+func (d *delegateRewardResolver) RewardType() string   { return d.rd.RewardType.String() }
+func (d *delegateRewardResolver) AllocationId() string { return d.rd.AllocationID }
+
+func (d *delegateRewardResolver) Allocation(ctx context.Context) (*allocationResolver, error) {
+	if d.rd.AllocationID == "" {
+		return nil, nil
+	}
+	db, err := d.r.model(ctx, &event.Allocation{})
+	if err != nil {
+		return nil, err
+	}
+	return d.r.findAllocation(ctx, db.Where("allocation_id = ?", d.rd.AllocationID))
+}
+
+type delegateRewardConnection struct {
+	nodes    []*delegateRewardResolver
+	pageInfo pageInfo
+}
+
+func (c *delegateRewardConnection) Nodes() []*delegateRewardResolver { return c.nodes }
+func (c *delegateRewardConnection) PageInfo() pageInfo               { return c.pageInfo }
+
+func (r *Resolver) delegateRewards(ctx context.Context, db *gorm.DB, args PageArgs) (*delegateRewardConnection, error) {
+	rows, pi, err := findPage(ctx, db, "id", args, func(rd *event.RewardDelegate) string { return idKey(rd.ID) })
+	if err != nil {
+		return nil, err
+	}
+	conn := &delegateRewardConnection{nodes: []*delegateRewardResolver{}, pageInfo: pi}
+	for i := range rows {
+		conn.nodes = append(conn.nodes, &delegateRewardResolver{r: r, rd: &rows[i]})
+	}
+	return conn, nil
+}
+
+type delegatePoolResolver struct {
+	r  *Resolver
+	dp *event.DelegatePool
+}
+
+func (d *delegatePoolResolver) PoolId() string       { return d.dp.PoolID }
+func (d *delegatePoolResolver) ProviderId() string   { return d.dp.ProviderID }
+func (d *delegatePoolResolver) ProviderType() string { return d.dp.ProviderType.String() }
+func (d *delegatePoolResolver) DelegateId() string   { return d.dp.DelegateID }
+func (d *delegatePoolResolver) Balance() Int64       { return Int64(d.dp.Balance) }
+func (d *delegatePoolResolver) Reward() Int64        { return Int64(d.dp.Reward) }
+func (d *delegatePoolResolver) TotalReward() Int64   { return Int64(d.dp.TotalReward) }
+func (d *delegatePoolResolver) TotalPenalty() Int64  { return Int64(d.dp.TotalPenalty) }
+func (d *delegatePoolResolver) Status() string       { return d.dp.Status.String() }
+func (d *delegatePoolResolver) RoundCreated() Int64  { return Int64(d.dp.RoundCreated) }
+func (d *delegatePoolResolver) StakedAt() Int64      { return Int64(d.dp.StakedAt) }
+func (d *delegatePoolResolver) AutoCompound() bool   { return d.dp.AutoCompound }
+
+func (d *delegatePoolResolver) Delegate(ctx context.Context) (*userResolver, error) {
+	db, err := d.r.model(ctx, &event.User{})
+	if err != nil {
+		return nil, err
+	}
+	return d.r.findUser(ctx, db.Where("user_id = ?", d.dp.DelegateID))
+}
+
+func (d *delegatePoolResolver) Rewards(ctx context.Context, args struct {
+	PageArgs
+	Filter *rewardFilter
+}) (*delegateRewardConnection, error) {
+	db, err := d.r.model(ctx, &event.RewardDelegate{})
+	if err != nil {
+		return nil, err
+	}
+	db = args.Filter.apply(db.Where("pool_id = ? AND provider_id = ?", d.dp.PoolID, d.dp.ProviderID), true)
+	return d.r.delegateRewards(ctx, db, args.PageArgs)
+}
+
+type delegatePoolConnection struct {
+	nodes    []*delegatePoolResolver
+	pageInfo pageInfo
+}
+
+func (c *delegatePoolConnection) Nodes() []*delegatePoolResolver { return c.nodes }
+func (c *delegatePoolConnection) PageInfo() pageInfo             { return c.pageInfo }
+
+func (r *Resolver) delegatePools(ctx context.Context, db *gorm.DB, args PageArgs) (*delegatePoolConnection, error) {
+	rows, pi, err := findPage(ctx, db, "id", args, func(dp *event.DelegatePool) string { return idKey(dp.ID) })
+	if err != nil {
+		return nil, err
+	}
+	conn := &delegatePoolConnection{nodes: []*delegatePoolResolver{}, pageInfo: pi}
+	for i := range rows {
+		conn.nodes = append(conn.nodes, &delegatePoolResolver{r: r, dp: &rows[i]})
+	}
+	return conn, nil
+}
+
+type userResolver struct {
+	r *Resolver
+	u *event.User
+}
+
+func (u *userResolver) Id() string     { return u.u.UserID }
+func (u *userResolver) Balance() Int64 { return Int64(u.u.Balance) }
+func (u *userResolver) Nonce() Int64   { return Int64(u.u.Nonce) }
+func (u *userResolver) Round() Int64   { return Int64(u.u.Round) }
+
+func (u *userResolver) Transactions(ctx context.Context, args struct{ PageArgs }) (*transactionConnection, error) {
+	db, err := u.r.model(ctx, &event.Transaction{})
+	if err != nil {
+		return nil, err
+	}
+	return u.r.transactions(ctx, db.Where("client_id = ?", u.u.UserID), args.PageArgs)
+}
+
+func (u *userResolver) Allocations(ctx context.Context, args struct {
+	PageArgs
+	Filter *allocationFilter
+}) (*allocationConnection, error) {
+	db, err := u.r.model(ctx, &event.Allocation{})
+	if err != nil {
+		return nil, err
+	}
+	db = args.Filter.apply(db.Where("owner = ?", u.u.UserID))
+	return u.r.allocations(ctx, db, args.PageArgs)
+}
+
+func (u *userResolver) DelegatePools(ctx context.Context, args struct {
+	PageArgs
+	Filter *delegatePoolFilter
+}) (*delegatePoolConnection, error) {
+	db, err := u.r.model(ctx, &event.DelegatePool{})
+	if err != nil {
+		return nil, err
+	}
+	db = args.Filter.apply(db.Where("delegate_id = ?", u.u.UserID))
+	return u.r.delegatePools(ctx, db, args.PageArgs)
+}
+
+type userConnection struct {
+	nodes    []*userResolver
+	pageInfo pageInfo
+}
+
+func (c *userConnection) Nodes() []*userResolver { return c.nodes }
+func (c *userConnection) PageInfo() pageInfo     { return c.pageInfo }
+
+func (r *Resolver) findUser(ctx context.Context, db *gorm.DB) (*userResolver, error) {
+	u, err := findOne[event.User](ctx, db)
+	if err != nil || u == nil {
+		return nil, err
+	}
+	return &userResolver{r: r, u: u}, nil
+}
@@ -157,6 +157,18 @@ func BenchmarkRestTests(
 				},
 				Endpoint: mrh.getDelegateRewards,
 			},
+			{
+				FuncName: "liveness",
+				Endpoint: mrh.getLiveness,
+			},
+			{
+				FuncName: "provider-jails",
+				Params: map[string]string{
+					"id":    data.Miners[0],
+					"limit": "20",
+				},
+				Endpoint: mrh.getProviderJails,
+			},
 		},
 		ADDRESS,
 		mrh,
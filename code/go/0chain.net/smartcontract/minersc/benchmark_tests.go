@@ -2,6 +2,7 @@ package minersc
 
 import (
 	"encoding/json"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -59,7 +60,8 @@ func (bt BenchTest) Transaction() *transaction.Transaction {
 
 func (bt BenchTest) Run(balances cstate.TimedQueryStateContext, b *testing.B) error {
 	b.StopTimer()
-	if bt.name == "miner.shareSignsOrShares" {
+	switch bt.name {
+	case "miner.shareSignsOrShares":
 		var pn = PhaseNode{
 			Phase:        Publish,
 			StartRound:   1,
@@ -70,6 +72,19 @@ func (bt BenchTest) Run(balances cstate.TimedQueryStateContext, b *testing.B) er
 		if err != nil {
 			panic(err)
 		}
+	case "miner.unjail":
+		var req provider.ProviderRequest
+		if err := req.Decode(bt.input); err != nil {
+			panic(err)
+		}
+		mn, err := getMinerNode(req.ID, balances)
+		if err != nil {
+			panic(err)
+		}
+		mn.jail(1)
+		if err := mn.save(balances); err != nil {
+			panic(err)
+		}
 	}
 	b.StartTimer()
 
@@ -83,7 +98,7 @@ func (bt BenchTest) Run(balances cstate.TimedQueryStateContext, b *testing.B) er
 }
 
 func BenchmarkTests(
-	data bk.BenchData, _ bk.SignatureScheme,
+	data bk.BenchData, sigScheme bk.SignatureScheme,
 ) bk.TestSuite {
 	creationTimeRaw := viper.GetInt64("MptCreationTime")
 	creationTime := common.Now()
@@ -302,6 +317,14 @@ func BenchmarkTests(
 					"max_charge":                          "0.5",
 					"epoch":                               "6415000000",
 					"reward_decline_rate":                 "0.1",
+					"equivocation_slash":                  "0.5",
+					"equivocation_reporter_reward":        "0.1",
+					"liveness_window":                     "100",
+					"max_missed_proposals":                "50",
+					"max_missed_tickets":                  "50",
+					"max_missed_health_checks":            "3",
+					"jail_penalty":                        "0.05",
+					"jail_cooldown":                       "1000",
 					"owner_id":                            "f769ccdf8587b8cab6a0f6a8a5a0a91d3405392768f283c80a45d6023a1bfa1f",
 					"cost.add_miner":                      "111",
 					"cost.add_sharder":                    "111",
@@ -323,6 +346,8 @@ func BenchmarkTests(
 					"cost.sharder_keep":                            "111",
 					"cost.kill_miner":                              "111",
 					"cost.kill_sharder":                            "111",
+					"cost.submit_equivocation_evidence":            "111",
+					"cost.unjail":                                  "111",
 				},
 			}).Encode(),
 		},
@@ -402,6 +427,34 @@ func BenchmarkTests(
 				ProviderID:   data.Miners[0],
 			}).Encode(),
 		},
+		{
+			name:     "miner.redelegate",
+			endpoint: msc.redelegate,
+			txn: &transaction.Transaction{
+				ClientID:     getMinerDelegatePoolId(0, 0, data.Clients),
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: (&stakepool.RedelegateRequest{
+				ProviderType:   spenum.Miner,
+				FromProviderID: data.Miners[0],
+				ToProviderID:   data.Miners[1],
+			}).Encode(),
+		},
+		{
+			name:     "miner.stake_pool_auto_compound",
+			endpoint: msc.stakePoolAutoCompound,
+			txn: &transaction.Transaction{
+				ClientID:     getMinerDelegatePoolId(0, 0, data.Clients),
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: (&stakepool.AutoCompoundRequest{
+				ProviderType: spenum.Miner,
+				ProviderID:   data.Miners[0],
+				AutoCompound: true,
+			}).Encode(),
+		},
 		{
 			name:     "miner.sharder_keep",
 			endpoint: msc.sharderKeep,
@@ -463,6 +516,40 @@ func BenchmarkTests(
 				return bytes
 			}(),
 		},
+		{
+			name:     "miner.submit_equivocation_evidence",
+			endpoint: msc.submitEquivocationEvidence,
+			txn: &transaction.Transaction{
+				ClientID:     data.Clients[0],
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: func() []byte {
+				ev := equivocationEvidence{Kind: equivocationBlock}
+				for i, sh := range []*SignedBlockHeader{&ev.First, &ev.Second} {
+					sh.MinerID = data.Miners[0]
+					sh.Round = viper.GetInt64(bk.NumBlocks)
+					sh.PrevHash = encryption.Hash("mock previous block " + strconv.Itoa(i))
+					_ = sigScheme.SetPublicKey(data.MinerKeys[0])
+					sigScheme.SetPrivateKey(data.MinerPrivateKeys[0])
+					sh.Signature, _ = sigScheme.Sign(sh.Hash())
+				}
+				bytes, _ := json.Marshal(&ev)
+				return bytes
+			}(),
+		},
+		{
+			name:     "miner.unjail",
+			endpoint: msc.unjail,
+			txn: &transaction.Transaction{
+				ClientID:     data.Clients[0],
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: (&provider.ProviderRequest{
+				ID: data.Miners[0],
+			}).Encode(),
+		},
 		{
 			name:     "miner.add_hardfork",
 			endpoint: msc.addHardFork,
@@ -82,6 +82,22 @@ func (msc *MinerSmartContract) deleteFromDelegatePool(
 	return resp, actErr
 }
 
+// enable or disable compounding of the rewards of a delegate pool
+func (msc *MinerSmartContract) stakePoolAutoCompound(
+	t *transaction.Transaction, inputData []byte, _ *GlobalNode,
+	balances cstate.StateContextI) (resp string, err error) {
+	return stakepool.StakePoolAutoCompound(t, inputData, balances, msc.getStakePoolAdapter)
+}
+
+// move delegated stake between two miners or two sharders
+func (msc *MinerSmartContract) redelegate(
+	t *transaction.Transaction, inputData []byte, gn *GlobalNode,
+	balances cstate.StateContextI) (resp string, err error) {
+	return stakepool.StakePoolRedelegate(t, inputData, balances,
+		stakepool.ValidationSettings{MaxStake: gn.MaxStake, MinStake: gn.MinStake, MaxNumDelegates: gn.MaxDelegates},
+		msc.getStakePoolAdapter, msc.refreshProvider)
+}
+
 // getStakePool of given blobber
 func (msc *MinerSmartContract) refreshProvider(
 	providerType spenum.Provider, providerID string, balances cstate.StateContextI,
@@ -326,32 +326,53 @@ func (msc *MinerSmartContract) createDKGMinersForContribute(
 		return err
 	}
 
-	if len(allMinersList.Nodes) < gn.MinN {
-		return common.NewErrorf("failed to create dkg miners", "too few miners for dkg, l_all_miners: %d, N: %d", len(allMinersList.Nodes), gn.MinN)
+	// jailed miners stay out of the DKG until they're unjailed, since the
+	// hermes hard fork
+	miners := allMinersList.Nodes
+	if err := cstate.WithActivation(balances, "hermes", func() error {
+		return nil
+	}, func() error {
+		miners = make([]*MinerNode, 0, len(allMinersList.Nodes))
+		for _, nd := range allMinersList.Nodes {
+			if !nd.isJailed() {
+				miners = append(miners, nd)
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if len(miners) < gn.MinN {
+		return common.NewErrorf("failed to create dkg miners", "too few miners for dkg, l_miners: %d, l_all_miners: %d, N: %d",
+			len(miners), len(allMinersList.Nodes), gn.MinN)
 	}
 
 	dkgMiners := NewDKGMinerNodes()
 	if lmb := balances.GetChainCurrentMagicBlock(); lmb != nil {
 		num := lmb.Miners.Size()
+		if num > len(miners) {
+			num = len(miners)
+		}
 		Logger.Debug("Calculate TKN from lmb",
 			zap.Int64("starting round", lmb.StartingRound),
 			zap.Int("miners num", num))
 		if num >= gn.MinN {
 			dkgMiners.calculateTKN(gn, num)
 		} else {
-			Logger.Debug("Calculate TKN from all miner list",
-				zap.Int("all count", len(allMinersList.Nodes)),
+			Logger.Debug("Calculate TKN from miner list",
+				zap.Int("count", len(miners)),
 				zap.Int64("gn.LastRound", gn.LastRound))
-			dkgMiners.calculateTKN(gn, len(allMinersList.Nodes))
+			dkgMiners.calculateTKN(gn, len(miners))
 		}
 	} else {
-		Logger.Debug("Calculate TKN from all miner list",
-			zap.Int("all count", len(allMinersList.Nodes)),
+		Logger.Debug("Calculate TKN from miner list",
+			zap.Int("count", len(miners)),
 			zap.Int64("gn.LastRound", gn.LastRound))
-		dkgMiners.calculateTKN(gn, len(allMinersList.Nodes))
+		dkgMiners.calculateTKN(gn, len(miners))
 	}
 
-	for _, nd := range allMinersList.Nodes {
+	for _, nd := range miners {
 		dkgMiners.SimpleNodes[nd.ID] = nd.SimpleNode
 	}
 
@@ -0,0 +1,244 @@
+package minersc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"0chain.net/chaincore/block"
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/util"
+)
+
+//go:generate msgp -io=false -tests=false -unexported -v
+
+//msgp:ignore SignedBlockHeader equivocationEvidence equivocationResult
+
+// kinds of the equivocation evidence
+const (
+	// two blocks of a round signed by their generator
+	equivocationBlock = "block"
+	// verification tickets of two blocks of a round generated by the same
+	// miner, signed by the same verifier
+	equivocationVerificationTicket = "verification_ticket"
+)
+
+// SignedBlockHeader is the header of a block with a signature of the block
+// hash, either the signature of the generator or a verification ticket.
+type SignedBlockHeader struct {
+	block.Header
+	Signature string `json:"signature"`
+}
+
+// verify checks the signature is the one of the given miner of the magic
+// block of the round of the block.
+func (sh *SignedBlockHeader) verify(signerID string, balances cstate.StateContextI) error {
+	mb := balances.GetMagicBlock(sh.Round)
+	if mb == nil {
+		return fmt.Errorf("no magic block for round %d", sh.Round)
+	}
+
+	n := mb.Miners.GetNode(signerID)
+	if n == nil {
+		return fmt.Errorf("%s is not a miner of the magic block of round %d", signerID, sh.Round)
+	}
+
+	signatureScheme := balances.GetSignatureScheme()
+	if err := signatureScheme.SetPublicKey(n.PublicKey); err != nil {
+		return err
+	}
+
+	ok, err := signatureScheme.Verify(sh.Signature, sh.Hash())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("invalid signature of block " + sh.Hash())
+	}
+	return nil
+}
+
+// equivocationEvidence proves a miner signed two conflicting blocks. The
+// blocks conflict when they have the same round, round random seed and
+// generator, so the same rank, but different hashes. A generator proposes
+// a single block per rank and an honest verifier verifies a single block
+// per generator and rank, so the signer of both blocks equivocated.
+type equivocationEvidence struct {
+	Kind string `json:"kind"`
+	// SignerID is the verifier of the blocks, the generator of the blocks
+	// signs the evidence of the block kind.
+	SignerID string            `json:"signer_id,omitempty"`
+	First    SignedBlockHeader `json:"first"`
+	Second   SignedBlockHeader `json:"second"`
+}
+
+func (ee *equivocationEvidence) decode(input []byte) error {
+	return json.Unmarshal(input, ee)
+}
+
+// offenderID returns the id of the miner signed both blocks.
+func (ee *equivocationEvidence) offenderID() string {
+	if ee.Kind == equivocationBlock {
+		return ee.First.MinerID
+	}
+	return ee.SignerID
+}
+
+func (ee *equivocationEvidence) validate(balances cstate.StateContextI) error {
+	switch ee.Kind {
+	case equivocationBlock:
+	case equivocationVerificationTicket:
+		if ee.SignerID == "" {
+			return errors.New("missing signer of the verification tickets")
+		}
+	default:
+		return fmt.Errorf("unknown kind of evidence: %q", ee.Kind)
+	}
+
+	first, second := &ee.First, &ee.Second
+	switch {
+	case first.Round != second.Round:
+		return errors.New("blocks of different rounds")
+	case first.RoundRandomSeed != second.RoundRandomSeed:
+		return errors.New("blocks of different round random seeds")
+	case first.MinerID != second.MinerID:
+		return errors.New("blocks of different generators")
+	case first.Hash() == second.Hash():
+		return errors.New("blocks are the same")
+	case first.Round > balances.GetBlock().Round:
+		return errors.New("blocks of a future round")
+	}
+
+	offenderID := ee.offenderID()
+	if err := first.verify(offenderID, balances); err != nil {
+		return err
+	}
+	return second.verify(offenderID, balances)
+}
+
+// equivocationRecord marks the equivocation of a miner in a round as
+// punished, so the same misbehaviour isn't slashed twice.
+type equivocationRecord struct {
+	Round    int64  `json:"round"`
+	Reporter string `json:"reporter"`
+}
+
+func equivocationKey(offenderID string, round int64) datastore.Key {
+	return ADDRESS + encryption.Hash("equivocation:"+offenderID+":"+strconv.FormatInt(round, 10))
+}
+
+// equivocationResult is the response of submitting equivocation evidence.
+type equivocationResult struct {
+	OffenderID     string        `json:"offender_id"`
+	Round          int64         `json:"round"`
+	Slashed        currency.Coin `json:"slashed"`
+	ReporterReward currency.Coin `json:"reporter_reward"`
+}
+
+// submitEquivocationEvidence slashes the stake pool of a miner proven to
+// have signed two conflicting blocks of a round. A part of the slashed
+// stake goes to the reporter, and the miner is jailed, so not rewarded nor
+// in the next DKG sets, until it's unjailed.
+func (msc *MinerSmartContract) submitEquivocationEvidence(
+	txn *transaction.Transaction,
+	input []byte,
+	gn *GlobalNode,
+	balances cstate.StateContextI,
+) (string, error) {
+	const errCode = "submit_equivocation_evidence_failed"
+
+	var ev equivocationEvidence
+	if err := ev.decode(input); err != nil {
+		return "", common.NewError(errCode, "invalid evidence: "+err.Error())
+	}
+
+	if err := ev.validate(balances); err != nil {
+		return "", common.NewError(errCode, "invalid evidence: "+err.Error())
+	}
+
+	offenderID := ev.offenderID()
+	if offenderID == txn.ClientID {
+		return "", common.NewError(errCode, "miner can't report itself")
+	}
+
+	key := equivocationKey(offenderID, ev.First.Round)
+	err := balances.GetTrieNode(key, &equivocationRecord{})
+	switch err {
+	case nil:
+		return "", common.NewErrorf(errCode, "equivocation of %s in round %d is punished already",
+			offenderID, ev.First.Round)
+	case util.ErrValueNotPresent:
+	default:
+		return "", common.NewError(errCode, err.Error())
+	}
+
+	mn, err := getMinerNode(offenderID, balances)
+	if err != nil {
+		return "", common.NewError(errCode, "can't get miner: "+err.Error())
+	}
+
+	staked, err := mn.StakePool.TotalStake()
+	if err != nil {
+		return "", common.NewError(errCode, err.Error())
+	}
+
+	if err := mn.StakePool.SlashFraction(gn.EquivocationSlash, mn.ID, spenum.Miner, balances); err != nil {
+		return "", common.NewError(errCode, "slashing stake pool: "+err.Error())
+	}
+
+	if mn.TotalStaked, err = mn.StakePool.TotalStake(); err != nil {
+		return "", common.NewError(errCode, err.Error())
+	}
+
+	res := equivocationResult{
+		OffenderID: offenderID,
+		Round:      ev.First.Round,
+	}
+	if res.Slashed, err = currency.MinusCoin(staked, mn.TotalStaked); err != nil {
+		return "", common.NewError(errCode, err.Error())
+	}
+
+	// the slashed tokens stay with the SC, but for the reporter reward
+	if res.ReporterReward, err = currency.MultFloat64(res.Slashed, gn.EquivocationReporterReward); err != nil {
+		return "", common.NewError(errCode, err.Error())
+	}
+	if res.ReporterReward > 0 {
+		if err := balances.AddTransfer(state.NewTransfer(ADDRESS, txn.ClientID, res.ReporterReward)); err != nil {
+			return "", common.NewError(errCode, "rewarding reporter: "+err.Error())
+		}
+	}
+
+	mn.jail(balances.GetBlock().Round)
+	if err := mn.save(balances); err != nil {
+		return "", common.NewError(errCode, err.Error())
+	}
+
+	if _, err := balances.InsertTrieNode(key, &equivocationRecord{
+		Round:    ev.First.Round,
+		Reporter: txn.ClientID,
+	}); err != nil {
+		return "", common.NewError(errCode, "saving evidence: "+err.Error())
+	}
+
+	emitProviderJail(mn, event.ProviderJail{
+		Status:  event.ProviderJailed,
+		Reason:  event.JailReasonEquivocation,
+		Penalty: res.Slashed,
+		Round:   balances.GetBlock().Round,
+	}, balances)
+
+	out, err := json.Marshal(&res)
+	if err != nil {
+		return "", common.NewError(errCode, err.Error())
+	}
+	return string(out), nil
+}
@@ -0,0 +1,129 @@
+package minersc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/smartcontract/stakepool"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmitEquivocationEvidence(t *testing.T) {
+	var (
+		balances = newTestBalances()
+		msc      = newTestMinerSC()
+		gn       = &GlobalNode{EquivocationSlash: 0.5, EquivocationReporterReward: 0.2}
+		offender = newMiner(t, true, balances)
+		verifier = newMiner(t, true, balances)
+		reporter = newClient(0, balances)
+	)
+	balances.setBalance(ADDRESS, 1000)
+
+	for _, m := range []*miner{offender, verifier} {
+		m.node.ProviderType = spenum.Miner
+		m.node.Pools[m.delegate.id] = &stakepool.DelegatePool{
+			Balance:    1000,
+			DelegateID: m.delegate.id,
+			Status:     spenum.Active,
+		}
+		require.NoError(t, m.node.save(balances))
+	}
+
+	sign := func(signer *miner, h block.Header) SignedBlockHeader {
+		sig, err := signer.miner.scheme.Sign(h.Hash())
+		require.NoError(t, err)
+		return SignedBlockHeader{Header: h, Signature: sig}
+	}
+
+	first := block.Header{
+		MinerID:         offender.miner.id,
+		PrevHash:        "prev",
+		Round:           90,
+		RoundRandomSeed: 7,
+		MerkleTreeRoot:  "first",
+	}
+	second := first
+	second.MerkleTreeRoot = "second"
+
+	submit := func(ev *equivocationEvidence) (*equivocationResult, error) {
+		txn := newTransaction(reporter.id, ADDRESS, 0, 10)
+		balances.txn = txn
+		resp, err := msc.submitEquivocationEvidence(txn, mustEncode(ev), gn, balances)
+		if err != nil {
+			return nil, err
+		}
+		var res equivocationResult
+		require.NoError(t, json.Unmarshal([]byte(resp), &res))
+		return &res, nil
+	}
+
+	_, err := submit(&equivocationEvidence{
+		Kind:   equivocationBlock,
+		First:  sign(offender, first),
+		Second: sign(offender, first),
+	})
+	require.Error(t, err, "the same block twice")
+
+	_, err = submit(&equivocationEvidence{
+		Kind:   equivocationBlock,
+		First:  sign(offender, first),
+		Second: sign(verifier, second),
+	})
+	require.Error(t, err, "second block signed by another miner")
+
+	nextRound := second
+	nextRound.Round++
+	_, err = submit(&equivocationEvidence{
+		Kind:   equivocationBlock,
+		First:  sign(offender, first),
+		Second: sign(offender, nextRound),
+	})
+	require.Error(t, err, "blocks of different rounds")
+
+	res, err := submit(&equivocationEvidence{
+		Kind:   equivocationBlock,
+		First:  sign(offender, first),
+		Second: sign(offender, second),
+	})
+	require.NoError(t, err)
+	require.Equal(t, offender.miner.id, res.OffenderID)
+	require.EqualValues(t, 500, res.Slashed)
+	require.EqualValues(t, 100, res.ReporterReward)
+	require.EqualValues(t, 100, balances.balances[reporter.id])
+
+	mn, err := getMinerNode(offender.miner.id, balances)
+	require.NoError(t, err)
+	require.EqualValues(t, 500, mn.Pools[offender.delegate.id].Balance)
+	require.True(t, mn.isJailed())
+	require.Equal(t, balances.block.Round, mn.JailedRound)
+
+	_, err = submit(&equivocationEvidence{
+		Kind:   equivocationBlock,
+		First:  sign(offender, second),
+		Second: sign(offender, first),
+	})
+	require.Error(t, err, "punished already")
+
+	res, err = submit(&equivocationEvidence{
+		Kind:     equivocationVerificationTicket,
+		SignerID: verifier.miner.id,
+		First:    sign(verifier, first),
+		Second:   sign(verifier, second),
+	})
+	require.NoError(t, err)
+	require.Equal(t, verifier.miner.id, res.OffenderID)
+}
+
+func TestSimpleNodeJailedMsgp(t *testing.T) {
+	sn := &SimpleNode{JailedRound: 3}
+
+	b, err := sn.MarshalMsg(nil)
+	require.NoError(t, err)
+
+	var got SimpleNode
+	_, err = got.UnmarshalMsg(b)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, got.JailedRound)
+}
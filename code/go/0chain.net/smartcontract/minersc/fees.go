@@ -326,6 +326,7 @@ func (msc *MinerSmartContract) payFees(t *transaction.Transaction,
 			b.GetRoundRandomSeed(),
 			gn.NumMinerDelegatesRewarded,
 			spenum.BlockRewardMiner,
+			gn.MaxStake,
 			balances,
 		); err != nil {
 			return "", err
@@ -338,6 +339,7 @@ func (msc *MinerSmartContract) payFees(t *transaction.Transaction,
 			b.GetRoundRandomSeed(),
 			gn.NumMinerDelegatesRewarded,
 			spenum.FeeRewardMiner,
+			gn.MaxStake,
 			balances,
 		); err != nil {
 			return "", err
@@ -384,6 +386,10 @@ func (msc *MinerSmartContract) payFees(t *transaction.Transaction,
 		}
 
 		for _, sh := range rewardSharders {
+			if err = updateTotalStaked(sh, balances); err != nil {
+				return "", common.NewErrorf("pay_fees/pay_sharders",
+					"updating total stake: %v", err)
+			}
 			if err = sh.save(balances); err != nil {
 				return "", common.NewErrorf("pay_fees/pay_sharders",
 					"saving sharder node: %v", err)
@@ -394,6 +400,10 @@ func (msc *MinerSmartContract) payFees(t *transaction.Transaction,
 	}
 
 	if mn != nil {
+		if err = updateTotalStaked(mn, balances); err != nil {
+			return "", common.NewErrorf("pay_fees",
+				"updating total stake: %v", err)
+		}
 		// save node first, for the VC pools work
 		if err = mn.save(balances); err != nil {
 			return "", common.NewErrorf("pay_fees",
@@ -401,6 +411,14 @@ func (msc *MinerSmartContract) payFees(t *transaction.Transaction,
 		}
 	}
 
+	if err = cstate.WithActivation(balances, "hermes", func() error {
+		return nil
+	}, func() error {
+		return trackLiveness(b, gn, balances)
+	}); err != nil {
+		return "", common.NewErrorf("pay_fees", "tracking liveness: %v", err)
+	}
+
 	if gn.RewardRoundFrequency != 0 && b.Round%gn.RewardRoundFrequency == 0 {
 		var lfmb = balances.GetLastestFinalizedMagicBlock().MagicBlock
 		if lfmb != nil {
@@ -424,8 +442,8 @@ func (msc *MinerSmartContract) payFees(t *transaction.Transaction,
 }
 
 // getRewardedMiner
-// if there is a valid un-killed block miner use that
-// otherwise select a random un-killed miner.
+// if there is a valid un-killed, un-jailed block miner use that
+// otherwise select a random un-killed, un-jailed miner.
 func getRewardedMiner(bk *block.Block, balances cstate.CommonStateContextI) (*MinerNode, error) {
 	mn, err := getMinerNode(bk.MinerID, balances)
 	if err != nil {
@@ -434,7 +452,7 @@ func getRewardedMiner(bk *block.Block, balances cstate.CommonStateContextI) (*Mi
 			zap.String("block miner id", bk.MinerID),
 			zap.Error(err))
 	} else {
-		if !mn.HasBeenKilled {
+		if !mn.HasBeenKilled && !mn.isJailed() {
 			return mn, nil
 		}
 	}
@@ -454,7 +472,7 @@ func getRewardedMiner(bk *block.Block, balances cstate.CommonStateContextI) (*Mi
 func filterDeadNodes(nodes []*MinerNode) []*MinerNode {
 	var filteredNodes []*MinerNode
 	for _, node := range nodes {
-		if !node.SimpleNode.HasBeenKilled {
+		if !node.SimpleNode.HasBeenKilled && !node.SimpleNode.isJailed() {
 			filteredNodes = append(filteredNodes, node)
 		}
 	}
@@ -468,7 +486,7 @@ func getLiveSharderIds(balances cstate.StateContextI) ([]string, error) {
 	}
 	var ids []string
 	for i := range nodes.Nodes {
-		if !nodes.Nodes[i].SimpleNode.HasBeenKilled {
+		if !nodes.Nodes[i].SimpleNode.HasBeenKilled && !nodes.Nodes[i].SimpleNode.isJailed() {
 			ids = append(ids, nodes.Nodes[i].ID)
 		}
 	}
@@ -577,7 +595,7 @@ func (msc *MinerSmartContract) payShardersAndDelegates(
 			return err
 		}
 		if err = sh.StakePool.DistributeRewardsRandN(
-			moveValue, sh.ID, spenum.Sharder, seed, gn.NumSharderDelegatesRewarded, rewardType, balances,
+			moveValue, sh.ID, spenum.Sharder, seed, gn.NumSharderDelegatesRewarded, rewardType, gn.MaxStake, balances,
 		); err != nil {
 			return common.NewErrorf("pay_fees/pay_sharders",
 				"distributing rewards: %v", err)
@@ -594,3 +612,12 @@ func (msc *MinerSmartContract) payShardersAndDelegates(
 
 	return nil
 }
+
+// updateTotalStaked keeps the total stake of a rewarded node in line with its
+// stake pool, the compounded rewards are staked on distribution
+func updateTotalStaked(n *MinerNode, balances cstate.StateContextI) error {
+	return cstate.WithActivation(balances, "hermes", func() error { return nil }, func() (err error) {
+		n.TotalStaked, err = n.StakePool.TotalStake()
+		return err
+	})
+}
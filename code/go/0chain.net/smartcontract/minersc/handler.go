@@ -63,6 +63,8 @@ func GetEndpoints(rh rest.RestHandlerI) []rest.Endpoint {
 		rest.MakeEndpoint(miner+"/hardfork", common.UserRateLimit(mrh.getHardfork)),
 		rest.MakeEndpoint(miner+"/provider-rewards", common.UserRateLimit(mrh.getProviderRewards)),
 		rest.MakeEndpoint(miner+"/delegate-rewards", common.UserRateLimit(mrh.getDelegateRewards)),
+		rest.MakeEndpoint(miner+"/liveness", common.UserRateLimit(mrh.getLiveness)),
+		rest.MakeEndpoint(miner+"/provider-jails", common.UserRateLimit(mrh.getProviderJails)),
 
 		//test endpoints
 		rest.MakeEndpoint("/test/screst/nodeStat", common.UserRateLimit(mrh.testNodeStat)),
@@ -193,6 +195,81 @@ func (mrh *MinerRestHandler) getProviderRewards(w http.ResponseWriter, r *http.R
 	common.Respond(w, r, rtv, nil)
 }
 
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d9/liveness miner-sc GetLiveness
+// Get liveness.
+// Retrieve the misses of the miners in the current liveness window.
+//
+// responses:
+//
+//	200: Liveness
+//	500:
+func (mrh *MinerRestHandler) getLiveness(w http.ResponseWriter, r *http.Request) {
+	lv, err := getLiveness(mrh.GetQueryStateContext())
+	switch err {
+	case nil:
+	case util.ErrValueNotPresent:
+		lv = newLiveness(0)
+	default:
+		common.Respond(w, r, nil, common.NewErrInternal("can't get liveness", err.Error()))
+		return
+	}
+	common.Respond(w, r, lv, nil)
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d9/provider-jails miner-sc GetProviderJails
+// Get provider jails.
+// Retrieve the jailing history of a miner or a sharder, supports pagination.
+//
+// parameters:
+//
+//	 +name: id
+//	  description: ID of the miner or the sharder
+//	  required: true
+//	  in: query
+//	  type: string
+//	 +name: offset
+//	  description: offset for pagination
+//	  in: query
+//	  type: string
+//	 +name: limit
+//	  description: limit for pagination
+//	  in: query
+//	  type: string
+//	 +name: sort
+//	  description: Sort direction (desc or asc)
+//	  in: query
+//	  type: string
+//
+// responses:
+//
+//	200: []ProviderJail
+//	400:
+//	500:
+func (mrh *MinerRestHandler) getProviderJails(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing id parameter"))
+		return
+	}
+	limit, err := common2.GetOffsetLimitOrderParam(r.URL.Query())
+	if err != nil {
+		common.Respond(w, r, nil, err)
+		return
+	}
+
+	edb := mrh.GetQueryStateContext().GetEventDB()
+	if edb == nil {
+		common.Respond(w, r, nil, common.NewErrInternal("no db connection"))
+		return
+	}
+	jails, err := edb.GetProviderJails(id, limit)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal(err.Error()))
+		return
+	}
+	common.Respond(w, r, jails, nil)
+}
+
 // swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d9/configs miner-sc GetMinerSCConfigs
 // Get Miner SC configs.
 // Retrieve the miner SC global configuration.
@@ -50,6 +50,8 @@ func (msc *MinerSmartContract) initSC() {
 	msc.smartContractFunctions["update_settings"] = msc.updateSettings
 	msc.smartContractFunctions["addToDelegatePool"] = msc.addToDelegatePool
 	msc.smartContractFunctions["deleteFromDelegatePool"] = msc.deleteFromDelegatePool
+	msc.smartContractFunctions["redelegate"] = msc.redelegate
+	msc.smartContractFunctions["stake_pool_auto_compound"] = msc.stakePoolAutoCompound
 	msc.smartContractFunctions["sharder_keep"] = msc.sharderKeep
 }
 
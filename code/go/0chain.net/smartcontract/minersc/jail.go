@@ -0,0 +1,14 @@
+package minersc
+
+// isJailed reports whether the node is jailed, for an equivocation or for
+// missing its liveness duties. A jailed node receives no rewards and stays
+// out of the next DKG sets until it's unjailed.
+func (smn *SimpleNode) isJailed() bool {
+	return smn.JailedRound > 0
+}
+
+// jail jails the node in the given round, the jail cooldown starts over
+// for a node jailed already.
+func (smn *SimpleNode) jail(round int64) {
+	smn.JailedRound = round
+}
@@ -0,0 +1,317 @@
+package minersc
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"0chain.net/chaincore/block"
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/provider"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/logging"
+	"github.com/0chain/common/core/util"
+	"go.uber.org/zap"
+)
+
+//go:generate msgp -io=false -tests=false -unexported -v
+
+// NodeLiveness is the misses of a miner in the current liveness window.
+type NodeLiveness struct {
+	// MissedProposals is the number of rounds a block of a lower rank
+	// miner was finalized, so the block of the miner wasn't there.
+	MissedProposals int64 `json:"missed_proposals"`
+	// MissedTickets is the number of blocks the verification ticket of the
+	// miner wasn't included for.
+	MissedTickets int64 `json:"missed_tickets"`
+}
+
+// Liveness counts the misses of the miners since the start of the current
+// liveness window.
+type Liveness struct {
+	WindowStart int64                    `json:"window_start"`
+	Nodes       map[string]*NodeLiveness `json:"nodes"`
+}
+
+func newLiveness(windowStart int64) *Liveness {
+	return &Liveness{
+		WindowStart: windowStart,
+		Nodes:       make(map[string]*NodeLiveness),
+	}
+}
+
+func getLiveness(balances cstate.CommonStateContextI) (*Liveness, error) {
+	lv := newLiveness(0)
+	if err := balances.GetTrieNode(LivenessKey, lv); err != nil {
+		return nil, err
+	}
+	return lv, nil
+}
+
+func (lv *Liveness) node(id string) *NodeLiveness {
+	nl, ok := lv.Nodes[id]
+	if !ok {
+		nl = &NodeLiveness{}
+		lv.Nodes[id] = nl
+	}
+	return nl
+}
+
+// countMissedProposals counts a missed proposal for every miner of the
+// magic block ranked before the generator of the block.
+func (lv *Liveness) countMissedProposals(b *block.Block, mb *block.MagicBlock) {
+	if mb == nil || mb.Miners == nil {
+		return
+	}
+
+	generator := mb.Miners.GetNode(b.MinerID)
+	if generator == nil {
+		return
+	}
+
+	// the same ranks the round computes for the miners
+	ranks := rand.New(rand.NewSource(b.GetRoundRandomSeed())).Perm(mb.Miners.Size())
+	if generator.SetIndex >= len(ranks) {
+		return
+	}
+
+	generatorRank := ranks[generator.SetIndex]
+	for _, n := range mb.Miners.CopyNodes() {
+		if n.SetIndex < len(ranks) && ranks[n.SetIndex] < generatorRank {
+			lv.node(n.ID).MissedProposals++
+		}
+	}
+}
+
+// countMissedTickets counts a missed verification ticket for every miner of
+// the magic block of the previous round whose ticket for the previous block
+// isn't in the block. The tickets are the ones the generator gathered, at
+// least the notarization threshold, so an online miner misses some of them
+// too; an offline one misses all of them.
+func (lv *Liveness) countMissedTickets(b *block.Block, mb *block.MagicBlock) {
+	tickets := b.GetPrevBlockVerificationTickets()
+	if len(tickets) == 0 || mb == nil || mb.Miners == nil {
+		return
+	}
+
+	verified := make(map[string]struct{}, len(tickets))
+	for _, t := range tickets {
+		verified[t.VerifierID] = struct{}{}
+	}
+
+	for _, n := range mb.Miners.CopyNodes() {
+		if _, ok := verified[n.ID]; !ok {
+			lv.node(n.ID).MissedTickets++
+		}
+	}
+}
+
+// missedHealthChecks returns the number of health check periods the node
+// missed by the given time. The first period after the last health check is
+// the grace period of a slow health check. The last health check of a node
+// is its registration time until it sends one, so a zero one is of a node
+// without a registration time and nothing is missed before its first
+// health check.
+func missedHealthChecks(last, now common.Timestamp, period time.Duration) int64 {
+	if period <= 0 || last == 0 || now <= last {
+		return 0
+	}
+	missed := int64(time.Duration(now-last) * time.Second / period)
+	if missed > 0 {
+		missed--
+	}
+	return missed
+}
+
+// jailReason returns the reason the node crossing the limits is jailed
+// for, or an empty string if it hasn't crossed any limit.
+func (gn *GlobalNode) jailReason(nl *NodeLiveness, missedHealthChecks int64) string {
+	switch {
+	case gn.MaxMissedProposals > 0 && nl.MissedProposals > gn.MaxMissedProposals:
+		return event.JailReasonMissedProposals
+	case gn.MaxMissedTickets > 0 && nl.MissedTickets > gn.MaxMissedTickets:
+		return event.JailReasonMissedTickets
+	case gn.MaxMissedHealthChecks > 0 && missedHealthChecks > gn.MaxMissedHealthChecks:
+		return event.JailReasonMissedHealthChecks
+	}
+	return ""
+}
+
+// trackLiveness counts the misses of the miners in the block and, at the end
+// of the liveness window, jails the nodes crossing the limits.
+func trackLiveness(b *block.Block, gn *GlobalNode, balances cstate.StateContextI) error {
+	if gn.LivenessWindow <= 0 {
+		return nil
+	}
+
+	lv, err := getLiveness(balances)
+	switch err {
+	case nil:
+	case util.ErrValueNotPresent:
+		lv = newLiveness(b.Round)
+	default:
+		return fmt.Errorf("getting liveness: %v", err)
+	}
+
+	lv.countMissedProposals(b, balances.GetMagicBlock(b.Round))
+	lv.countMissedTickets(b, balances.GetMagicBlock(b.Round-1))
+
+	if b.Round >= lv.WindowStart+gn.LivenessWindow {
+		if err := jailNotLiveNodes(lv, b, gn, balances); err != nil {
+			return err
+		}
+		lv = newLiveness(b.Round + 1)
+	}
+
+	if _, err := balances.InsertTrieNode(LivenessKey, lv); err != nil {
+		return fmt.Errorf("saving liveness: %v", err)
+	}
+	return nil
+}
+
+// jailNotLiveNodes jails the miners and the sharders crossing the limits of
+// the liveness window.
+func jailNotLiveNodes(lv *Liveness, b *block.Block, gn *GlobalNode, balances cstate.StateContextI) error {
+	miners, err := getMinersList(balances)
+	if err != nil {
+		return fmt.Errorf("getting miners list: %v", err)
+	}
+	sharders, err := getAllShardersList(balances)
+	if err != nil {
+		return fmt.Errorf("getting sharders list: %v", err)
+	}
+
+	nodes := append(append([]*MinerNode{}, miners.Nodes...), sharders.Nodes...)
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].ID < nodes[j].ID
+	})
+
+	for _, n := range nodes {
+		if n.HasBeenKilled || n.isJailed() {
+			continue
+		}
+
+		nl, ok := lv.Nodes[n.ID]
+		if !ok {
+			nl = &NodeLiveness{}
+		}
+		missedHC := missedHealthChecks(n.LastHealthCheck, b.CreationDate, gn.HealthCheckPeriod)
+
+		reason := gn.jailReason(nl, missedHC)
+		if reason == "" {
+			continue
+		}
+
+		penalty, err := jailNode(n, gn.JailPenalty, b.Round, balances)
+		if err != nil {
+			return fmt.Errorf("jailing %s: %v", n.ID, err)
+		}
+
+		logging.Logger.Info("liveness: node jailed",
+			zap.String("id", n.ID),
+			zap.String("reason", reason),
+			zap.Int64("round", b.Round))
+
+		emitProviderJail(n, event.ProviderJail{
+			Status:             event.ProviderJailed,
+			Reason:             reason,
+			MissedProposals:    nl.MissedProposals,
+			MissedTickets:      nl.MissedTickets,
+			MissedHealthChecks: missedHC,
+			Penalty:            penalty,
+			Round:              b.Round,
+		}, balances)
+	}
+	return nil
+}
+
+// jailNode jails the node and slashes the given part of its stake,
+// returning the slashed tokens.
+func jailNode(n *MinerNode, penalty float64, round int64, balances cstate.StateContextI) (currency.Coin, error) {
+	staked, err := n.StakePool.TotalStake()
+	if err != nil {
+		return 0, err
+	}
+
+	if penalty > 0 {
+		if err := n.StakePool.SlashFraction(penalty, n.ID, n.ProviderType, balances); err != nil {
+			return 0, fmt.Errorf("slashing stake pool: %v", err)
+		}
+		if n.TotalStaked, err = n.StakePool.TotalStake(); err != nil {
+			return 0, err
+		}
+	}
+
+	n.jail(round)
+	if err := n.save(balances); err != nil {
+		return 0, err
+	}
+
+	return currency.MinusCoin(staked, n.TotalStaked)
+}
+
+func emitProviderJail(n *MinerNode, jail event.ProviderJail, balances cstate.StateContextI) {
+	jail.ProviderID = n.ID
+	jail.ProviderType = n.ProviderType
+	balances.EmitEvent(event.TypeStats, event.TagProviderJail, n.ID, jail)
+}
+
+// unjail releases a jailed miner or sharder once the jail cooldown is
+// over. The node or its delegate wallet
+// can unjail it.
+func (msc *MinerSmartContract) unjail(
+	txn *transaction.Transaction,
+	input []byte,
+	gn *GlobalNode,
+	balances cstate.StateContextI,
+) (string, error) {
+	const errCode = "unjail_failed"
+
+	var req provider.ProviderRequest
+	if err := req.Decode(input); err != nil {
+		return "", common.NewError(errCode, "invalid request: "+err.Error())
+	}
+
+	n, err := getMinerNode(req.ID, balances)
+	if err != nil {
+		n, err = getSharderNode(req.ID, balances)
+	}
+	if err != nil {
+		return "", common.NewError(errCode, "can't get node: "+err.Error())
+	}
+
+	if txn.ClientID != n.ID && (n.Settings.DelegateWallet == "" || txn.ClientID != n.Settings.DelegateWallet) {
+		return "", common.NewError(errCode, "only the node or its delegate wallet can unjail it")
+	}
+
+	if !n.isJailed() {
+		return "", common.NewError(errCode, "node isn't jailed")
+	}
+
+	round := balances.GetBlock().Round
+	if round < n.JailedRound+gn.JailCooldown {
+		return "", common.NewErrorf(errCode, "node can't be unjailed before round %d",
+			n.JailedRound+gn.JailCooldown)
+	}
+
+	n.JailedRound = 0
+	if err := n.save(balances); err != nil {
+		return "", common.NewError(errCode, err.Error())
+	}
+
+	emitProviderJail(n, event.ProviderJail{
+		Status: event.ProviderUnjailed,
+		Round:  round,
+	}, balances)
+
+	out, err := json.Marshal(n.SimpleNode)
+	if err != nil {
+		return "", common.NewError(errCode, err.Error())
+	}
+	return string(out), nil
+}
@@ -0,0 +1,164 @@
+package minersc
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/chaincore/node"
+	"0chain.net/core/common"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/provider"
+	"0chain.net/smartcontract/stakepool"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMissedHealthChecks(t *testing.T) {
+	const period = 10 * time.Second
+	tests := []struct {
+		name      string
+		last, now common.Timestamp
+		period    time.Duration
+		want      int64
+	}{
+		{name: "disabled", last: 100, now: 200, period: 0, want: 0},
+		{name: "never checked", last: 0, now: 200, period: period, want: 0},
+		{name: "just checked", last: 100, now: 100, period: period, want: 0},
+		{name: "slow check", last: 100, now: 115, period: period, want: 0},
+		{name: "one missed", last: 100, now: 120, period: period, want: 1},
+		{name: "many missed", last: 100, now: 200, period: period, want: 9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, missedHealthChecks(tt.last, tt.now, tt.period))
+		})
+	}
+}
+
+func TestLivenessJailAndUnjail(t *testing.T) {
+	var (
+		balances = newTestBalances()
+		msc      = newTestMinerSC()
+		gn       = &GlobalNode{
+			LivenessWindow:     10,
+			MaxMissedProposals: 2,
+			JailPenalty:        0.1,
+			JailCooldown:       50,
+		}
+		lazy = newMiner(t, true, balances)
+		live = newMiner(t, true, balances)
+	)
+
+	var ids NodeIDs
+	for _, m := range []*miner{lazy, live} {
+		m.node.ProviderType = spenum.Miner
+		m.node.Pools[m.delegate.id] = &stakepool.DelegatePool{
+			Balance:    1000,
+			DelegateID: m.delegate.id,
+			Status:     spenum.Active,
+		}
+		require.NoError(t, m.node.save(balances))
+		ids = append(ids, m.miner.id)
+	}
+	mustSave(t, AllMinersKey, &ids, balances)
+
+	lv := newLiveness(91)
+	lv.node(lazy.miner.id).MissedProposals = 3
+	lv.node(live.miner.id).MissedProposals = 1
+	mustSave(t, LivenessKey, lv, balances)
+
+	// the window isn't over yet
+	balances.block.Round = 99
+	require.NoError(t, trackLiveness(balances.block, gn, balances))
+	mn, err := getMinerNode(lazy.miner.id, balances)
+	require.NoError(t, err)
+	require.Zero(t, mn.JailedRound)
+
+	balances.block.Round = 101
+	require.NoError(t, trackLiveness(balances.block, gn, balances))
+
+	mn, err = getMinerNode(lazy.miner.id, balances)
+	require.NoError(t, err)
+	require.EqualValues(t, 101, mn.JailedRound)
+	require.True(t, mn.isJailed())
+	require.EqualValues(t, 900, mn.Pools[lazy.delegate.id].Balance)
+
+	mn, err = getMinerNode(live.miner.id, balances)
+	require.NoError(t, err)
+	require.Zero(t, mn.JailedRound)
+
+	lv, err = getLiveness(balances)
+	require.NoError(t, err)
+	require.EqualValues(t, 102, lv.WindowStart)
+	require.Empty(t, lv.Nodes)
+
+	unjail := func(clientID string) error {
+		txn := newTransaction(clientID, ADDRESS, 0, 10)
+		balances.txn = txn
+		resp, err := msc.unjail(txn, mustEncode(&provider.ProviderRequest{ID: lazy.miner.id}), gn, balances)
+		if err != nil {
+			return err
+		}
+		var sn SimpleNode
+		require.NoError(t, json.Unmarshal([]byte(resp), &sn))
+		return nil
+	}
+
+	require.Error(t, unjail(live.miner.id), "not the node or its delegate wallet")
+	require.Error(t, unjail(lazy.delegate.id), "cooldown isn't over")
+
+	balances.block.Round = 151
+	require.NoError(t, unjail(lazy.delegate.id))
+
+	mn, err = getMinerNode(lazy.miner.id, balances)
+	require.NoError(t, err)
+	require.Zero(t, mn.JailedRound)
+	require.False(t, mn.isJailed())
+
+	require.Error(t, unjail(lazy.miner.id), "not jailed")
+}
+
+func TestCountMissedTickets(t *testing.T) {
+	mb := block.NewMagicBlock()
+	mb.Miners = node.NewPool(node.NodeTypeMiner)
+	for _, id := range []string{"a", "b", "c"} {
+		n := &node.Node{Type: node.NodeTypeMiner}
+		n.ID = id
+		mb.Miners.Nodes = append(mb.Miners.Nodes, n)
+	}
+
+	b := block.NewBlock("", 10)
+	lv := newLiveness(1)
+	lv.countMissedTickets(b, mb)
+	require.Empty(t, lv.Nodes, "no tickets of the previous block")
+
+	b.PrevBlockVerificationTickets = []*block.VerificationTicket{
+		{VerifierID: "a"},
+		{VerifierID: "b"},
+	}
+	lv.countMissedTickets(b, mb)
+	lv.countMissedTickets(b, mb)
+	require.Len(t, lv.Nodes, 1)
+	require.EqualValues(t, 2, lv.Nodes["c"].MissedTickets)
+
+	gn := &GlobalNode{MaxMissedTickets: 2}
+	require.Empty(t, gn.jailReason(lv.Nodes["c"], 0))
+	lv.countMissedTickets(b, mb)
+	require.Equal(t, event.JailReasonMissedTickets, gn.jailReason(lv.Nodes["c"], 0))
+}
+
+func TestLivenessMsgp(t *testing.T) {
+	lv := newLiveness(10)
+	lv.node("a").MissedProposals = 2
+	lv.node("b").MissedTickets = 3
+
+	b, err := lv.MarshalMsg(nil)
+	require.NoError(t, err)
+
+	got := newLiveness(0)
+	_, err = got.UnmarshalMsg(b)
+	require.NoError(t, err)
+	require.Equal(t, lv, got)
+}
@@ -42,6 +42,8 @@ func (msc *MinerSmartContract) InitSmartContractFunctions() {
 
 	msc.smartContractFunctions["kill_miner"] = msc.killMiner
 	msc.smartContractFunctions["kill_sharder"] = msc.killSharder
+	msc.smartContractFunctions["submit_equivocation_evidence"] = msc.submitEquivocationEvidence
+	msc.smartContractFunctions["unjail"] = msc.unjail
 
 	msc.smartContractFunctions["miner_health_check"] = msc.minerHealthCheck
 	msc.smartContractFunctions["sharder_health_check"] = msc.sharderHealthCheck
@@ -58,6 +60,8 @@ func (msc *MinerSmartContract) InitSmartContractFunctions() {
 
 	msc.smartContractFunctions["addToDelegatePool"] = msc.addToDelegatePool
 	msc.smartContractFunctions["deleteFromDelegatePool"] = msc.deleteFromDelegatePool
+	msc.smartContractFunctions["redelegate"] = msc.redelegate
+	msc.smartContractFunctions["stake_pool_auto_compound"] = msc.stakePoolAutoCompound
 
 	msc.smartContractFunctions["sharder_keep"] = msc.sharderKeep
 	msc.smartContractFunctions["add_hardfork"] = msc.addHardFork
@@ -94,6 +94,7 @@ var (
 	PhaseKey             = globalKeyHash("phase")
 	DeleteMinersKey      = globalKeyHash("delete_miners")
 	DeleteShardersKey    = globalKeyHash("delete_sharders")
+	LivenessKey          = globalKeyHash("liveness")
 
 	lockAllMiners sync.Mutex
 )
@@ -258,6 +259,29 @@ type GlobalNode struct {
 	OwnerId              string         `json:"owner_id"`
 	CooldownPeriod       int64          `json:"cooldown_period"`
 	Cost                 map[string]int `json:"cost"`
+	// EquivocationSlash is the part of the stake of a miner slashed when
+	// it's proven to have signed two conflicting blocks of a round.
+	EquivocationSlash float64 `json:"equivocation_slash"`
+	// EquivocationReporterReward is the part of the slashed stake given to
+	// the client that submitted the evidence.
+	EquivocationReporterReward float64 `json:"equivocation_reporter_reward"`
+	// LivenessWindow is the number of rounds the misses of the nodes are
+	// counted over before jailing the ones crossing the limits, 0 disables
+	// the liveness tracking.
+	LivenessWindow int64 `json:"liveness_window"`
+	// MaxMissedProposals, MaxMissedTickets and MaxMissedHealthChecks are
+	// the misses allowed in a liveness window, 0 means no limit. The blocks
+	// carry the tickets of the notarization threshold at least, not of all
+	// the miners, so MaxMissedTickets should be well above the share of the
+	// window out of the threshold.
+	MaxMissedProposals    int64 `json:"max_missed_proposals"`
+	MaxMissedTickets      int64 `json:"max_missed_tickets"`
+	MaxMissedHealthChecks int64 `json:"max_missed_health_checks"`
+	// JailPenalty is the part of the stake slashed when a node is jailed.
+	JailPenalty float64 `json:"jail_penalty"`
+	// JailCooldown is the number of rounds a node stays jailed before it
+	// can be unjailed.
+	JailCooldown int64 `json:"jail_cooldown"`
 }
 
 func (gn *GlobalNode) readConfig() (err error) {
@@ -300,6 +324,14 @@ func (gn *GlobalNode) readConfig() (err error) {
 	gn.OwnerId = config2.SmartContractConfig.GetString(pfx + SettingName[OwnerId])
 	gn.CooldownPeriod = config2.SmartContractConfig.GetInt64(pfx + SettingName[CooldownPeriod])
 	gn.Cost = config2.SmartContractConfig.GetStringMapInt(pfx + "cost")
+	gn.EquivocationSlash = config2.SmartContractConfig.GetFloat64(pfx + SettingName[EquivocationSlash])
+	gn.EquivocationReporterReward = config2.SmartContractConfig.GetFloat64(pfx + SettingName[EquivocationReporterReward])
+	gn.LivenessWindow = config2.SmartContractConfig.GetInt64(pfx + SettingName[LivenessWindow])
+	gn.MaxMissedProposals = config2.SmartContractConfig.GetInt64(pfx + SettingName[MaxMissedProposals])
+	gn.MaxMissedTickets = config2.SmartContractConfig.GetInt64(pfx + SettingName[MaxMissedTickets])
+	gn.MaxMissedHealthChecks = config2.SmartContractConfig.GetInt64(pfx + SettingName[MaxMissedHealthChecks])
+	gn.JailPenalty = config2.SmartContractConfig.GetFloat64(pfx + SettingName[JailPenalty])
+	gn.JailCooldown = config2.SmartContractConfig.GetInt64(pfx + SettingName[JailCooldown])
 	return nil
 }
 
@@ -335,6 +367,38 @@ func (gn *GlobalNode) validate() error {
 		return fmt.Errorf("%s cannot be negative: %d",
 			NumShardersRewarded.String(), gn.NumShardersRewarded)
 	}
+	if gn.EquivocationSlash < 0 || gn.EquivocationSlash > 1 {
+		return fmt.Errorf("%s should be in the interval [0,1]: %v",
+			EquivocationSlash.String(), gn.EquivocationSlash)
+	}
+	if gn.EquivocationReporterReward < 0 || gn.EquivocationReporterReward > 1 {
+		return fmt.Errorf("%s should be in the interval [0,1]: %v",
+			EquivocationReporterReward.String(), gn.EquivocationReporterReward)
+	}
+	if gn.LivenessWindow < 0 {
+		return fmt.Errorf("%s cannot be negative: %d",
+			LivenessWindow.String(), gn.LivenessWindow)
+	}
+	if gn.MaxMissedProposals < 0 {
+		return fmt.Errorf("%s cannot be negative: %d",
+			MaxMissedProposals.String(), gn.MaxMissedProposals)
+	}
+	if gn.MaxMissedTickets < 0 {
+		return fmt.Errorf("%s cannot be negative: %d",
+			MaxMissedTickets.String(), gn.MaxMissedTickets)
+	}
+	if gn.MaxMissedHealthChecks < 0 {
+		return fmt.Errorf("%s cannot be negative: %d",
+			MaxMissedHealthChecks.String(), gn.MaxMissedHealthChecks)
+	}
+	if gn.JailCooldown < 0 {
+		return fmt.Errorf("%s cannot be negative: %d",
+			JailCooldown.String(), gn.JailCooldown)
+	}
+	if gn.JailPenalty < 0 || gn.JailPenalty > 1 {
+		return fmt.Errorf("%s should be in the interval [0,1]: %v",
+			JailPenalty.String(), gn.JailPenalty)
+	}
 	return nil
 }
 
@@ -417,6 +481,22 @@ func (gn *GlobalNode) Get(key Setting) (interface{}, error) {
 		return gn.OwnerId, nil
 	case CooldownPeriod:
 		return gn.CooldownPeriod, nil
+	case EquivocationSlash:
+		return gn.EquivocationSlash, nil
+	case EquivocationReporterReward:
+		return gn.EquivocationReporterReward, nil
+	case LivenessWindow:
+		return gn.LivenessWindow, nil
+	case MaxMissedProposals:
+		return gn.MaxMissedProposals, nil
+	case MaxMissedTickets:
+		return gn.MaxMissedTickets, nil
+	case MaxMissedHealthChecks:
+		return gn.MaxMissedHealthChecks, nil
+	case JailPenalty:
+		return gn.JailPenalty, nil
+	case JailCooldown:
+		return gn.JailCooldown, nil
 	default:
 		return nil, errors.New("Setting not implemented")
 	}
@@ -717,6 +797,10 @@ type SimpleNode struct {
 
 	//LastSettingUpdateRound will be set to round number when settings were updated
 	LastSettingUpdateRound int64 `json:"last_setting_update_round"`
+
+	// JailedRound is the round the node was jailed in, for an equivocation
+	// or for missing its liveness duties, 0 if it isn't jailed.
+	JailedRound int64 `json:"jailed_round,omitempty"`
 }
 
 func (smn *SimpleNode) Encode() []byte {
@@ -4,6 +4,7 @@ package minersc
 
 import (
 	"0chain.net/chaincore/block"
+
 	"github.com/tinylib/msgp/msgp"
 )
 
@@ -308,9 +309,9 @@ func (z *DKGMinerNodes) Msgsize() (s int) {
 // MarshalMsg implements msgp.Marshaler
 func (z *GlobalNode) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 28
+	// map header, size 36
 	// string "ViewChange"
-	o = append(o, 0xde, 0x0, 0x1c, 0xaa, 0x56, 0x69, 0x65, 0x77, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65)
+	o = append(o, 0xde, 0x0, 0x24, 0xaa, 0x56, 0x69, 0x65, 0x77, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65)
 	o = msgp.AppendInt64(o, z.ViewChange)
 	// string "MaxN"
 	o = append(o, 0xa4, 0x4d, 0x61, 0x78, 0x4e)
@@ -427,6 +428,30 @@ func (z *GlobalNode) MarshalMsg(b []byte) (o []byte, err error) {
 		o = msgp.AppendString(o, k)
 		o = msgp.AppendInt(o, za0002)
 	}
+	// string "EquivocationSlash"
+	o = append(o, 0xb1, 0x45, 0x71, 0x75, 0x69, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x6c, 0x61, 0x73, 0x68)
+	o = msgp.AppendFloat64(o, z.EquivocationSlash)
+	// string "EquivocationReporterReward"
+	o = append(o, 0xba, 0x45, 0x71, 0x75, 0x69, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64)
+	o = msgp.AppendFloat64(o, z.EquivocationReporterReward)
+	// string "LivenessWindow"
+	o = append(o, 0xae, 0x4c, 0x69, 0x76, 0x65, 0x6e, 0x65, 0x73, 0x73, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77)
+	o = msgp.AppendInt64(o, z.LivenessWindow)
+	// string "MaxMissedProposals"
+	o = append(o, 0xb2, 0x4d, 0x61, 0x78, 0x4d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x73)
+	o = msgp.AppendInt64(o, z.MaxMissedProposals)
+	// string "MaxMissedTickets"
+	o = append(o, 0xb0, 0x4d, 0x61, 0x78, 0x4d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73)
+	o = msgp.AppendInt64(o, z.MaxMissedTickets)
+	// string "MaxMissedHealthChecks"
+	o = append(o, 0xb5, 0x4d, 0x61, 0x78, 0x4d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73)
+	o = msgp.AppendInt64(o, z.MaxMissedHealthChecks)
+	// string "JailPenalty"
+	o = append(o, 0xab, 0x4a, 0x61, 0x69, 0x6c, 0x50, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79)
+	o = msgp.AppendFloat64(o, z.JailPenalty)
+	// string "JailCooldown"
+	o = append(o, 0xac, 0x4a, 0x61, 0x69, 0x6c, 0x43, 0x6f, 0x6f, 0x6c, 0x64, 0x6f, 0x77, 0x6e)
+	o = msgp.AppendInt64(o, z.JailCooldown)
 	return
 }
 
@@ -651,6 +676,54 @@ func (z *GlobalNode) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				}
 				z.Cost[za0001] = za0002
 			}
+		case "EquivocationSlash":
+			z.EquivocationSlash, bts, err = msgp.ReadFloat64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "EquivocationSlash")
+				return
+			}
+		case "EquivocationReporterReward":
+			z.EquivocationReporterReward, bts, err = msgp.ReadFloat64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "EquivocationReporterReward")
+				return
+			}
+		case "LivenessWindow":
+			z.LivenessWindow, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "LivenessWindow")
+				return
+			}
+		case "MaxMissedProposals":
+			z.MaxMissedProposals, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MaxMissedProposals")
+				return
+			}
+		case "MaxMissedTickets":
+			z.MaxMissedTickets, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MaxMissedTickets")
+				return
+			}
+		case "MaxMissedHealthChecks":
+			z.MaxMissedHealthChecks, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MaxMissedHealthChecks")
+				return
+			}
+		case "JailPenalty":
+			z.JailPenalty, bts, err = msgp.ReadFloat64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "JailPenalty")
+				return
+			}
+		case "JailCooldown":
+			z.JailCooldown, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "JailCooldown")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -678,6 +751,7 @@ func (z *GlobalNode) Msgsize() (s int) {
 			s += msgp.StringPrefixSize + len(za0001) + msgp.IntSize
 		}
 	}
+	s += 18 + msgp.Float64Size + 27 + msgp.Float64Size + 15 + msgp.Int64Size + 19 + msgp.Int64Size + 17 + msgp.Int64Size + 22 + msgp.Int64Size + 12 + msgp.Float64Size + 13 + msgp.Int64Size
 	return
 }
 
@@ -866,9 +940,9 @@ func (z *PhaseNode) Msgsize() (s int) {
 // MarshalMsg implements msgp.Marshaler
 func (z *SimpleNode) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 13
+	// map header, size 14
 	// string "Provider"
-	o = append(o, 0x8d, 0xa8, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72)
+	o = append(o, 0x8e, 0xa8, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72)
 	o, err = z.Provider.MarshalMsg(o)
 	if err != nil {
 		err = msgp.WrapError(err, "Provider")
@@ -918,6 +992,9 @@ func (z *SimpleNode) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "LastSettingUpdateRound"
 	o = append(o, 0xb6, 0x4c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x75, 0x6e, 0x64)
 	o = msgp.AppendInt64(o, z.LastSettingUpdateRound)
+	// string "JailedRound"
+	o = append(o, 0xab, 0x4a, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x52, 0x6f, 0x75, 0x6e, 0x64)
+	o = msgp.AppendInt64(o, z.JailedRound)
 	return
 }
 
@@ -1021,6 +1098,12 @@ func (z *SimpleNode) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "LastSettingUpdateRound")
 				return
 			}
+		case "JailedRound":
+			z.JailedRound, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "JailedRound")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -1035,7 +1118,7 @@ func (z *SimpleNode) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *SimpleNode) Msgsize() (s int) {
-	s = 1 + 9 + z.Provider.Msgsize() + 8 + msgp.StringPrefixSize + len(z.N2NHost) + 5 + msgp.StringPrefixSize + len(z.Host) + 5 + msgp.IntSize + 5 + msgp.StringPrefixSize + len(z.Path) + 10 + msgp.StringPrefixSize + len(z.PublicKey) + 10 + msgp.StringPrefixSize + len(z.ShortName) + 9 + msgp.StringPrefixSize + len(z.BuildTag) + 12 + z.TotalStaked.Msgsize() + 7 + msgp.BoolSize + 9 + msgp.IntSize + 16 + z.LastHealthCheck.Msgsize() + 23 + msgp.Int64Size
+	s = 1 + 9 + z.Provider.Msgsize() + 8 + msgp.StringPrefixSize + len(z.N2NHost) + 5 + msgp.StringPrefixSize + len(z.Host) + 5 + msgp.IntSize + 5 + msgp.StringPrefixSize + len(z.Path) + 10 + msgp.StringPrefixSize + len(z.PublicKey) + 10 + msgp.StringPrefixSize + len(z.ShortName) + 9 + msgp.StringPrefixSize + len(z.BuildTag) + 12 + z.TotalStaked.Msgsize() + 7 + msgp.BoolSize + 9 + msgp.IntSize + 16 + z.LastHealthCheck.Msgsize() + 23 + msgp.Int64Size + 12 + msgp.Int64Size
 	return
 }
 
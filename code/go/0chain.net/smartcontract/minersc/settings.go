@@ -62,10 +62,22 @@ const (
 	CostMintedTokens
 	CostAddToDelegatePool
 	CostDeleteFromDelegatePool
+	CostRedelegate
+	CostStakePoolAutoCompound
 	CostSharderKeep
 	CostKillMiner
 	CostKillSharder
+	CostEquivocationEvidence
+	CostUnjail
 	HealthCheckPeriod
+	EquivocationSlash
+	EquivocationReporterReward
+	LivenessWindow
+	MaxMissedProposals
+	MaxMissedTickets
+	MaxMissedHealthChecks
+	JailPenalty
+	JailCooldown
 	NumberOfSettings
 )
 
@@ -114,6 +126,14 @@ func initSettingName() {
 	SettingName[OwnerId] = "owner_id"
 	SettingName[CooldownPeriod] = "cooldown_period"
 	SettingName[HealthCheckPeriod] = "health_check_period"
+	SettingName[EquivocationSlash] = "equivocation_slash"
+	SettingName[EquivocationReporterReward] = "equivocation_reporter_reward"
+	SettingName[LivenessWindow] = "liveness_window"
+	SettingName[MaxMissedProposals] = "max_missed_proposals"
+	SettingName[MaxMissedTickets] = "max_missed_tickets"
+	SettingName[MaxMissedHealthChecks] = "max_missed_health_checks"
+	SettingName[JailPenalty] = "jail_penalty"
+	SettingName[JailCooldown] = "jail_cooldown"
 	SettingName[CostAddMiner] = "cost.add_miner"
 	SettingName[CostAddSharder] = "cost.add_sharder"
 	SettingName[CostDeleteMiner] = "cost.delete_miner"
@@ -131,9 +151,13 @@ func initSettingName() {
 	SettingName[CostMintedTokens] = strings.ToLower("cost.mintedTokens")
 	SettingName[CostAddToDelegatePool] = strings.ToLower("cost.addToDelegatePool")
 	SettingName[CostDeleteFromDelegatePool] = strings.ToLower("cost.deleteFromDelegatePool")
+	SettingName[CostRedelegate] = "cost.redelegate"
+	SettingName[CostStakePoolAutoCompound] = "cost.stake_pool_auto_compound"
 	SettingName[CostSharderKeep] = "cost.sharder_keep"
 	SettingName[CostKillMiner] = "cost.kill_miner"
 	SettingName[CostKillSharder] = "cost.kill_sharder"
+	SettingName[CostEquivocationEvidence] = "cost.submit_equivocation_evidence"
+	SettingName[CostUnjail] = "cost.unjail"
 }
 
 func initSettings() {
@@ -165,6 +189,14 @@ func initSettings() {
 		OwnerId.String():                     {OwnerId, config.Key},
 		CooldownPeriod.String():              {CooldownPeriod, config.Int64},
 		HealthCheckPeriod.String():           {HealthCheckPeriod, config.Duration},
+		EquivocationSlash.String():           {EquivocationSlash, config.Float64},
+		EquivocationReporterReward.String():  {EquivocationReporterReward, config.Float64},
+		LivenessWindow.String():              {LivenessWindow, config.Int64},
+		MaxMissedProposals.String():          {MaxMissedProposals, config.Int64},
+		MaxMissedTickets.String():            {MaxMissedTickets, config.Int64},
+		MaxMissedHealthChecks.String():       {MaxMissedHealthChecks, config.Int64},
+		JailPenalty.String():                 {JailPenalty, config.Float64},
+		JailCooldown.String():                {JailCooldown, config.Int64},
 		CostAddMiner.String():                {CostAddMiner, config.Cost},
 		CostAddSharder.String():              {CostAddSharder, config.Cost},
 		CostDeleteMiner.String():             {CostDeleteMiner, config.Cost},
@@ -182,9 +214,13 @@ func initSettings() {
 		CostMintedTokens.String():            {CostMintedTokens, config.Cost},
 		CostAddToDelegatePool.String():       {CostAddToDelegatePool, config.Cost},
 		CostDeleteFromDelegatePool.String():  {CostDeleteFromDelegatePool, config.Cost},
+		CostRedelegate.String():              {CostRedelegate, config.Cost},
+		CostStakePoolAutoCompound.String():   {CostStakePoolAutoCompound, config.Cost},
 		CostSharderKeep.String():             {CostSharderKeep, config.Cost},
 		CostKillMiner.String():               {CostKillMiner, config.Cost},
 		CostKillSharder.String():             {CostKillSharder, config.Cost},
+		CostEquivocationEvidence.String():    {CostEquivocationEvidence, config.Cost},
+		CostUnjail.String():                  {CostUnjail, config.Cost},
 	}
 }
 
@@ -246,6 +282,16 @@ func (gn *GlobalNode) setInt64(key string, change int64) error {
 		gn.Epoch = change
 	case CooldownPeriod:
 		gn.CooldownPeriod = change
+	case LivenessWindow:
+		gn.LivenessWindow = change
+	case MaxMissedProposals:
+		gn.MaxMissedProposals = change
+	case MaxMissedTickets:
+		gn.MaxMissedTickets = change
+	case MaxMissedHealthChecks:
+		gn.MaxMissedHealthChecks = change
+	case JailCooldown:
+		gn.JailCooldown = change
 	default:
 		return fmt.Errorf("key: %v not implemented as int64", key)
 	}
@@ -268,6 +314,12 @@ func (gn *GlobalNode) setFloat64(key string, change float64) error {
 		gn.MaxCharge = change
 	case RewardDeclineRate:
 		gn.RewardDeclineRate = change
+	case EquivocationSlash:
+		gn.EquivocationSlash = change
+	case EquivocationReporterReward:
+		gn.EquivocationReporterReward = change
+	case JailPenalty:
+		gn.JailPenalty = change
 	default:
 		return fmt.Errorf("key: %v not implemented as float64", key)
 	}
@@ -198,6 +198,14 @@ func TestUpdateSettings(t *testing.T) {
 					"max_charge":                          "0.5",
 					"epoch":                               "6415000000",
 					"reward_decline_rate":                 "0.1",
+					"equivocation_slash":                  "0.5",
+					"equivocation_reporter_reward":        "0.1",
+					"liveness_window":                     "100",
+					"max_missed_proposals":                "50",
+					"max_missed_tickets":                  "50",
+					"max_missed_health_checks":            "3",
+					"jail_penalty":                        "0.05",
+					"jail_cooldown":                       "1000",
 					"owner_id":                            owner,
 					"cost.add_miner":                      "111",
 					"cost.add_sharder":                    "111",
@@ -219,6 +227,8 @@ func TestUpdateSettings(t *testing.T) {
 					"cost.sharder_keep":                            "111",
 					"cost.kill_miner":                              "111",
 					"cost.kill_sharder":                            "111",
+					"cost.submit_equivocation_evidence":            "111",
+					"cost.unjail":                                  "111",
 				},
 			},
 		},
@@ -312,9 +312,12 @@ func (msc *MinerSmartContract) sharderKeep(_ *transaction.Transaction,
 	}
 
 	//check new sharder
-	_, err = getSharderNode(newSharder.ID, balances)
+	sn, err := getSharderNode(newSharder.ID, balances)
 	switch err {
 	case nil:
+		if sn.JailedRound > 0 {
+			return "", common.NewErrorf("sharder_keep", "sharder is jailed: %v", newSharder.ID)
+		}
 	case util.ErrValueNotPresent:
 		return "", common.NewErrorf("sharder_keep", "unknown sharder: %v", newSharder.ID)
 	default:
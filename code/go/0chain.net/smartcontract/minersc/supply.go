@@ -0,0 +1,54 @@
+package minersc
+
+import (
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/provider"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"0chain.net/smartcontract/supply"
+)
+
+func init() {
+	supply.RegisterContract(supply.Contract{
+		Name:      "miner",
+		Address:   ADDRESS,
+		Providers: []spenum.Provider{spenum.Miner, spenum.Sharder},
+		Records:   supplyRecords,
+	})
+	supply.RegisterNode(supply.NodeType{
+		Name:    "miner_node",
+		NewNode: func() supply.Node { return NewMinerNode() },
+		Key:     provider.GetKey,
+	})
+}
+
+// SupplyID returns the id of the miner or sharder, the decoded node may be
+// another provider under the same key prefix.
+func (mn *MinerNode) SupplyID() string {
+	if mn.SimpleNode == nil || mn.StakePool == nil ||
+		(mn.ProviderType != spenum.Miner && mn.ProviderType != spenum.Sharder) {
+		return ""
+	}
+	return mn.ID
+}
+
+// supplyRecords returns the block and fee rewards paid by the miner smart
+// contract and the transaction fees paid to it.
+func supplyRecords(edb *event.EventDb, round int64) ([]supply.Entry, error) {
+	blockRewards, err := edb.GetRewardsTotal(round, spenum.BlockRewardMiner, spenum.BlockRewardSharder)
+	if err != nil {
+		return nil, err
+	}
+	feeRewards, err := edb.GetRewardsTotal(round, spenum.FeeRewardMiner, spenum.FeeRewardSharder)
+	if err != nil {
+		return nil, err
+	}
+	fees, err := edb.GetTransactionFeesTotal(round)
+	if err != nil {
+		return nil, err
+	}
+	return []supply.Entry{
+		{Category: supply.BlockRewards, Amount: blockRewards},
+		{Category: supply.FeeRewards, Amount: feeRewards},
+		{Category: supply.TxnFees, Amount: fees},
+	}, nil
+}
@@ -95,7 +95,7 @@ func (p *partition) save(state state.StateContextI) error {
 	return err
 }
 
-func (p *partition) load(state state.StateContextI, key datastore.Key) error {
+func (p *partition) load(state state.CommonStateContextI, key datastore.Key) error {
 	err := state.GetTrieNode(key, p)
 	if err != nil {
 		return fmt.Errorf("load partition failed, key: %s, %v", key, err)
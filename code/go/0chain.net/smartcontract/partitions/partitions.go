@@ -131,7 +131,7 @@ func CreateIfNotExists(state state.StateContextI, name string, partitionSize int
 }
 
 // GetPartitions returns partitions of given name
-func GetPartitions(state state.StateContextI, name string) (*Partitions, error) {
+func GetPartitions(state state.CommonStateContextI, name string) (*Partitions, error) {
 	p := Partitions{}
 	if err := state.GetTrieNode(name, &p); err != nil {
 		return nil, err
@@ -287,7 +287,7 @@ func (p *Partitions) Get(state state.StateContextI, id string, v PartitionItem)
 
 // ForEachPart iterates all items in specific partition,
 // break whenever the callback function returns false
-func (p *Partitions) ForEachPart(state state.StateContextI, partIndex int, f func(partIndex int, id string, data []byte) (stop bool)) error {
+func (p *Partitions) ForEachPart(state state.CommonStateContextI, partIndex int, f func(partIndex int, id string, data []byte) (stop bool)) error {
 	part, err := p.getPartition(state, partIndex)
 	if err != nil {
 		return err
@@ -302,7 +302,7 @@ func (p *Partitions) ForEachPart(state state.StateContextI, partIndex int, f fun
 }
 
 // ForEach iterates all items in all partitions
-func (p *Partitions) ForEach(state state.StateContextI, f func(partIndex int, id string, data []byte) (stop bool)) error {
+func (p *Partitions) ForEach(state state.CommonStateContextI, f func(partIndex int, id string, data []byte) (stop bool)) error {
 	lastIdx := p.Last.Loc
 	for i := 0; i <= lastIdx; i++ {
 		if err := p.ForEachPart(state, i, f); err != nil {
@@ -747,7 +747,7 @@ func setPartitionItems(rtv []item, vs interface{}) error {
 	return nil
 }
 
-func (p *Partitions) getPartition(state state.StateContextI, i int) (*partition, error) {
+func (p *Partitions) getPartition(state state.CommonStateContextI, i int) (*partition, error) {
 	if i > p.Last.Loc {
 		return nil, fmt.Errorf("partition id %d overflow %d", i, p.Last.Loc)
 	}
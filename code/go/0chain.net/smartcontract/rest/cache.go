@@ -0,0 +1,305 @@
+package rest
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"0chain.net/core/common"
+	"0chain.net/core/encryption"
+	"0chain.net/core/viper"
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+const (
+	cacheHeader = "X-Cache"
+	cacheHit    = "HIT"
+	cacheMiss   = "MISS"
+)
+
+// clientHeaders are the response headers set for the client of the request,
+// they are not cached.
+var clientHeaders = []string{
+	"RateLimit-Limit",
+	"RateLimit-Remaining",
+	"RateLimit-Reset",
+	"Retry-After",
+	"Set-Cookie",
+}
+
+// CacheConfig of the REST response cache. A response can only change when
+// the latest finalized block changes, so the responses are cached per round
+// and dropped once a new block is finalized. The TTL bounds the age of a
+// response within a round, for the endpoints reading the event database or
+// the time.
+type CacheConfig struct {
+	Enabled bool
+	// MaxEntries is the number of responses kept, least recently used
+	// responses are evicted first.
+	MaxEntries int
+	// MaxEntrySize in bytes, larger responses are not cached.
+	MaxEntrySize int
+	// TTL of the responses of the endpoints without their own TTL.
+	TTL time.Duration
+	// Endpoints TTL by the lower cased last segment of the endpoint URI, a
+	// zero TTL disables the caching of the endpoint.
+	Endpoints map[string]time.Duration
+}
+
+// ttl returns the TTL of the responses of the endpoint.
+func (cc *CacheConfig) ttl(uri string) time.Duration {
+	if ttl, ok := cc.Endpoints[strings.ToLower(path.Base(uri))]; ok {
+		return ttl
+	}
+	return cc.TTL
+}
+
+// RoundQueryChainer is a QueryChainer that knows the round of the state its
+// queries run on, the responses are only cached for it.
+type RoundQueryChainer interface {
+	QueryChainer
+	// GetQueryRound returns the round of the state, a negative round when
+	// there is none.
+	GetQueryRound() int64
+}
+
+type cachedResponse struct {
+	round   int64
+	expires time.Time
+	status  int
+	header  http.Header
+	body    []byte
+	etag    string
+}
+
+// ResponseCache caches the responses of the GET requests to the REST
+// endpoints by endpoint, query parameters and round.
+type ResponseCache struct {
+	config  CacheConfig
+	entries *lru.Cache[string, *cachedResponse]
+
+	mu    sync.Mutex
+	round int64
+
+	hits   int64
+	misses int64
+}
+
+// NewResponseCache creates a response cache, it returns nil when the cache
+// is disabled.
+func NewResponseCache(config CacheConfig) *ResponseCache {
+	if !config.Enabled || config.MaxEntries <= 0 {
+		return nil
+	}
+	entries, err := lru.New[string, *cachedResponse](config.MaxEntries)
+	if err != nil {
+		return nil
+	}
+	return &ResponseCache{config: config, entries: entries}
+}
+
+var responseCache *ResponseCache
+
+// ConfigResponseCache configures the REST response cache from the
+// network.user_handlers.cache settings, it must be called before the
+// endpoints are registered.
+func ConfigResponseCache() {
+	config := CacheConfig{
+		Enabled:      viper.GetBool("network.user_handlers.cache.enabled"),
+		MaxEntries:   viper.GetInt("network.user_handlers.cache.max_entries"),
+		MaxEntrySize: viper.GetInt("network.user_handlers.cache.max_entry_size"),
+		TTL:          viper.GetDuration("network.user_handlers.cache.ttl"),
+		Endpoints:    make(map[string]time.Duration),
+	}
+	for name := range viper.GetStringMap("network.user_handlers.cache.endpoints") {
+		config.Endpoints[name] = viper.GetDuration("network.user_handlers.cache.endpoints." + name)
+	}
+	responseCache = NewResponseCache(config)
+}
+
+// InvalidateResponseCache drops the cached responses of the rounds before
+// the given finalized round.
+func InvalidateResponseCache(round int64) {
+	responseCache.Invalidate(round)
+}
+
+// Invalidate drops the responses of the rounds before the given round.
+func (rc *ResponseCache) Invalidate(round int64) {
+	if rc == nil {
+		return
+	}
+	rc.mu.Lock()
+	if round <= rc.round {
+		rc.mu.Unlock()
+		return
+	}
+	rc.round = round
+	rc.mu.Unlock()
+
+	for _, key := range rc.entries.Keys() {
+		if cr, ok := rc.entries.Peek(key); ok && cr.round < round {
+			rc.entries.Remove(key)
+		}
+	}
+}
+
+// Len returns the number of cached responses.
+func (rc *ResponseCache) Len() int {
+	return rc.entries.Len()
+}
+
+// Hits returns the number of requests served from the cache.
+func (rc *ResponseCache) Hits() int64 {
+	return atomic.LoadInt64(&rc.hits)
+}
+
+// Misses returns the number of cacheable requests not found in the cache.
+func (rc *ResponseCache) Misses() int64 {
+	return atomic.LoadInt64(&rc.misses)
+}
+
+// Wrap caches the responses of the handler of the endpoint. The requests
+// are served from the cache while the state round is unchanged, without
+// going through the handler. The handlers are rate limited, the cache hits
+// are rate limited the same way, the cache only saves the handler work. The
+// cached responses carry an ETag and are not sent again to the clients
+// having them.
+func (rc *ResponseCache) Wrap(uri string, qc QueryChainer, fn func(w http.ResponseWriter, r *http.Request)) func(w http.ResponseWriter, r *http.Request) {
+	rqc, ok := qc.(RoundQueryChainer)
+	if rc == nil || !ok {
+		return fn
+	}
+	ttl := rc.config.ttl(uri)
+	if ttl <= 0 {
+		return fn
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		round := rqc.GetQueryRound()
+		if r.Method != http.MethodGet || round < 0 {
+			fn(w, r)
+			return
+		}
+
+		key := cacheKey(uri, r.URL.Query(), round)
+		if cr, ok := rc.entries.Get(key); ok && time.Now().Before(cr.expires) {
+			common.UserRateLimit(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt64(&rc.hits, 1)
+				cr.write(w, r, cacheHit)
+			})(w, r)
+			return
+		}
+		atomic.AddInt64(&rc.misses, 1)
+
+		rec := &responseRecorder{header: make(http.Header), status: http.StatusOK}
+		fn(rec, r)
+
+		// the headers of the client go to this response only
+		h := w.Header()
+		for _, k := range clientHeaders {
+			if v := rec.header.Values(k); len(v) > 0 {
+				h[http.CanonicalHeaderKey(k)] = v
+				rec.header.Del(k)
+			}
+		}
+		cr := &cachedResponse{
+			round:   round,
+			expires: time.Now().Add(ttl),
+			status:  rec.status,
+			header:  rec.header,
+			body:    rec.body.Bytes(),
+		}
+		if rec.status == http.StatusOK && (rc.config.MaxEntrySize <= 0 || len(cr.body) <= rc.config.MaxEntrySize) {
+			cr.etag = etag(cr.body)
+			if rc.isCurrent(round) {
+				rc.entries.Add(key, cr)
+			}
+		}
+		cr.write(w, r, cacheMiss)
+	}
+}
+
+// isCurrent reports whether the round has not been invalidated yet.
+func (rc *ResponseCache) isCurrent(round int64) bool {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return round >= rc.round
+}
+
+func (cr *cachedResponse) write(w http.ResponseWriter, r *http.Request, cache string) {
+	h := w.Header()
+	for k, v := range cr.header {
+		h[k] = v
+	}
+	if cr.etag == "" {
+		w.WriteHeader(cr.status)
+		w.Write(cr.body) //nolint:errcheck
+		return
+	}
+
+	h.Set("ETag", cr.etag)
+	h.Set(cacheHeader, cache)
+	if etagMatch(r.Header.Get("If-None-Match"), cr.etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.WriteHeader(cr.status)
+	w.Write(cr.body) //nolint:errcheck
+}
+
+// cacheKey of a request, the query parameters are encoded sorted by name so
+// that the same query in another order hits the same response.
+func cacheKey(uri string, params url.Values, round int64) string {
+	return strconv.FormatInt(round, 10) + " " + uri + "?" + params.Encode()
+}
+
+// etag of a response, it only depends on the body so that the clients
+// keep their copy across the rounds that do not change it.
+func etag(body []byte) string {
+	return `"` + encryption.Hash(body)[:32] + `"`
+}
+
+// etagMatch reports whether the If-None-Match header lists the ETag.
+func etagMatch(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	for _, t := range strings.Split(header, ",") {
+		t = strings.TrimPrefix(strings.TrimSpace(t), "W/")
+		if t == "*" || t == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// responseRecorder keeps the response of a handler to be cached.
+type responseRecorder struct {
+	header      http.Header
+	status      int
+	body        bytes.Buffer
+	wroteHeader bool
+}
+
+func (rr *responseRecorder) Header() http.Header {
+	return rr.header
+}
+
+func (rr *responseRecorder) WriteHeader(status int) {
+	if rr.wroteHeader {
+		return
+	}
+	rr.status = status
+	rr.wroteHeader = true
+}
+
+func (rr *responseRecorder) Write(b []byte) (int, error) {
+	rr.wroteHeader = true
+	return rr.body.Write(b)
+}
@@ -0,0 +1,186 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"0chain.net/core/common"
+	"0chain.net/core/viper"
+	"github.com/stretchr/testify/require"
+)
+
+type testRoundChainer struct {
+	TestQueryChainer
+	round int64
+}
+
+func (qc *testRoundChainer) GetQueryRound() int64 {
+	return qc.round
+}
+
+type testEndpoint struct {
+	calls  int
+	status int
+	body   string
+}
+
+func (te *testEndpoint) handle(w http.ResponseWriter, r *http.Request) {
+	te.calls++
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(te.status)
+	w.Write([]byte(te.body)) //nolint:errcheck
+}
+
+func serve(h func(w http.ResponseWriter, r *http.Request), method, target, etag string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, target, nil)
+	if etag != "" {
+		r.Header.Set("If-None-Match", etag)
+	}
+	w := httptest.NewRecorder()
+	h(w, r)
+	return w
+}
+
+func TestResponseCache(t *testing.T) {
+	rc := NewResponseCache(CacheConfig{
+		Enabled:      true,
+		MaxEntries:   10,
+		MaxEntrySize: 16,
+		TTL:          time.Minute,
+		Endpoints:    map[string]time.Duration{"getstakepoolstat": time.Millisecond, "nocache": 0},
+	})
+	qc := &testRoundChainer{round: 5}
+	te := &testEndpoint{status: http.StatusOK, body: `{"n":1}`}
+	h := rc.Wrap("/v1/screst/sc/getblobbers", qc, te.handle)
+
+	w := serve(h, http.MethodGet, "/v1/screst/sc/getblobbers?a=1&b=2", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, `{"n":1}`, w.Body.String())
+	require.Equal(t, cacheMiss, w.Header().Get(cacheHeader))
+	require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	etag := w.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	// the same query with the parameters in another order
+	w = serve(h, http.MethodGet, "/v1/screst/sc/getblobbers?b=2&a=1", "")
+	require.Equal(t, `{"n":1}`, w.Body.String())
+	require.Equal(t, cacheHit, w.Header().Get(cacheHeader))
+	require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	require.Equal(t, 1, te.calls)
+
+	// another query
+	serve(h, http.MethodGet, "/v1/screst/sc/getblobbers?a=2", "")
+	require.Equal(t, 2, te.calls)
+
+	// the client has the response already
+	w = serve(h, http.MethodGet, "/v1/screst/sc/getblobbers?a=1&b=2", `W/"x", `+etag)
+	require.Equal(t, http.StatusNotModified, w.Code)
+	require.Empty(t, w.Body.String())
+
+	// not cached
+	serve(h, http.MethodPost, "/v1/screst/sc/getblobbers?a=1&b=2", "")
+	require.Equal(t, 3, te.calls)
+
+	// a new block is finalized
+	qc.round = 6
+	rc.Invalidate(6)
+	require.Equal(t, 0, rc.Len())
+	w = serve(h, http.MethodGet, "/v1/screst/sc/getblobbers?a=1&b=2", etag)
+	require.Equal(t, http.StatusNotModified, w.Code)
+	require.Equal(t, cacheMiss, w.Header().Get(cacheHeader))
+	require.Equal(t, 4, te.calls)
+	require.Equal(t, int64(2), rc.Hits())
+	require.Equal(t, int64(3), rc.Misses())
+
+	// the responses of an invalidated round are not cached
+	qc.round = 5
+	serve(h, http.MethodGet, "/v1/screst/sc/getblobbers?a=3", "")
+	serve(h, http.MethodGet, "/v1/screst/sc/getblobbers?a=3", "")
+	require.Equal(t, 6, te.calls)
+
+	// errors and large responses are not cached
+	qc.round = 6
+	te.status = http.StatusBadRequest
+	serve(h, http.MethodGet, "/v1/screst/sc/getblobbers?a=4", "")
+	w = serve(h, http.MethodGet, "/v1/screst/sc/getblobbers?a=4", "")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Empty(t, w.Header().Get("ETag"))
+	require.Equal(t, 8, te.calls)
+	te.status = http.StatusOK
+	te.body = `{"n":"large response"}`
+	serve(h, http.MethodGet, "/v1/screst/sc/getblobbers?a=5", "")
+	serve(h, http.MethodGet, "/v1/screst/sc/getblobbers?a=5", "")
+	require.Equal(t, 10, te.calls)
+
+	// expired
+	te.body = `{"n":2}`
+	h = rc.Wrap("/v1/screst/sc/getStakePoolStat", qc, te.handle)
+	serve(h, http.MethodGet, "/v1/screst/sc/getStakePoolStat", "")
+	time.Sleep(2 * time.Millisecond)
+	serve(h, http.MethodGet, "/v1/screst/sc/getStakePoolStat", "")
+	require.Equal(t, 12, te.calls)
+
+	// caching disabled
+	h = rc.Wrap("/v1/screst/sc/nocache", qc, te.handle)
+	serve(h, http.MethodGet, "/v1/screst/sc/nocache", "")
+	serve(h, http.MethodGet, "/v1/screst/sc/nocache", "")
+	require.Equal(t, 14, te.calls)
+
+	// no state round
+	qc.round = -1
+	h = rc.Wrap("/v1/screst/sc/getblobbers", qc, te.handle)
+	serve(h, http.MethodGet, "/v1/screst/sc/getblobbers", "")
+	serve(h, http.MethodGet, "/v1/screst/sc/getblobbers", "")
+	require.Equal(t, 16, te.calls)
+}
+
+func TestResponseCacheRateLimit(t *testing.T) {
+	viper.Set("network.user_handlers.rate_limit", 1.0)
+	viper.Set("network.user_handlers.burst", 2)
+	common.ConfigRateLimits()
+	t.Cleanup(func() {
+		viper.Set("network.user_handlers.rate_limit", 0)
+		viper.Set("network.user_handlers.burst", 0)
+		common.ConfigRateLimits()
+	})
+
+	rc := NewResponseCache(CacheConfig{Enabled: true, MaxEntries: 10, TTL: time.Minute})
+	te := &testEndpoint{status: http.StatusOK, body: `{"n":1}`}
+	h := rc.Wrap("/v1/screst/sc/getblobbers", &testRoundChainer{round: 1}, common.UserRateLimit(te.handle))
+
+	w := serve(h, http.MethodGet, "/v1/screst/sc/getblobbers", "")
+	require.Equal(t, cacheMiss, w.Header().Get(cacheHeader))
+	require.Equal(t, "1", w.Header().Get("RateLimit-Remaining"))
+
+	// the hits take from the bucket of their client, not the cached one
+	w = serve(h, http.MethodGet, "/v1/screst/sc/getblobbers", "")
+	require.Equal(t, cacheHit, w.Header().Get(cacheHeader))
+	require.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+
+	w = serve(h, http.MethodGet, "/v1/screst/sc/getblobbers", "")
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	require.Empty(t, w.Header().Get(cacheHeader))
+	require.Equal(t, 1, te.calls)
+	require.Equal(t, int64(1), rc.Hits())
+}
+
+func TestResponseCacheDisabled(t *testing.T) {
+	require.Nil(t, NewResponseCache(CacheConfig{MaxEntries: 10, TTL: time.Minute}))
+
+	var rc *ResponseCache
+	rc.Invalidate(1)
+	te := &testEndpoint{status: http.StatusOK}
+	h := rc.Wrap("/v1/screst/sc/getblobbers", &testRoundChainer{round: 1}, te.handle)
+	serve(h, http.MethodGet, "/v1/screst/sc/getblobbers", "")
+	serve(h, http.MethodGet, "/v1/screst/sc/getblobbers", "")
+	require.Equal(t, 2, te.calls)
+
+	// the chainer does not know its round
+	rc = NewResponseCache(CacheConfig{Enabled: true, MaxEntries: 10, TTL: time.Minute})
+	h = rc.Wrap("/v1/screst/sc/getblobbers", &TestQueryChainer{}, te.handle)
+	serve(h, http.MethodGet, "/v1/screst/sc/getblobbers", "")
+	serve(h, http.MethodGet, "/v1/screst/sc/getblobbers", "")
+	require.Equal(t, 4, te.calls)
+}
@@ -56,7 +56,7 @@ func NewRestHandler(c QueryChainer) RestHandlerI {
 
 func (rh *RestHandler) Register(endpoints []Endpoint) {
 	for _, e := range endpoints {
-		http.HandleFunc(e.URI, WithCORS(e.Handler))
+		http.HandleFunc(e.URI, WithCORS(responseCache.Wrap(e.URI, rh.QueryChainer, e.Handler)))
 	}
 }
 
@@ -0,0 +1,183 @@
+package schedulersc
+
+import (
+	"0chain.net/chaincore/block"
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
+	"0chain.net/smartcontract/dbs/event"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/logging"
+	"github.com/0chain/common/core/statecache"
+	"github.com/0chain/common/core/util"
+	"go.uber.org/zap"
+)
+
+func init() {
+	logging.Logger = zap.NewNop()
+}
+
+//
+// helper for tests implements chainState.StateContextI
+//
+
+type testBalances struct {
+	block     *block.Block
+	balances  map[datastore.Key]currency.Coin
+	transfers []*state.Transfer
+	tree      map[datastore.Key]util.MPTSerializable
+	tc        *statecache.TransactionCache
+}
+
+func newTestBalances(round int64, minerID string) *testBalances {
+	bc := statecache.NewBlockCache(statecache.NewStateCache(), statecache.Block{})
+
+	b := &block.Block{}
+	b.Round = round
+	b.MinerID = minerID
+	return &testBalances{
+		block:    b,
+		balances: make(map[datastore.Key]currency.Coin),
+		tree:     make(map[datastore.Key]util.MPTSerializable),
+		tc:       statecache.NewTransactionCache(bc),
+	}
+}
+
+// revert runs f, dropping its state changes if it fails, as the chain does
+// for the failing transactions
+func (tb *testBalances) revert(f func() error) error {
+	var (
+		balances  = make(map[datastore.Key]currency.Coin, len(tb.balances))
+		tree      = make(map[datastore.Key]util.MPTSerializable, len(tb.tree))
+		transfers = len(tb.transfers)
+	)
+	for k, v := range tb.balances {
+		balances[k] = v
+	}
+	for k, v := range tb.tree {
+		tree[k] = v
+	}
+
+	err := f()
+	if err != nil {
+		tb.balances, tb.tree, tb.transfers = balances, tree, tb.transfers[:transfers]
+	}
+	return err
+}
+
+func (tb *testBalances) Cache() *statecache.TransactionCache {
+	return tb.tc
+}
+
+// stubs
+func (tb *testBalances) GetBlock() *block.Block                       { return tb.block }
+func (tb *testBalances) GetState() util.MerklePatriciaTrieI           { return nil }
+func (tb *testBalances) GetTransaction() *transaction.Transaction     { return nil }
+func (tb *testBalances) Validate() error                              { return nil }
+func (tb *testBalances) GetMints() []*state.Mint                      { return nil }
+func (tb *testBalances) SetStateContext(*state.State) error           { return nil }
+func (tb *testBalances) AddMint(*state.Mint) error                    { return nil }
+func (tb *testBalances) GetTransfers() []*state.Transfer              { return tb.transfers }
+func (tb *testBalances) GetChainCurrentMagicBlock() *block.MagicBlock { return nil }
+func (tb *testBalances) AddSignedTransfer(st *state.SignedTransfer)   {}
+func (tb *testBalances) GetEventDB() *event.EventDb                   { return nil }
+func (tb *testBalances) EmitEventWithVersion(eventVersion event.EventVersion, eventType event.EventType, tag event.EventTag, index string, data interface{}, appenders ...cstate.Appender) {
+}
+func (tb *testBalances) EmitEvent(event.EventType, event.EventTag, string, interface{}, ...cstate.Appender) {
+}
+func (tb *testBalances) EmitError(error)                             {}
+func (tb *testBalances) GetEvents() []event.Event                    { return nil }
+func (tb *testBalances) GetLatestFinalizedBlock() *block.Block       { return nil }
+func (tb *testBalances) GetMagicBlock(round int64) *block.MagicBlock { return nil }
+func (tb *testBalances) SetMagicBlock(block *block.MagicBlock)       {}
+func (tb *testBalances) GetLastestFinalizedMagicBlock() *block.Block {
+	return nil
+}
+
+func (tb *testBalances) GetSignatureScheme() encryption.SignatureScheme {
+	return encryption.NewBLS0ChainScheme()
+}
+func (tb *testBalances) GetSignedTransfers() []*state.SignedTransfer {
+	return nil
+}
+func (tb *testBalances) DeleteTrieNode(key datastore.Key) (
+	datastore.Key, error) {
+
+	delete(tb.tree, key)
+	return key, nil
+}
+
+func (tb *testBalances) GetClientBalance(clientID datastore.Key) (
+	b currency.Coin, err error) {
+
+	var ok bool
+	if b, ok = tb.balances[clientID]; !ok {
+		return 0, util.ErrValueNotPresent
+	}
+	return
+}
+
+func (tb *testBalances) GetTrieNode(key datastore.Key, v util.MPTSerializable) error {
+	nd, ok := tb.tree[key]
+	if !ok {
+		return util.ErrValueNotPresent
+	}
+
+	b, err := nd.MarshalMsg(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	_, err = v.UnmarshalMsg(b)
+	if err != nil {
+		panic(err)
+	}
+
+	return nil
+}
+
+func (tb *testBalances) InsertTrieNode(key datastore.Key,
+	node util.MPTSerializable) (_ datastore.Key, _ error) {
+
+	b, err := node.MarshalMsg(nil)
+	if err != nil {
+		panic(err)
+	}
+	tb.tree[key] = &rawNode{b}
+	return
+}
+
+func (tb *testBalances) AddTransfer(t *state.Transfer) error {
+	tb.balances[t.ClientID] -= t.Amount
+	tb.balances[t.ToClientID] += t.Amount
+	tb.transfers = append(tb.transfers, t)
+	return nil
+}
+
+func (tb *testBalances) GetInvalidStateErrors() []error { return nil }
+
+func (tb *testBalances) GetClientState(clientID datastore.Key) (*state.State, error) {
+	return nil, nil
+}
+
+func (tb *testBalances) SetClientState(clientID datastore.Key, s *state.State) (util.Key, error) {
+	return nil, nil
+}
+
+func (tb *testBalances) GetMissingNodeKeys() []util.Key { return nil }
+
+// rawNode keeps the encoded value of a node, as the MPT does
+type rawNode struct {
+	b []byte
+}
+
+func (rn *rawNode) MarshalMsg(o []byte) ([]byte, error) {
+	return append(o, rn.b...), nil
+}
+
+func (rn *rawNode) UnmarshalMsg(b []byte) ([]byte, error) {
+	rn.b = append(rn.b[:0], b...)
+	return nil, nil
+}
@@ -0,0 +1,297 @@
+package schedulersc
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	chainstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/smartcontractinterface"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	config2 "0chain.net/core/config"
+	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/util"
+)
+
+//go:generate msgp -io=false -tests=false -unexported=true -v
+
+type Setting int
+
+const (
+	MaxPending Setting = iota
+	MaxExecutionsPerRound
+	MaxDelay
+	MaxDelayRounds
+	DefaultExpiry
+	OwnerId
+	Cost
+)
+
+var (
+	Settings = []string{
+		"max_pending",
+		"max_executions_per_round",
+		"max_delay",
+		"max_delay_rounds",
+		"default_expiry",
+		"owner_id",
+		"cost",
+	}
+
+	costFunctions = []string{
+		"schedule",
+		"cancel",
+		ExecuteScheduledFuncName,
+		"schedulersc-update-settings",
+	}
+)
+
+func scConfigKey(scKey string) datastore.Key {
+	return scKey + encryption.Hash("schedulersc_config")
+}
+
+// config represents SC configurations ('schedulersc:' from sc.yaml)
+type config struct {
+	// MaxPending is the max number of calls scheduled at once.
+	MaxPending int `json:"max_pending"`
+	// MaxExecutionsPerRound is the max number of scheduled calls executed,
+	// or refunded when expired, by a block.
+	MaxExecutionsPerRound int `json:"max_executions_per_round"`
+	// MaxDelay is the max time a call can be scheduled ahead.
+	MaxDelay time.Duration `json:"max_delay"`
+	// MaxDelayRounds is the max number of rounds a call can be scheduled
+	// ahead.
+	MaxDelayRounds int64 `json:"max_delay_rounds"`
+	// DefaultExpiry is the time a call can be executed for, after its not
+	// before time, when it's scheduled without an expiry.
+	DefaultExpiry time.Duration  `json:"default_expiry"`
+	OwnerId       string         `json:"owner_id"`
+	Cost          map[string]int `json:"cost"`
+}
+
+func (c *config) validate() (err error) {
+	switch {
+	case c.MaxPending < 1:
+		return errors.New("invalid max_pending (< 1)")
+	case c.MaxExecutionsPerRound < 1:
+		return errors.New("invalid max_executions_per_round (< 1)")
+	case toSeconds(c.MaxDelay) < 1:
+		return errors.New("invalid max_delay (< 1s)")
+	case c.MaxDelayRounds < 1:
+		return errors.New("invalid max_delay_rounds (< 1)")
+	case toSeconds(c.DefaultExpiry) < 1:
+		return errors.New("invalid default_expiry (< 1s)")
+	case c.OwnerId == "":
+		return errors.New("owner_id is not set or empty")
+	}
+	return
+}
+
+func (c *config) Encode() (b []byte) {
+	var err error
+	if b, err = json.Marshal(c); err != nil {
+		panic(err) // must not happens
+	}
+	return
+}
+
+func (c *config) Decode(b []byte) error {
+	return json.Unmarshal(b, c)
+}
+
+func (c *config) update(changes *config2.StringMap) error {
+	for key, value := range changes.Fields {
+		switch key {
+		case Settings[MaxPending]:
+			if iValue, err := strconv.Atoi(value); err != nil {
+				return fmt.Errorf("value %v cannot be converted to int, "+
+					"failing to set config key %s", value, key)
+			} else {
+				c.MaxPending = iValue
+			}
+		case Settings[MaxExecutionsPerRound]:
+			if iValue, err := strconv.Atoi(value); err != nil {
+				return fmt.Errorf("value %v cannot be converted to int, "+
+					"failing to set config key %s", value, key)
+			} else {
+				c.MaxExecutionsPerRound = iValue
+			}
+		case Settings[MaxDelay]:
+			if dValue, err := time.ParseDuration(value); err != nil {
+				return fmt.Errorf("value %v cannot be converted to time.Duration, "+
+					"failing to set config key %s", value, key)
+			} else {
+				c.MaxDelay = dValue
+			}
+		case Settings[MaxDelayRounds]:
+			if iValue, err := strconv.ParseInt(value, 10, 64); err != nil {
+				return fmt.Errorf("value %v cannot be converted to int64, "+
+					"failing to set config key %s", value, key)
+			} else {
+				c.MaxDelayRounds = iValue
+			}
+		case Settings[DefaultExpiry]:
+			if dValue, err := time.ParseDuration(value); err != nil {
+				return fmt.Errorf("value %v cannot be converted to time.Duration, "+
+					"failing to set config key %s", value, key)
+			} else {
+				c.DefaultExpiry = dValue
+			}
+		case Settings[OwnerId]:
+			if _, err := hex.DecodeString(value); err != nil {
+				return fmt.Errorf("value %v cannot be converted to int with 16 base, "+
+					"failing to set config key %s", value, key)
+			} else {
+				c.OwnerId = value
+			}
+
+		default:
+			return c.setCostValue(key, value)
+		}
+	}
+	return c.validate()
+}
+
+func (c *config) setCostValue(key, value string) error {
+	if !strings.HasPrefix(key, Settings[Cost]) {
+		return fmt.Errorf("config setting %s not found", key)
+	}
+
+	costKey := strings.ToLower(strings.TrimPrefix(key, Settings[Cost]+"."))
+	for _, costFunction := range costFunctions {
+		if costKey != strings.ToLower(costFunction) {
+			continue
+		}
+		costValue, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("key %s, unable to convert %v to integer", key, value)
+		}
+
+		if costValue < 0 {
+			return fmt.Errorf("cost.%s contains invalid value %s", key, value)
+		}
+
+		c.Cost[costKey] = costValue
+
+		return nil
+	}
+
+	return fmt.Errorf("cost config setting %s not found", costKey)
+}
+
+func (c *config) getConfigMap() config2.StringMap {
+	fields := map[string]string{
+		Settings[MaxPending]:            fmt.Sprintf("%v", c.MaxPending),
+		Settings[MaxExecutionsPerRound]: fmt.Sprintf("%v", c.MaxExecutionsPerRound),
+		Settings[MaxDelay]:              fmt.Sprintf("%v", c.MaxDelay),
+		Settings[MaxDelayRounds]:        fmt.Sprintf("%v", c.MaxDelayRounds),
+		Settings[DefaultExpiry]:         fmt.Sprintf("%v", c.DefaultExpiry),
+		Settings[OwnerId]:               fmt.Sprintf("%v", c.OwnerId),
+	}
+
+	for _, key := range costFunctions {
+		fields[fmt.Sprintf("cost.%s", key)] = fmt.Sprintf("%0v", c.Cost[strings.ToLower(key)])
+	}
+
+	return config2.StringMap{
+		Fields: fields,
+	}
+}
+
+func (ssc *SchedulerSmartContract) updateConfig(
+	txn *transaction.Transaction,
+	input []byte,
+	balances chainstate.StateContextI,
+) (resp string, err error) {
+	var conf *config
+	if conf, err = ssc.getConfig(balances); err != nil {
+		return "", common.NewError("update_config",
+			"can't get config: "+err.Error())
+	}
+
+	if err := smartcontractinterface.AuthorizeWithOwner("update_config", func() bool {
+		return conf.OwnerId == txn.ClientID
+	}); err != nil {
+		return "", err
+	}
+
+	update := &config2.StringMap{}
+	if err = update.Decode(input); err != nil {
+		return "", common.NewError("update_config", err.Error())
+	}
+
+	if err := conf.update(update); err != nil {
+		return "", common.NewError("update_config", err.Error())
+	}
+
+	_, err = balances.InsertTrieNode(scConfigKey(ADDRESS), conf)
+	if err != nil {
+		return "", common.NewError("update_config", err.Error())
+	}
+
+	return "", nil
+}
+
+//
+// helpers
+//
+
+func toSeconds(dur time.Duration) common.Timestamp {
+	return common.Timestamp(dur / time.Second)
+}
+
+// configurations from sc.yaml
+func getConfiguredConfig() (conf *config, err error) {
+	const prefix = "smart_contracts.schedulersc."
+
+	conf = new(config)
+
+	// short hand
+	var scconf = config2.SmartContractConfig
+	conf.MaxPending = scconf.GetInt(prefix + "max_pending")
+	conf.MaxExecutionsPerRound = scconf.GetInt(prefix + "max_executions_per_round")
+	conf.MaxDelay = scconf.GetDuration(prefix + "max_delay")
+	conf.MaxDelayRounds = scconf.GetInt64(prefix + "max_delay_rounds")
+	conf.DefaultExpiry = scconf.GetDuration(prefix + "default_expiry")
+	conf.OwnerId = scconf.GetString(prefix + "owner_id")
+	conf.Cost = scconf.GetStringMapInt(prefix + "cost")
+
+	err = conf.validate()
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// getConfigReadOnly returns the configurations saved by update_settings, or
+// the ones from sc.yaml until then, so the SC doesn't need any state before
+// it's used.
+func getConfigReadOnly(
+	balances chainstate.CommonStateContextI,
+) (conf *config, err error) {
+	conf = new(config)
+	err = balances.GetTrieNode(scConfigKey(ADDRESS), conf)
+	switch err {
+	case nil:
+		return conf, nil
+	case util.ErrValueNotPresent:
+		if conf, err = getConfiguredConfig(); err != nil {
+			return nil, err
+		}
+		return conf, nil
+	default:
+		return nil, err
+	}
+}
+
+func (ssc *SchedulerSmartContract) getConfig(
+	balances chainstate.StateContextI,
+) (conf *config, err error) {
+	return getConfigReadOnly(balances)
+}
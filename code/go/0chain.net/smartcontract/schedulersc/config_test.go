@@ -0,0 +1,47 @@
+package schedulersc
+
+import (
+	"testing"
+	"time"
+
+	config2 "0chain.net/core/config"
+	"github.com/stretchr/testify/require"
+)
+
+func configureConfig() *config {
+	const pfx = "smart_contracts.schedulersc."
+
+	conf := newTestConfig()
+	config2.SmartContractConfig.Set(pfx+"max_pending", conf.MaxPending)
+	config2.SmartContractConfig.Set(pfx+"max_executions_per_round", conf.MaxExecutionsPerRound)
+	config2.SmartContractConfig.Set(pfx+"max_delay", conf.MaxDelay)
+	config2.SmartContractConfig.Set(pfx+"max_delay_rounds", conf.MaxDelayRounds)
+	config2.SmartContractConfig.Set(pfx+"default_expiry", conf.DefaultExpiry)
+	config2.SmartContractConfig.Set(pfx+"owner_id", conf.OwnerId)
+	config2.SmartContractConfig.Set(pfx+"cost", map[string]int{"schedule": 10})
+	conf.Cost = map[string]int{"schedule": 10}
+	return conf
+}
+
+func TestGetConfig(t *testing.T) {
+	var (
+		balances   = newTestBalances(10, minerID)
+		ssc        = NewSchedulerSmartContract().(*SchedulerSmartContract)
+		configured = configureConfig()
+	)
+
+	// the configurations of sc.yaml until the settings are updated
+	conf, err := ssc.getConfig(balances)
+	require.NoError(t, err)
+	require.Equal(t, configured, conf)
+	require.Empty(t, balances.tree, "nothing saved")
+
+	saved := newTestConfig()
+	saved.MaxDelay = 2 * time.Hour
+	_, err = balances.InsertTrieNode(scConfigKey(ADDRESS), saved)
+	require.NoError(t, err)
+
+	conf, err = ssc.getConfig(balances)
+	require.NoError(t, err)
+	require.Equal(t, saved, conf)
+}
@@ -0,0 +1,88 @@
+package schedulersc
+
+import (
+	"net/http"
+
+	"0chain.net/core/common"
+	"0chain.net/smartcontract"
+	"0chain.net/smartcontract/rest"
+)
+
+type SchedulerRestHandler struct {
+	rest.RestHandlerI
+}
+
+func NewSchedulerRestHandler(rh rest.RestHandlerI) *SchedulerRestHandler {
+	return &SchedulerRestHandler{rh}
+}
+
+func SetupRestHandler(rh rest.RestHandlerI) {
+	rh.Register(GetEndpoints(rh))
+}
+
+func GetEndpoints(rh rest.RestHandlerI) []rest.Endpoint {
+	srh := NewSchedulerRestHandler(rh)
+	scheduler := "/v1/screst/" + ADDRESS
+	return []rest.Endpoint{
+		rest.MakeEndpoint(scheduler+"/scheduled-call", common.UserRateLimit(srh.getScheduledCall)),
+		rest.MakeEndpoint(scheduler+"/scheduled-calls", common.UserRateLimit(srh.getScheduledCalls)),
+		rest.MakeEndpoint(scheduler+"/scheduler-config", common.UserRateLimit(srh.getConfig)),
+	}
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712e1/scheduled-call scheduledCall
+// get a scheduled call
+//
+// parameters:
+//
+//	+name: id
+//	 description: id of the scheduled call, the hash of the transaction scheduling it
+//	 required: true
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: scheduledCall
+//	400:
+func (srh *SchedulerRestHandler) getScheduledCall(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	sc, err := getScheduledCall(id, srh.GetQueryStateContext())
+	if err != nil {
+		common.Respond(w, r, nil, smartcontract.NewErrNoResourceOrErrInternal(err, true, "can't get scheduled call"))
+		return
+	}
+	common.Respond(w, r, sc, nil)
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712e1/scheduled-calls scheduledCalls
+// get the schedules of the pending scheduled calls
+//
+// responses:
+//
+//	200: scheduledQueue
+//	500:
+func (srh *SchedulerRestHandler) getScheduledCalls(w http.ResponseWriter, r *http.Request) {
+	sq, err := getScheduledQueue(srh.GetQueryStateContext())
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("can't get scheduled calls", err.Error()))
+		return
+	}
+	common.Respond(w, r, sq, nil)
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712e1/scheduler-config scheduler_config
+// get scheduler configuration settings
+//
+// responses:
+//
+//	200: StringMap
+//	500:
+func (srh *SchedulerRestHandler) getConfig(w http.ResponseWriter, r *http.Request) {
+	conf, err := getConfigReadOnly(srh.GetQueryStateContext())
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("can't get config", err.Error()))
+		return
+	}
+	common.Respond(w, r, conf.getConfigMap(), nil)
+}
@@ -0,0 +1,105 @@
+package schedulersc
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"0chain.net/chaincore/smartcontract"
+
+	chainstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/smartcontractinterface"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	metrics "github.com/rcrowley/go-metrics"
+)
+
+const (
+	ADDRESS = "6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712e1"
+	name    = "scheduler"
+
+	// ExecuteScheduledFuncName is the function of the built-in transactions
+	// the miners add to their blocks to execute the due scheduled calls.
+	ExecuteScheduledFuncName = "execute_scheduled"
+)
+
+// SchedulerSmartContract keeps smart contract calls escrowed by the clients
+// until their not before round and time, the block generators execute them
+// as built-in transactions.
+type SchedulerSmartContract struct {
+	*smartcontractinterface.SmartContract
+}
+
+func NewSchedulerSmartContract() smartcontractinterface.SmartContractInterface {
+	var sscCopy = &SchedulerSmartContract{
+		smartcontractinterface.NewSC(ADDRESS),
+	}
+	sscCopy.setSC(sscCopy.SmartContract, &smartcontract.BCContext{})
+	return sscCopy
+}
+
+func (ssc *SchedulerSmartContract) GetHandlerStats(ctx context.Context, params url.Values) (interface{}, error) {
+	return ssc.SmartContract.HandlerStats(ctx, params)
+}
+
+func (ssc *SchedulerSmartContract) GetExecutionStats() map[string]interface{} {
+	return ssc.SmartContractExecutionStats
+}
+
+func (ssc *SchedulerSmartContract) GetName() string {
+	return name
+}
+
+func (ssc *SchedulerSmartContract) GetAddress() string {
+	return ADDRESS
+}
+
+func (ssc *SchedulerSmartContract) GetCostTable(balances chainstate.StateContextI) (map[string]int, error) {
+	conf, err := ssc.getConfig(balances)
+	if err != nil {
+		return map[string]int{}, err
+	}
+	if conf.Cost == nil {
+		return map[string]int{}, err
+	}
+	return conf.Cost, nil
+}
+
+func (ssc *SchedulerSmartContract) setSC(sc *smartcontractinterface.SmartContract,
+	bcContext smartcontractinterface.BCContextI) {
+
+	ssc.SmartContract = sc
+
+	// schedule/cancel a call by its client
+	ssc.SmartContractExecutionStats["schedule"] = metrics.GetOrRegisterTimer(
+		fmt.Sprintf("sc:%v:func:%v", ssc.ID, "schedule"), nil)
+	ssc.SmartContractExecutionStats["cancel"] = metrics.GetOrRegisterTimer(
+		fmt.Sprintf("sc:%v:func:%v", ssc.ID, "cancel"), nil)
+
+	// execute a due call, or refund an expired one, by the block generator
+	ssc.SmartContractExecutionStats[ExecuteScheduledFuncName] = metrics.GetOrRegisterTimer(
+		fmt.Sprintf("sc:%v:func:%v", ssc.ID, ExecuteScheduledFuncName), nil)
+
+	ssc.SmartContractExecutionStats["schedulersc-update-settings"] = metrics.GetOrRegisterTimer(
+		fmt.Sprintf("sc:%v:func:%v", ssc.ID, "schedulersc-update-settings"), nil)
+}
+
+func (ssc *SchedulerSmartContract) Execute(t *transaction.Transaction,
+	function string, input []byte, balances chainstate.StateContextI) (
+	resp string, err error) {
+
+	switch function {
+	case "schedule":
+		resp, err = ssc.schedule(t, input, balances)
+	case "cancel":
+		resp, err = ssc.cancel(t, input, balances)
+	case ExecuteScheduledFuncName:
+		resp, err = ssc.executeScheduled(t, input, balances)
+	case "schedulersc-update-settings":
+		resp, err = ssc.updateConfig(t, input, balances)
+	default:
+		err = common.NewError("scheduler_sc_failed",
+			fmt.Sprintf("no function with %q name", function))
+	}
+	return
+}
@@ -0,0 +1,546 @@
+package schedulersc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	chainstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/smartcontract"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
+	"0chain.net/smartcontract/partitions"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/util"
+)
+
+//msgp:ignore scheduleRequest callRequest executeResult scheduledQueue
+//go:generate msgp -io=false -tests=false -unexported=true -v
+
+//
+// schedule a call
+//
+
+// scheduleRequest of the schedule function. The value of the transaction is
+// the value of the call plus the fee paid to the block generator executing
+// it, both escrowed until the call is executed, canceled or expired.
+type scheduleRequest struct {
+	Call transaction.MultiCall `json:"call"`
+	Fee  currency.Coin         `json:"fee"`
+	// NotBeforeRound is the first round the call can be executed at.
+	NotBeforeRound int64 `json:"not_before_round,omitempty"`
+	// NotBefore is the time the call can be executed from.
+	NotBefore common.Timestamp `json:"not_before,omitempty"`
+	// ExpiresAt is the time the call is refunded at if it isn't executed
+	// yet, default_expiry after the not before time if not set.
+	ExpiresAt common.Timestamp `json:"expires_at,omitempty"`
+}
+
+func (sr *scheduleRequest) decode(b []byte) error {
+	return json.Unmarshal(b, sr)
+}
+
+func (sr *scheduleRequest) validate(t *transaction.Transaction, round int64,
+	conf *config) (err error) {
+
+	switch {
+	case !encryption.IsHash(sr.Call.Address):
+		return errors.New("invalid smart contract address")
+	case sr.Call.Address == ADDRESS:
+		return errors.New("can't schedule a call of the scheduler")
+	case smartcontract.GetSmartContract(sr.Call.Address) == nil:
+		return fmt.Errorf("unknown smart contract %s", sr.Call.Address)
+	case sr.Call.FunctionName == "":
+		return errors.New("missing function name")
+	case sr.NotBeforeRound <= 0 && sr.NotBefore <= 0:
+		return errors.New("missing not before round or time")
+	case sr.NotBeforeRound > round+conf.MaxDelayRounds:
+		return fmt.Errorf("not before round is more than %d rounds ahead",
+			conf.MaxDelayRounds)
+	case sr.NotBefore > t.CreationDate+toSeconds(conf.MaxDelay):
+		return fmt.Errorf("not before time is more than %v ahead",
+			conf.MaxDelay)
+	case sr.ExpiresAt != 0 && sr.ExpiresAt <= sr.notBefore(t.CreationDate):
+		return errors.New("expires before the not before time")
+	}
+
+	value, err := currency.AddCoin(sr.Call.Value, sr.Fee)
+	if err != nil {
+		return err
+	}
+	if value != t.Value {
+		return fmt.Errorf("transaction value %v doesn't match the value and "+
+			"fee of the call %v", t.Value, value)
+	}
+	return nil
+}
+
+// notBefore returns the time the call can be executed from, the time it's
+// scheduled at for the calls scheduled by round only.
+func (sr *scheduleRequest) notBefore(now common.Timestamp) common.Timestamp {
+	if sr.NotBefore > now {
+		return sr.NotBefore
+	}
+	return now
+}
+
+// callRequest of the cancel and execute_scheduled functions
+type callRequest struct {
+	ID string `json:"id"`
+}
+
+func (cr *callRequest) decode(b []byte) error {
+	return json.Unmarshal(b, cr)
+}
+
+//
+// a scheduled call
+//
+
+func scheduledCallKey(sscKey, callID datastore.Key) datastore.Key {
+	return sscKey + ":scheduledcall:" + callID
+}
+
+// swagger:model scheduledCall
+type scheduledCall struct {
+	ID string `json:"id"`
+	// ClientID is the client the call is executed as
+	ClientID  string `json:"client_id"`
+	PublicKey string `json:"public_key"`
+	// the call
+	Address      string        `json:"address"`
+	FunctionName string        `json:"name"`
+	InputData    string        `json:"input"`
+	Value        currency.Coin `json:"value"`
+	// Fee is paid to the generator of the block executing the call
+	Fee            currency.Coin    `json:"fee"`
+	NotBeforeRound int64            `json:"not_before_round"`
+	NotBefore      common.Timestamp `json:"not_before"`
+	ExpiresAt      common.Timestamp `json:"expires_at"`
+}
+
+func (sc *scheduledCall) Encode() (b []byte) {
+	var err error
+	if b, err = json.Marshal(sc); err != nil {
+		panic(err) // must not happen
+	}
+	return
+}
+
+func (sc *scheduledCall) Decode(b []byte) error {
+	return json.Unmarshal(b, sc)
+}
+
+// escrow is the value and fee escrowed for the call
+func (sc *scheduledCall) escrow() (currency.Coin, error) {
+	return currency.AddCoin(sc.Value, sc.Fee)
+}
+
+// queued returns the schedule of the call
+func (sc *scheduledCall) queued() *queuedCall {
+	return &queuedCall{
+		ID:             sc.ID,
+		NotBeforeRound: sc.NotBeforeRound,
+		NotBefore:      sc.NotBefore,
+		ExpiresAt:      sc.ExpiresAt,
+	}
+}
+
+// call returns the transaction the call is executed as, on behalf of the
+// scheduling client by the t built-in transaction.
+func (sc *scheduledCall) call(t *transaction.Transaction) (
+	*transaction.Transaction, error) {
+
+	ct, err := t.CallTransaction(&transaction.MultiCall{
+		Address: sc.Address,
+		Value:   sc.Value,
+		SmartContractData: transaction.SmartContractData{
+			FunctionName: sc.FunctionName,
+			InputData:    json.RawMessage(sc.InputData),
+		},
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+	ct.ClientID = sc.ClientID
+	ct.PublicKey = sc.PublicKey
+	ct.Fee = sc.Fee
+	return ct, nil
+}
+
+func (sc *scheduledCall) save(balances chainstate.StateContextI) (err error) {
+	_, err = balances.InsertTrieNode(scheduledCallKey(ADDRESS, sc.ID), sc)
+	return
+}
+
+func getScheduledCall(
+	callID datastore.Key,
+	balances chainstate.CommonStateContextI,
+) (sc *scheduledCall, err error) {
+	sc = new(scheduledCall)
+	if err = balances.GetTrieNode(scheduledCallKey(ADDRESS, callID), sc); err != nil {
+		return nil, err
+	}
+	return
+}
+
+//
+// index of the scheduled calls
+//
+
+func scheduledQueueKey(sscKey datastore.Key) datastore.Key {
+	return sscKey + ":scheduledqueue"
+}
+
+var scheduledQueuePartitionSize = 50
+
+// queuedCall is the schedule of a call
+type queuedCall struct {
+	ID             string           `json:"id"`
+	NotBeforeRound int64            `json:"not_before_round"`
+	NotBefore      common.Timestamp `json:"not_before"`
+	ExpiresAt      common.Timestamp `json:"expires_at"`
+}
+
+// due tells if the call can be executed in the round at the time
+func (qc *queuedCall) due(round int64, now common.Timestamp) bool {
+	return round >= qc.NotBeforeRound && now >= qc.NotBefore
+}
+
+func (qc *queuedCall) expired(now common.Timestamp) bool {
+	return now >= qc.ExpiresAt
+}
+
+// GetID implements partitions.PartitionItem
+func (qc *queuedCall) GetID() string {
+	return qc.ID
+}
+
+// scheduledQueue is the list of the pending scheduled calls, read from the
+// partitions of the queue.
+// swagger:model scheduledQueue
+type scheduledQueue struct {
+	Calls []*queuedCall `json:"calls"`
+}
+
+// pick returns up to max calls to execute, or refund, in the round: the due
+// and the expired calls starting from a position rotating with the round, so
+// calls failing again and again don't hold the other calls back.
+func (sq *scheduledQueue) pick(round int64, now common.Timestamp, max int) (
+	ids []string) {
+
+	var ready []string
+	for _, qc := range sq.Calls {
+		if qc.due(round, now) || qc.expired(now) {
+			ready = append(ready, qc.ID)
+		}
+	}
+	if len(ready) <= max {
+		return ready
+	}
+
+	start := int(round % int64(len(ready)))
+	ids = make([]string, 0, max)
+	for i := 0; i < max; i++ {
+		ids = append(ids, ready[(start+i)%len(ready)])
+	}
+	return
+}
+
+func getScheduledQueuePartitions(
+	balances chainstate.StateContextI,
+) (*partitions.Partitions, error) {
+	return partitions.CreateIfNotExists(balances, scheduledQueueKey(ADDRESS),
+		scheduledQueuePartitionSize)
+}
+
+func getScheduledQueue(
+	balances chainstate.CommonStateContextI,
+) (sq *scheduledQueue, err error) {
+	sq = new(scheduledQueue)
+	parts, err := partitions.GetPartitions(balances, scheduledQueueKey(ADDRESS))
+	if err == util.ErrValueNotPresent {
+		return sq, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var decodeErr error
+	err = parts.ForEach(balances, func(_ int, _ string, data []byte) bool {
+		qc := new(queuedCall)
+		if _, decodeErr = qc.UnmarshalMsg(data); decodeErr != nil {
+			return true
+		}
+		sq.Calls = append(sq.Calls, qc)
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+	return
+}
+
+// GetDueCalls returns the ids of the scheduled calls the generator of the
+// block of the round and time should execute, or refund when expired, at
+// most max_executions_per_round of them.
+func GetDueCalls(
+	balances chainstate.CommonStateContextI,
+	round int64,
+	now common.Timestamp,
+) ([]string, error) {
+	conf, err := getConfigReadOnly(balances)
+	if err != nil {
+		return nil, err
+	}
+	sq, err := getScheduledQueue(balances)
+	if err != nil {
+		return nil, err
+	}
+	return sq.pick(round, now, conf.MaxExecutionsPerRound), nil
+}
+
+//
+// SC functions
+//
+
+func (ssc *SchedulerSmartContract) schedule(t *transaction.Transaction,
+	input []byte, balances chainstate.StateContextI) (resp string, err error) {
+
+	var sr scheduleRequest
+	if err = sr.decode(input); err != nil {
+		return "", common.NewError("schedule_failed",
+			"malformed request: "+err.Error())
+	}
+
+	var conf *config
+	if conf, err = ssc.getConfig(balances); err != nil {
+		return "", common.NewError("schedule_failed",
+			"can't get SC configurations: "+err.Error())
+	}
+
+	if err = sr.validate(t, balances.GetBlock().Round, conf); err != nil {
+		return "", common.NewError("schedule_failed",
+			"invalid request: "+err.Error())
+	}
+
+	var parts *partitions.Partitions
+	if parts, err = getScheduledQueuePartitions(balances); err != nil {
+		return "", common.NewError("schedule_failed",
+			"can't get scheduled calls: "+err.Error())
+	}
+	var pending int
+	if pending, err = parts.Size(balances); err != nil {
+		return "", common.NewError("schedule_failed",
+			"can't get scheduled calls: "+err.Error())
+	}
+	if pending >= conf.MaxPending {
+		return "", common.NewError("schedule_failed",
+			fmt.Sprintf("too many scheduled calls, max %d", conf.MaxPending))
+	}
+
+	var sc = &scheduledCall{
+		ID:             t.Hash,
+		ClientID:       t.ClientID,
+		PublicKey:      t.PublicKey,
+		Address:        sr.Call.Address,
+		FunctionName:   sr.Call.FunctionName,
+		InputData:      string(sr.Call.InputData),
+		Value:          sr.Call.Value,
+		Fee:            sr.Fee,
+		NotBeforeRound: sr.NotBeforeRound,
+		NotBefore:      sr.NotBefore,
+		ExpiresAt:      sr.ExpiresAt,
+	}
+	if sc.ExpiresAt == 0 {
+		sc.ExpiresAt = sr.notBefore(t.CreationDate) + toSeconds(conf.DefaultExpiry)
+	}
+
+	if t.Value > 0 {
+		if err = balances.AddTransfer(state.NewTransfer(t.ClientID, ADDRESS, t.Value)); err != nil {
+			return "", common.NewError("schedule_failed",
+				"can't escrow tokens: "+err.Error())
+		}
+	}
+
+	if err = parts.Add(balances, sc.queued()); err != nil {
+		return "", common.NewError("schedule_failed",
+			"can't add scheduled call: "+err.Error())
+	}
+	if err = parts.Save(balances); err != nil {
+		return "", common.NewError("schedule_failed",
+			"can't save scheduled calls: "+err.Error())
+	}
+	if err = sc.save(balances); err != nil {
+		return "", common.NewError("schedule_failed",
+			"can't save scheduled call: "+err.Error())
+	}
+
+	return string(sc.Encode()), nil
+}
+
+func (ssc *SchedulerSmartContract) cancel(t *transaction.Transaction,
+	input []byte, balances chainstate.StateContextI) (resp string, err error) {
+
+	var cr callRequest
+	if err = cr.decode(input); err != nil {
+		return "", common.NewError("cancel_scheduled_failed",
+			"malformed request: "+err.Error())
+	}
+
+	var sc *scheduledCall
+	if sc, err = getScheduledCall(cr.ID, balances); err != nil {
+		return "", common.NewError("cancel_scheduled_failed",
+			"can't get scheduled call: "+err.Error())
+	}
+
+	if sc.ClientID != t.ClientID {
+		return "", common.NewError("cancel_scheduled_failed",
+			"only the client scheduling the call can cancel it")
+	}
+
+	if err = ssc.refund(sc, balances); err != nil {
+		return "", common.NewError("cancel_scheduled_failed", err.Error())
+	}
+
+	return `{"id":"` + sc.ID + `","action":"canceled"}`, nil
+}
+
+// executeResult is the output of execute_scheduled
+type executeResult struct {
+	ID     string `json:"id"`
+	Action string `json:"action"`
+	Output string `json:"output,omitempty"`
+}
+
+// executeScheduled executes a due scheduled call on behalf of its client,
+// or refunds it when expired. It's called by the block generators only, a
+// failing call is left scheduled to be retried until it expires.
+func (ssc *SchedulerSmartContract) executeScheduled(t *transaction.Transaction,
+	input []byte, balances chainstate.StateContextI) (resp string, err error) {
+
+	var b = balances.GetBlock()
+	if t.ClientID != b.MinerID {
+		return "", common.NewError("execute_scheduled_failed",
+			"not block generator")
+	}
+
+	var cr callRequest
+	if err = cr.decode(input); err != nil {
+		return "", common.NewError("execute_scheduled_failed",
+			"malformed request: "+err.Error())
+	}
+
+	var sc *scheduledCall
+	if sc, err = getScheduledCall(cr.ID, balances); err != nil {
+		return "", common.NewError("execute_scheduled_failed",
+			"can't get scheduled call: "+err.Error())
+	}
+
+	var (
+		qc  = sc.queued()
+		res = executeResult{ID: sc.ID}
+	)
+
+	switch {
+	case qc.expired(t.CreationDate):
+		if err = ssc.refund(sc, balances); err != nil {
+			return "", common.NewError("execute_scheduled_failed", err.Error())
+		}
+		res.Action = "expired"
+	case qc.due(b.Round, t.CreationDate):
+		if res.Output, err = ssc.execute(t, sc, balances); err != nil {
+			return "", common.NewError("execute_scheduled_failed", err.Error())
+		}
+		res.Action = "executed"
+	default:
+		return "", common.NewError("execute_scheduled_failed",
+			"the call is not due")
+	}
+
+	out, err := json.Marshal(&res)
+	if err != nil {
+		return "", common.NewError("execute_scheduled_failed", err.Error())
+	}
+	return string(out), nil
+}
+
+// execute the scheduled call: the escrowed value goes back to the client
+// spending it in the call and the fee goes to the block generator.
+func (ssc *SchedulerSmartContract) execute(t *transaction.Transaction,
+	sc *scheduledCall, balances chainstate.StateContextI) (string, error) {
+
+	if err := ssc.remove(sc, balances); err != nil {
+		return "", err
+	}
+
+	if sc.Value > 0 {
+		if err := balances.AddTransfer(state.NewTransfer(ADDRESS, sc.ClientID, sc.Value)); err != nil {
+			return "", fmt.Errorf("can't release the value: %v", err)
+		}
+	}
+	if sc.Fee > 0 {
+		if err := balances.AddTransfer(state.NewTransfer(ADDRESS, t.ClientID, sc.Fee)); err != nil {
+			return "", fmt.Errorf("can't pay the fee: %v", err)
+		}
+	}
+
+	ct, err := sc.call(t)
+	if err != nil {
+		return "", err
+	}
+	output, err := smartcontract.ExecuteSmartContract(ct, balances)
+	if err != nil {
+		return "", fmt.Errorf("call %s of %s: %v", sc.FunctionName, sc.Address, err)
+	}
+	return output, nil
+}
+
+// refund the escrowed value and fee of the scheduled call to its client
+func (ssc *SchedulerSmartContract) refund(sc *scheduledCall,
+	balances chainstate.StateContextI) error {
+
+	if err := ssc.remove(sc, balances); err != nil {
+		return err
+	}
+
+	escrow, err := sc.escrow()
+	if err != nil {
+		return err
+	}
+	if escrow > 0 {
+		if err := balances.AddTransfer(state.NewTransfer(ADDRESS, sc.ClientID, escrow)); err != nil {
+			return fmt.Errorf("can't refund: %v", err)
+		}
+	}
+	return nil
+}
+
+// remove the scheduled call and its schedule
+func (ssc *SchedulerSmartContract) remove(sc *scheduledCall,
+	balances chainstate.StateContextI) error {
+
+	parts, err := getScheduledQueuePartitions(balances)
+	if err != nil {
+		return fmt.Errorf("can't get scheduled calls: %v", err)
+	}
+	err = parts.Remove(balances, sc.ID)
+	switch {
+	case err == nil:
+		if err := parts.Save(balances); err != nil {
+			return fmt.Errorf("can't save scheduled calls: %v", err)
+		}
+	case !partitions.ErrItemNotFound(err):
+		return fmt.Errorf("can't remove scheduled call: %v", err)
+	}
+	if _, err := balances.DeleteTrieNode(scheduledCallKey(ADDRESS, sc.ID)); err != nil {
+		return fmt.Errorf("can't delete scheduled call: %v", err)
+	}
+	return nil
+}
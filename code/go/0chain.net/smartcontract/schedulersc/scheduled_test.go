@@ -0,0 +1,312 @@
+package schedulersc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	chainstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/smartcontract"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/currency"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	targetAddress = encryption.Hash("scheduler test target")
+	clientID      = encryption.Hash("client")
+	minerID       = encryption.Hash("miner")
+)
+
+// targetSC records the calls and locks their value
+type targetSC struct {
+	calls []*transaction.Transaction
+}
+
+func (ts *targetSC) Execute(t *transaction.Transaction, funcName string,
+	input []byte, balances chainstate.StateContextI) (string, error) {
+
+	if funcName == "fail" {
+		return "", common.NewError("fail", "failing call")
+	}
+	ts.calls = append(ts.calls, t)
+	if t.Value > 0 {
+		if err := balances.AddTransfer(state.NewTransfer(t.ClientID, t.ToClientID, t.Value)); err != nil {
+			return "", err
+		}
+	}
+	return "done " + string(input), nil
+}
+
+func (ts *targetSC) GetHandlerStats(context.Context, url.Values) (interface{}, error) {
+	return nil, nil
+}
+func (ts *targetSC) GetExecutionStats() map[string]interface{} { return nil }
+func (ts *targetSC) GetName() string                           { return "target" }
+func (ts *targetSC) GetAddress() string                        { return targetAddress }
+func (ts *targetSC) GetCostTable(chainstate.StateContextI) (map[string]int, error) {
+	return nil, nil
+}
+
+func newTestConfig() *config {
+	return &config{
+		MaxPending:            3,
+		MaxExecutionsPerRound: 2,
+		MaxDelay:              time.Hour,
+		MaxDelayRounds:        100,
+		DefaultExpiry:         time.Minute,
+		OwnerId:               encryption.Hash("owner"),
+		Cost:                  map[string]int{},
+	}
+}
+
+func newTestSchedulerSC(t *testing.T, balances *testBalances) (
+	*SchedulerSmartContract, *targetSC) {
+
+	ts := new(targetSC)
+	smartcontract.ContractMap[targetAddress] = ts
+	t.Cleanup(func() { delete(smartcontract.ContractMap, targetAddress) })
+
+	_, err := balances.InsertTrieNode(scConfigKey(ADDRESS), newTestConfig())
+	require.NoError(t, err)
+	return NewSchedulerSmartContract().(*SchedulerSmartContract), ts
+}
+
+func scheduleTxn(t *testing.T, hash string, now common.Timestamp,
+	sr *scheduleRequest) *transaction.Transaction {
+
+	input, err := json.Marshal(sr)
+	require.NoError(t, err)
+	value, err := currency.AddCoin(sr.Call.Value, sr.Fee)
+	require.NoError(t, err)
+
+	return &transaction.Transaction{
+		HashIDField:  datastore.HashIDField{Hash: hash},
+		ClientID:     clientID,
+		ToClientID:   ADDRESS,
+		Value:        value,
+		CreationDate: now,
+		SmartContractData: &transaction.SmartContractData{
+			FunctionName: "schedule",
+			InputData:    input,
+		},
+	}
+}
+
+func executeTxn(t *testing.T, id string, from string,
+	now common.Timestamp) *transaction.Transaction {
+
+	input, err := json.Marshal(&callRequest{ID: id})
+	require.NoError(t, err)
+	return &transaction.Transaction{
+		HashIDField:  datastore.HashIDField{Hash: encryption.Hash(fmt.Sprint(id, from, now))},
+		ClientID:     from,
+		ToClientID:   ADDRESS,
+		CreationDate: now,
+		SmartContractData: &transaction.SmartContractData{
+			FunctionName: ExecuteScheduledFuncName,
+			InputData:    input,
+		},
+	}
+}
+
+func call(value currency.Coin, name string) transaction.MultiCall {
+	return transaction.MultiCall{
+		Address: targetAddress,
+		Value:   value,
+		SmartContractData: transaction.SmartContractData{
+			FunctionName: name,
+			InputData:    json.RawMessage(`{"n":1}`),
+		},
+	}
+}
+
+func execute(ssc *SchedulerSmartContract, txn *transaction.Transaction,
+	balances *testBalances) (resp string, err error) {
+
+	err = balances.revert(func() error {
+		resp, err = ssc.Execute(txn, txn.FunctionName, txn.InputData, balances)
+		return err
+	})
+	return
+}
+
+func TestScheduleRequestValidate(t *testing.T) {
+	ts := new(targetSC)
+	smartcontract.ContractMap[targetAddress] = ts
+	defer delete(smartcontract.ContractMap, targetAddress)
+
+	var (
+		conf = newTestConfig()
+		now  = common.Timestamp(1000)
+		txn  = &transaction.Transaction{CreationDate: now, Value: 15}
+	)
+
+	for _, tc := range []struct {
+		name string
+		sr   scheduleRequest
+		err  string
+	}{
+		{name: "by round", sr: scheduleRequest{Call: call(10, "f"), Fee: 5, NotBeforeRound: 20}},
+		{name: "by time", sr: scheduleRequest{Call: call(10, "f"), Fee: 5, NotBefore: now + 60, ExpiresAt: now + 61}},
+		{name: "unknown sc", sr: scheduleRequest{Call: transaction.MultiCall{Address: encryption.Hash("x"), SmartContractData: transaction.SmartContractData{FunctionName: "f"}}, Fee: 15, NotBeforeRound: 20}, err: "unknown smart contract"},
+		{name: "scheduler", sr: scheduleRequest{Call: transaction.MultiCall{Address: ADDRESS, SmartContractData: transaction.SmartContractData{FunctionName: "f"}}, Fee: 15, NotBeforeRound: 20}, err: "of the scheduler"},
+		{name: "no function", sr: scheduleRequest{Call: call(10, ""), Fee: 5, NotBeforeRound: 20}, err: "missing function name"},
+		{name: "no schedule", sr: scheduleRequest{Call: call(10, "f"), Fee: 5}, err: "missing not before"},
+		{name: "too many rounds ahead", sr: scheduleRequest{Call: call(10, "f"), Fee: 5, NotBeforeRound: 111}, err: "rounds ahead"},
+		{name: "too far ahead", sr: scheduleRequest{Call: call(10, "f"), Fee: 5, NotBefore: now + 3601}, err: "ahead"},
+		{name: "expires before", sr: scheduleRequest{Call: call(10, "f"), Fee: 5, NotBefore: now + 60, ExpiresAt: now + 60}, err: "expires before"},
+		{name: "value mismatch", sr: scheduleRequest{Call: call(10, "f"), Fee: 4, NotBeforeRound: 20}, err: "doesn't match"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.sr.validate(txn, 10, conf)
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestScheduledQueuePick(t *testing.T) {
+	sq := &scheduledQueue{Calls: []*queuedCall{
+		{ID: "a", NotBeforeRound: 10, ExpiresAt: 100},
+		{ID: "b", NotBefore: 50, ExpiresAt: 100},
+		{ID: "c", NotBeforeRound: 20, ExpiresAt: 40},
+		{ID: "d", NotBeforeRound: 5, NotBefore: 60, ExpiresAt: 100},
+	}}
+
+	require.Empty(t, sq.pick(5, 10, 5))
+	require.Equal(t, []string{"a"}, sq.pick(10, 10, 5))
+	require.Equal(t, []string{"a", "b", "c"}, sq.pick(10, 50, 5), "c expired")
+	require.Equal(t, []string{"a", "b", "c", "d"}, sq.pick(10, 60, 5))
+
+	// the start rotates with the round
+	require.Equal(t, []string{"a", "b"}, sq.pick(12, 60, 2))
+	require.Equal(t, []string{"b", "c"}, sq.pick(13, 60, 2))
+	require.Equal(t, []string{"d", "a"}, sq.pick(15, 60, 2))
+}
+
+func TestScheduleAndExecute(t *testing.T) {
+	var (
+		balances = newTestBalances(10, minerID)
+		ssc, ts  = newTestSchedulerSC(t, balances)
+		now      = common.Timestamp(1000)
+	)
+	balances.balances[clientID] = 100
+
+	txn := scheduleTxn(t, encryption.Hash("schedule"), now,
+		&scheduleRequest{Call: call(30, "lock"), Fee: 5, NotBeforeRound: 12})
+	_, err := execute(ssc, txn, balances)
+	require.NoError(t, err)
+	require.EqualValues(t, 65, balances.balances[clientID])
+	require.EqualValues(t, 35, balances.balances[ADDRESS])
+
+	sc, err := getScheduledCall(txn.Hash, balances)
+	require.NoError(t, err)
+	require.Equal(t, now+60, sc.ExpiresAt, "default expiry")
+
+	ids, err := GetDueCalls(balances, 11, now+1)
+	require.NoError(t, err)
+	require.Empty(t, ids)
+
+	_, err = execute(ssc, executeTxn(t, txn.Hash, minerID, now+1), balances)
+	require.Error(t, err, "not due")
+
+	balances.block.Round = 12
+	ids, err = GetDueCalls(balances, 12, now+1)
+	require.NoError(t, err)
+	require.Equal(t, []string{txn.Hash}, ids)
+
+	_, err = execute(ssc, executeTxn(t, txn.Hash, clientID, now+1), balances)
+	require.Error(t, err, "not the block generator")
+
+	resp, err := execute(ssc, executeTxn(t, txn.Hash, minerID, now+1), balances)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"id":"`+txn.Hash+`","action":"executed","output":"done {\"n\":1}"}`, resp)
+
+	require.Len(t, ts.calls, 1)
+	require.Equal(t, clientID, ts.calls[0].ClientID)
+	require.Equal(t, targetAddress, ts.calls[0].ToClientID)
+	require.EqualValues(t, 30, ts.calls[0].Value)
+
+	require.EqualValues(t, 65, balances.balances[clientID])
+	require.EqualValues(t, 0, balances.balances[ADDRESS])
+	require.EqualValues(t, 30, balances.balances[targetAddress])
+	require.EqualValues(t, 5, balances.balances[minerID])
+
+	_, err = getScheduledCall(txn.Hash, balances)
+	require.Error(t, err)
+	ids, err = GetDueCalls(balances, 12, now+1)
+	require.NoError(t, err)
+	require.Empty(t, ids)
+}
+
+func TestScheduleCancelAndExpire(t *testing.T) {
+	var (
+		balances = newTestBalances(10, minerID)
+		ssc, ts  = newTestSchedulerSC(t, balances)
+		now      = common.Timestamp(1000)
+		sr       = &scheduleRequest{Call: call(10, "fail"), Fee: 2, NotBefore: now + 10}
+	)
+	balances.balances[clientID] = 100
+
+	canceled := scheduleTxn(t, encryption.Hash("canceled"), now, sr)
+	_, err := execute(ssc, canceled, balances)
+	require.NoError(t, err)
+	expired := scheduleTxn(t, encryption.Hash("expired"), now, sr)
+	_, err = execute(ssc, expired, balances)
+	require.NoError(t, err)
+	require.EqualValues(t, 76, balances.balances[clientID])
+
+	input, err := json.Marshal(&callRequest{ID: canceled.Hash})
+	require.NoError(t, err)
+	cancel := &transaction.Transaction{ClientID: minerID, CreationDate: now}
+	_, err = ssc.Execute(cancel, "cancel", input, balances)
+	require.Error(t, err, "only the client can cancel")
+
+	cancel.ClientID = clientID
+	_, err = ssc.Execute(cancel, "cancel", input, balances)
+	require.NoError(t, err)
+	require.EqualValues(t, 88, balances.balances[clientID])
+
+	// the failing call is kept to be retried until it expires
+	_, err = execute(ssc, executeTxn(t, expired.Hash, minerID, now+10), balances)
+	require.Error(t, err)
+
+	resp, err := execute(ssc, executeTxn(t, expired.Hash, minerID, now+70), balances)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"id":"`+expired.Hash+`","action":"expired"}`, resp)
+	require.EqualValues(t, 100, balances.balances[clientID])
+	require.EqualValues(t, 0, balances.balances[ADDRESS])
+	require.Empty(t, ts.calls)
+
+	sq, err := getScheduledQueue(balances)
+	require.NoError(t, err)
+	require.Empty(t, sq.Calls)
+}
+
+func TestScheduleMaxPending(t *testing.T) {
+	var (
+		balances = newTestBalances(10, minerID)
+		ssc, _   = newTestSchedulerSC(t, balances)
+		sr       = &scheduleRequest{Call: call(0, "f"), NotBeforeRound: 11}
+	)
+
+	for i := 0; i < newTestConfig().MaxPending; i++ {
+		_, err := execute(ssc, scheduleTxn(t, encryption.Hash(string(rune('a'+i))), 1000, sr), balances)
+		require.NoError(t, err)
+	}
+	_, err := execute(ssc, scheduleTxn(t, encryption.Hash("z"), 1000, sr), balances)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "too many scheduled calls")
+}
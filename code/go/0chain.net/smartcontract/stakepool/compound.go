@@ -0,0 +1,124 @@
+package stakepool
+
+import (
+	"encoding/json"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
+)
+
+// compoundRewards adds the rewards of the active delegate pools with auto
+// compounding enabled to their balances, up to the max stake of a delegate
+// pool of the provider. Whatever does not fit stays as reward to be collected.
+func (sp *StakePool) compoundRewards(providerId string, providerType spenum.Provider,
+	maxStake currency.Coin, balances cstate.StateContextI) error {
+	var ids []string
+	for _, id := range sp.OrderedPoolIds() {
+		dp := sp.Pools[id]
+		if dp.AutoCompound && dp.Reward > 0 && dp.Status == spenum.Active && dp.Balance < maxStake {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return cstate.WithActivation(balances, "hermes", func() error { return nil }, func() error {
+		for _, id := range ids {
+			dp := sp.Pools[id]
+			amount := currency.Min(dp.Reward, maxStake-dp.Balance)
+			balance, err := currency.AddCoin(dp.Balance, amount)
+			if err != nil {
+				return err
+			}
+			dp.Balance = balance
+			dp.Reward -= amount
+
+			i, err := amount.Int64()
+			if err != nil {
+				return err
+			}
+			balances.EmitEvent(event.TypeStats, event.TagCompoundReward, id, event.RewardCompound{
+				Amount:       i,
+				BlockNumber:  balances.GetBlock().Round,
+				ClientID:     dp.DelegateID,
+				PoolID:       id,
+				ProviderType: providerType.String(),
+				ProviderID:   providerId,
+			})
+		}
+		return sp.EmitStakeEvent(providerType, providerId, balances)
+	})
+}
+
+// AutoCompoundRequest enables or disables reward compounding for the
+// delegate pool of the transaction client.
+type AutoCompoundRequest struct {
+	ProviderType spenum.Provider `json:"provider_type,omitempty"`
+	ProviderID   string          `json:"provider_id,omitempty"`
+	AutoCompound bool            `json:"auto_compound"`
+}
+
+func (acr *AutoCompoundRequest) Encode() []byte {
+	bytes, _ := json.Marshal(acr)
+	return bytes
+}
+
+func (acr *AutoCompoundRequest) decode(p []byte) error {
+	return json.Unmarshal(p, acr)
+}
+
+// StakePoolAutoCompound sets whether the rewards of the delegate pool of
+// the transaction client are added to its stake when distributed.
+func StakePoolAutoCompound(t *transaction.Transaction, input []byte, balances cstate.StateContextI,
+	funcs ...func(providerType spenum.Provider, providerID string, balances cstate.StateContextI) (AbstractStakePool, error),
+) (resp string, err error) {
+	if actErr := cstate.WithActivation(balances, "hermes", func() error {
+		return common.NewError("stake_pool_auto_compound_failed",
+			"auto compounding is not supported yet")
+	}, func() error { return nil }); actErr != nil {
+		return "", actErr
+	}
+
+	var acr AutoCompoundRequest
+	if err = acr.decode(input); err != nil {
+		return "", common.NewErrorf("stake_pool_auto_compound_failed",
+			"invalid request: %v", err)
+	}
+	if len(funcs) < 1 {
+		return "", common.NewError("stake_pool_auto_compound_failed",
+			"provide get func")
+	}
+	get := funcs[0]
+
+	var sp AbstractStakePool
+	if sp, err = get(acr.ProviderType, acr.ProviderID, balances); err != nil {
+		return "", common.NewErrorf("stake_pool_auto_compound_failed",
+			"can't get stake pool: %v", err)
+	}
+
+	dp, ok := sp.GetPools()[t.ClientID]
+	if !ok {
+		return "", common.NewErrorf("stake_pool_auto_compound_failed",
+			"no such delegate pool: %v ", t.ClientID)
+	}
+	if dp.AutoCompound == acr.AutoCompound {
+		return toJson(acr), nil
+	}
+	dp.AutoCompound = acr.AutoCompound
+
+	if err = sp.Save(acr.ProviderType, acr.ProviderID, balances); err != nil {
+		return "", common.NewErrorf("stake_pool_auto_compound_failed",
+			"saving stake pool: %v", err)
+	}
+
+	dpUpdate := newDelegatePoolUpdate(t.ClientID, acr.ProviderID, acr.ProviderType)
+	dpUpdate.Updates["auto_compound"] = dp.AutoCompound
+	dpUpdate.emitUpdate(balances)
+
+	return toJson(acr), nil
+}
@@ -0,0 +1,78 @@
+package stakepool
+
+import (
+	"testing"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStakePoolCompoundRewards(t *testing.T) {
+	sp := &StakePool{
+		Pools: map[string]*DelegatePool{
+			"c1": {Balance: 50, Reward: 20, DelegateID: "c1", Status: spenum.Active, AutoCompound: true},
+			"c2": {Balance: 90, Reward: 20, DelegateID: "c2", Status: spenum.Active, AutoCompound: true},
+			"c3": {Balance: 50, Reward: 20, DelegateID: "c3", Status: spenum.Active},
+			"c4": {Balance: 50, Reward: 20, DelegateID: "c4", Status: spenum.Pending, AutoCompound: true},
+		},
+	}
+	balances := newTestBalances(t, false)
+	require.NoError(t, sp.compoundRewards("b1", spenum.Blobber, 100, balances))
+
+	for id, want := range map[string]struct{ balance, reward currency.Coin }{
+		"c1": {balance: 70, reward: 0},
+		"c2": {balance: 100, reward: 10},
+		"c3": {balance: 50, reward: 20},
+		"c4": {balance: 50, reward: 20},
+	} {
+		require.Equal(t, want.balance, sp.Pools[id].Balance, id)
+		require.Equal(t, want.reward, sp.Pools[id].Reward, id)
+	}
+
+	var compounded int64
+	for _, e := range balances.events {
+		if e.Tag == event.TagCompoundReward {
+			compounded += e.Data.(event.RewardCompound).Amount
+		}
+	}
+	require.Equal(t, int64(30), compounded)
+
+	// at the max stake, rewards are kept
+	require.NoError(t, sp.compoundRewards("b1", spenum.Blobber, 100, balances))
+	require.Equal(t, currency.Coin(10), sp.Pools["c2"].Reward)
+
+	// not active before the hard fork
+	h := cstate.NewHardFork("hermes", 10)
+	_, err := balances.InsertTrieNode(h.GetKey(), h)
+	require.NoError(t, err)
+	require.NoError(t, sp.compoundRewards("b1", spenum.Blobber, 200, balances))
+	require.Equal(t, currency.Coin(10), sp.Pools["c2"].Reward)
+}
+
+func TestStakePoolAutoCompound(t *testing.T) {
+	balances := newTestBalances(t, false)
+	txn := &transaction.Transaction{ClientID: "c1", ToClientID: "sc"}
+	balances.setTransaction(t, txn)
+	pools := newRedelegateStakePools(100)
+
+	input := (&AutoCompoundRequest{
+		ProviderType: spenum.Blobber,
+		ProviderID:   "b1",
+		AutoCompound: true,
+	}).Encode()
+	_, err := StakePoolAutoCompound(txn, input, balances, getTestStakePool(pools))
+	require.NoError(t, err)
+	require.True(t, pools["b1"].Pools["c1"].AutoCompound)
+
+	input = (&AutoCompoundRequest{
+		ProviderType: spenum.Blobber,
+		ProviderID:   "b2",
+		AutoCompound: true,
+	}).Encode()
+	_, err = StakePoolAutoCompound(txn, input, balances, getTestStakePool(pools))
+	require.Error(t, err)
+}
@@ -36,6 +36,7 @@ func (dp DelegatePool) EmitNew(
 		Status:       dp.Status,
 		RoundCreated: balances.GetBlock().Round,
 		StakedAt:     dp.StakedAt,
+		AutoCompound: dp.AutoCompound,
 	}
 
 	balances.EmitEvent(
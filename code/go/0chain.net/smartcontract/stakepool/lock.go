@@ -16,6 +16,7 @@ import (
 
 	cstate "0chain.net/chaincore/chain/state"
 	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
 	"0chain.net/core/datastore"
 )
 
@@ -64,64 +65,97 @@ func (sp *StakePool) LockPool(
 		return "", err
 	}
 
-	var newPoolId = txn.ClientID
-	dp, ok := sp.Pools[newPoolId]
+	lock, err := sp.addToPool(txn.ClientID, txn.Value, txn.CreationDate, providerType, providerId, status, balances)
+	if err != nil {
+		return "", err
+	}
+	logging.Logger.Info("emmit TagLockStakePool", zap.String("client_id", txn.ClientID), zap.String("provider_id", providerId))
+	balances.EmitEvent(event.TypeStats, event.TagLockStakePool, txn.ClientID, lock)
+
+	if err := balances.AddTransfer(state.NewTransfer(
+		txn.ClientID, txn.ToClientID, txn.Value,
+	)); err != nil {
+		return "", err
+	}
+
+	return toJson(lock), nil
+}
+
+// LockRedelegated adds stake moved from another provider of the same smart
+// contract to the delegate pool of the transaction client. Unlike LockPool
+// no tokens are transferred, as they are already held by the smart contract.
+func (sp *StakePool) LockRedelegated(
+	txn *transaction.Transaction,
+	amount currency.Coin,
+	providerType spenum.Provider,
+	providerId datastore.Key,
+	balances cstate.StateContextI,
+) (string, error) {
+	lock, err := sp.addToPool(txn.ClientID, amount, txn.CreationDate, providerType, providerId, spenum.Active, balances)
+	if err != nil {
+		return "", err
+	}
+	balances.EmitEvent(event.TypeStats, event.TagLockStakePool, txn.ClientID, lock)
+	return toJson(lock), nil
+}
+
+// addToPool adds the amount to the delegate pool of the client, creating
+// the pool if needed.
+func (sp *StakePool) addToPool(
+	clientID string,
+	amount currency.Coin,
+	stakedAt common.Timestamp,
+	providerType spenum.Provider,
+	providerId datastore.Key,
+	status spenum.PoolStatus,
+	balances cstate.StateContextI,
+) (event.DelegatePoolLock, error) {
+	dp, ok := sp.Pools[clientID]
 	if !ok {
 		// new stake
 		dp = &DelegatePool{
-			Balance:      txn.Value,
+			Balance:      amount,
 			Reward:       0,
 			Status:       status,
-			DelegateID:   txn.ClientID,
+			DelegateID:   clientID,
 			RoundCreated: balances.GetBlock().Round,
-			StakedAt:     txn.CreationDate,
+			StakedAt:     stakedAt,
 		}
-		sp.Pools[newPoolId] = dp
-		dp.EmitNew(newPoolId, providerId, providerType, balances)
+		sp.Pools[clientID] = dp
+		dp.EmitNew(clientID, providerId, providerType, balances)
 	} else {
 		// stake from the same clients
-		if dp.DelegateID != txn.ClientID {
-			return "", fmt.Errorf("could not stake for different delegate id: %s, txn client id: %s", dp.DelegateID, txn.ClientID)
+		if dp.DelegateID != clientID {
+			return event.DelegatePoolLock{}, fmt.Errorf("could not stake for different delegate id: %s, txn client id: %s", dp.DelegateID, clientID)
 		}
 
 		//  check status, only allow staking more when current pool is active
 		if dp.Status != spenum.Active && dp.Status != spenum.Pending {
-			return "", fmt.Errorf("could not stake pool in %s status", dp.Status)
+			return event.DelegatePoolLock{}, fmt.Errorf("could not stake pool in %s status", dp.Status)
 		}
 
-		b, err := currency.AddCoin(dp.Balance, txn.Value)
+		b, err := currency.AddCoin(dp.Balance, amount)
 		if err != nil {
-			return "", err
+			return event.DelegatePoolLock{}, err
 		}
 
 		dp.Balance = b
-		dp.StakedAt = txn.CreationDate
+		dp.StakedAt = stakedAt
 
-		update := newDelegatePoolUpdate(newPoolId, providerId, providerType)
+		update := newDelegatePoolUpdate(clientID, providerId, providerType)
 		update.Updates["balance"] = dp.Balance
 		update.emitUpdate(balances)
 	}
 
-	i, _ := txn.Value.Int64()
-	logging.Logger.Info("emmit TagLockStakePool", zap.String("client_id", txn.ClientID), zap.String("provider_id", providerId))
-
-	lock := event.DelegatePoolLock{
-		Client:       txn.ClientID,
+	i, _ := amount.Int64()
+	return event.DelegatePoolLock{
+		Client:       clientID,
 		ProviderId:   providerId,
 		ProviderType: providerType,
 		Amount:       i,
 		Reward:       currency.Coin(0),
 		Total:        i,
-	}
-	balances.EmitEvent(event.TypeStats, event.TagLockStakePool, newPoolId, lock)
-
-	if err := balances.AddTransfer(state.NewTransfer(
-		txn.ClientID, txn.ToClientID, txn.Value,
-	)); err != nil {
-		return "", err
-	}
-
-	return toJson(lock), nil
+	}, nil
 }
 
 func (sp *StakePool) EmitStakeEvent(providerType spenum.Provider, providerID string, balances cstate.StateContextI) error {
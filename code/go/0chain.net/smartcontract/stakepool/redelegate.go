@@ -0,0 +1,152 @@
+package stakepool
+
+import (
+	"encoding/json"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
+)
+
+// RedelegateRequest moves stake of a delegate from one provider to another
+// provider of the same type.
+type RedelegateRequest struct {
+	ProviderType   spenum.Provider `json:"provider_type,omitempty"`
+	FromProviderID string          `json:"from_provider_id"`
+	ToProviderID   string          `json:"to_provider_id"`
+	// Amount to move, the whole delegate pool is moved if not set.
+	Amount currency.Coin `json:"amount,omitempty"`
+}
+
+func (rr *RedelegateRequest) Encode() []byte {
+	bytes, _ := json.Marshal(rr)
+	return bytes
+}
+
+func (rr *RedelegateRequest) decode(p []byte) error {
+	return json.Unmarshal(p, rr)
+}
+
+// StakePoolRedelegate moves stake of the transaction client between two
+// providers in a single transaction, without the tokens leaving the smart
+// contract. The source pool must be out of its min lock period, the
+// destination pool is validated as a lock of the same amount and its lock
+// period restarts. Pending rewards stay in the source pool unless it is
+// moved as a whole, in which case they are minted to the client. Available
+// after hermes.
+func StakePoolRedelegate(t *transaction.Transaction, input []byte, balances cstate.StateContextI, vs ValidationSettings,
+	funcs ...func(providerType spenum.Provider, providerID string, balances cstate.StateContextI) (AbstractStakePool, error),
+) (resp string, err error) {
+	if err := cstate.WithActivation(balances, "hermes", func() error {
+		return common.NewError("redelegate_failed", "redelegation is not enabled yet")
+	}, func() error {
+		return nil
+	}); err != nil {
+		return "", err
+	}
+
+	var rr RedelegateRequest
+	if err = rr.decode(input); err != nil {
+		return "", common.NewErrorf("redelegate_failed",
+			"invalid request: %v", err)
+	}
+	if rr.FromProviderID == "" || rr.ToProviderID == "" {
+		return "", common.NewError("redelegate_failed",
+			"missing source or destination provider")
+	}
+	if rr.FromProviderID == rr.ToProviderID {
+		return "", common.NewError("redelegate_failed",
+			"source and destination providers are the same")
+	}
+	if len(funcs) < 1 {
+		return "", common.NewError("redelegate_failed",
+			"provide get func")
+	}
+	get := funcs[0]
+
+	var from, to AbstractStakePool
+	if from, err = get(rr.ProviderType, rr.FromProviderID, balances); err != nil {
+		return "", common.NewErrorf("redelegate_failed",
+			"can't get source stake pool: %v", err)
+	}
+	if to, err = get(rr.ProviderType, rr.ToProviderID, balances); err != nil {
+		return "", common.NewErrorf("redelegate_failed",
+			"can't get destination stake pool: %v", err)
+	}
+	if to.IsDead() {
+		return "", common.NewError("redelegate_failed",
+			"destination provider is killed")
+	}
+
+	dp, ok := from.GetPools()[t.ClientID]
+	if !ok {
+		return "", common.NewErrorf("redelegate_failed", "no such delegate pool: %v ", t.ClientID)
+	}
+	if err := checkMinLockPeriod("redelegate_failed", dp); err != nil {
+		return "", err
+	}
+
+	amount := rr.Amount
+	if amount == 0 {
+		amount = dp.Balance
+	}
+	if amount > dp.Balance {
+		return "", common.NewErrorf("redelegate_failed",
+			"amount %v is greater than stake %v", amount, dp.Balance)
+	}
+	if err := validateStake("redelegate_failed", t.ClientID, amount, to, vs, balances); err != nil {
+		return "", err
+	}
+
+	if amount < dp.Balance {
+		if _, err = from.UnlockPartial(t.ClientID, amount, rr.ProviderType, rr.FromProviderID, balances); err != nil {
+			return "", common.NewErrorf("redelegate_failed", "%v", err)
+		}
+	} else {
+		if _, err = from.UnlockPool(t.ClientID, rr.ProviderType, rr.FromProviderID, balances); err != nil {
+			return "", common.NewErrorf("redelegate_failed", "%v", err)
+		}
+		dp.Balance = 0
+		dp.Status = spenum.Deleted
+		if err = from.DeletePool(t.ClientID, rr.ProviderType, rr.FromProviderID, balances); err != nil {
+			return "", common.NewErrorf("redelegate_failed",
+				"deleting stake pool: %v", err)
+		}
+	}
+
+	out, err := to.LockRedelegated(t, amount, rr.ProviderType, rr.ToProviderID, balances)
+	if err != nil {
+		return "", common.NewErrorf("redelegate_failed",
+			"stake pool digging error: %v", err)
+	}
+
+	for _, p := range []struct {
+		id string
+		sp AbstractStakePool
+	}{
+		{id: rr.FromProviderID, sp: from},
+		{id: rr.ToProviderID, sp: to},
+	} {
+		if err = p.sp.Save(rr.ProviderType, p.id, balances); err != nil {
+			return "", common.NewErrorf("redelegate_failed",
+				"saving stake pool: %v", err)
+		}
+
+		if err = p.sp.EmitStakeEvent(rr.ProviderType, p.id, balances); err != nil {
+			return "", common.NewErrorf("redelegate_failed",
+				"stake pool staking error: %v", err)
+		}
+
+		if len(funcs) > 1 {
+			refresh := funcs[1]
+			if _, err = refresh(rr.ProviderType, p.id, balances); err != nil {
+				return "", common.NewErrorf("redelegate_failed",
+					"can't refresh provider: %v", err)
+			}
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,154 @@
+package stakepool
+
+import (
+	"testing"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
+	"github.com/stretchr/testify/require"
+)
+
+func newRedelegateStakePools(balance currency.Coin) map[string]*StakePool {
+	settings := Settings{MaxNumDelegates: 2, MinStake: 10}
+	return map[string]*StakePool{
+		"b1": {
+			Pools: map[string]*DelegatePool{
+				"c1": {Balance: balance, DelegateID: "c1", Status: spenum.Active},
+			},
+			Settings: settings,
+		},
+		"b2": {
+			Pools: map[string]*DelegatePool{
+				"c2": {Balance: 50, DelegateID: "c2", Status: spenum.Active},
+			},
+			Settings: settings,
+		},
+	}
+}
+
+func getTestStakePool(pools map[string]*StakePool) func(spenum.Provider, string, cstate.StateContextI) (AbstractStakePool, error) {
+	return func(_ spenum.Provider, id string, _ cstate.StateContextI) (AbstractStakePool, error) {
+		return pools[id], nil
+	}
+}
+
+func TestStakePoolUnlock_Partial(t *testing.T) {
+	tests := []struct {
+		name        string
+		amount      currency.Coin
+		wantBalance currency.Coin
+		hermes      int64
+		wantPool    bool
+		wantErr     bool
+	}{
+		{name: "partial", amount: 30, wantBalance: 70, wantPool: true},
+		{name: "below min stake", amount: 95, wantErr: true},
+		{name: "whole pool", amount: 100, wantPool: false},
+		{name: "no amount", amount: 0, wantPool: false},
+		{name: "more than staked", amount: 101, wantErr: true},
+		{name: "before hermes", amount: 30, hermes: 10, wantPool: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			balances := newTestBalances(t, false)
+			txn := &transaction.Transaction{ClientID: "c1", ToClientID: "sc"}
+			balances.setTransaction(t, txn)
+			balances.balances["sc"] = 150
+			h := cstate.NewHardFork("hermes", tt.hermes)
+			_, err := balances.InsertTrieNode(h.GetKey(), h)
+			require.NoError(t, err)
+			pools := newRedelegateStakePools(100)
+
+			input := (&StakePoolRequest{
+				ProviderType: spenum.Blobber,
+				ProviderID:   "b1",
+				Amount:       tt.amount,
+			}).Encode()
+			_, err = StakePoolUnlock(txn, input, balances, getTestStakePool(pools))
+			if tt.wantErr {
+				require.Error(t, err)
+				require.Equal(t, currency.Coin(100), pools["b1"].Pools["c1"].Balance)
+				return
+			}
+			require.NoError(t, err)
+
+			dp, ok := pools["b1"].Pools["c1"]
+			require.Equal(t, tt.wantPool, ok)
+			if ok {
+				require.Equal(t, tt.wantBalance, dp.Balance)
+				require.Equal(t, tt.amount, balances.balances["c1"])
+			} else {
+				require.Equal(t, currency.Coin(100), balances.balances["c1"])
+			}
+		})
+	}
+}
+
+func TestStakePoolRedelegate(t *testing.T) {
+	vs := ValidationSettings{MinStake: 10, MaxStake: 1000, MaxNumDelegates: 2}
+	tests := []struct {
+		name     string
+		from, to string
+		amount   currency.Coin
+		wantFrom currency.Coin
+		wantTo   currency.Coin
+		hermes   int64
+		wantErr  bool
+	}{
+		{name: "partial", from: "b1", to: "b2", amount: 40, wantFrom: 60, wantTo: 40},
+		{name: "whole pool", from: "b1", to: "b2", wantTo: 100},
+		{name: "remaining below min stake", from: "b1", to: "b2", amount: 95, wantErr: true},
+		{name: "more than staked", from: "b1", to: "b2", amount: 101, wantErr: true},
+		{name: "same provider", from: "b1", to: "b1", amount: 40, wantErr: true},
+		{name: "below destination min stake", from: "b1", to: "b2", amount: 5, wantErr: true},
+		{name: "before hermes", from: "b1", to: "b2", amount: 40, hermes: 10, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			balances := newTestBalances(t, false)
+			txn := &transaction.Transaction{ClientID: "c1", ToClientID: "sc"}
+			balances.setTransaction(t, txn)
+			h := cstate.NewHardFork("hermes", tt.hermes)
+			_, err := balances.InsertTrieNode(h.GetKey(), h)
+			require.NoError(t, err)
+			pools := newRedelegateStakePools(100)
+
+			input := (&RedelegateRequest{
+				ProviderType:   spenum.Blobber,
+				FromProviderID: tt.from,
+				ToProviderID:   tt.to,
+				Amount:         tt.amount,
+			}).Encode()
+			_, err = StakePoolRedelegate(txn, input, balances, vs, getTestStakePool(pools))
+			if tt.wantErr {
+				require.Error(t, err)
+				require.Equal(t, currency.Coin(100), pools["b1"].Pools["c1"].Balance)
+				return
+			}
+			require.NoError(t, err)
+			require.Empty(t, balances.transfers)
+
+			if tt.wantFrom > 0 {
+				require.Equal(t, tt.wantFrom, pools["b1"].Pools["c1"].Balance)
+			} else {
+				require.NotContains(t, pools["b1"].Pools, "c1")
+			}
+			require.Equal(t, tt.wantTo, pools["b2"].Pools["c1"].Balance)
+
+			var unlocked, locked bool
+			for _, e := range balances.events {
+				switch e.Tag {
+				case event.TagUnlockStakePool:
+					unlocked = true
+				case event.TagAddDelegatePool:
+					locked = true
+				}
+			}
+			require.True(t, unlocked)
+			require.True(t, locked)
+		})
+	}
+}
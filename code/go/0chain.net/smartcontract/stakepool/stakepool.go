@@ -43,6 +43,8 @@ type AbstractStakePool interface {
 	GetSettings() Settings
 	Empty(sscID, poolID, clientID string, balances cstate.StateContextI) error
 	UnlockPool(clientID string, providerType spenum.Provider, providerId datastore.Key, balances cstate.StateContextI) (string, error)
+	UnlockPartial(clientID string, amount currency.Coin, providerType spenum.Provider, providerId datastore.Key, balances cstate.StateContextI) (string, error)
+	LockRedelegated(txn *transaction.Transaction, amount currency.Coin, providerType spenum.Provider, providerId datastore.Key, balances cstate.StateContextI) (string, error)
 	DeletePool(clientID string, providerType spenum.Provider, providerId datastore.Key, balances cstate.StateContextI) error
 	Kill(float64, string, spenum.Provider, cstate.StateContextI) error
 	IsDead() bool
@@ -73,6 +75,7 @@ type DelegatePool struct {
 	RoundCreated int64             `json:"round_created"` // used for cool down
 	DelegateID   string            `json:"delegate_id"`
 	StakedAt     common.Timestamp  `json:"staked_at"`
+	AutoCompound bool              `json:"auto_compound,omitempty" msg:"AutoCompound,omitempty"` // add rewards to the balance on distribution
 }
 
 // StakePoolStat Deprecated
@@ -391,7 +394,8 @@ func (sp *StakePool) TotalStake() (currency.Coin, error) {
 	return total, nil
 }
 
-// DistributeRewardsRandN distributes rewards to randomly selected N delegate pools
+// DistributeRewardsRandN distributes rewards to randomly selected N delegate pools,
+// the delegate pools compounding their rewards are staked up to maxStake
 func (sp *StakePool) DistributeRewardsRandN(
 	value currency.Coin,
 	providerId string,
@@ -399,6 +403,7 @@ func (sp *StakePool) DistributeRewardsRandN(
 	seed int64,
 	randN int,
 	rewardType spenum.Reward,
+	maxStake currency.Coin,
 	balances cstate.StateContextI,
 ) (err error) {
 	total, err := sp.stake()
@@ -495,7 +500,7 @@ func (sp *StakePool) DistributeRewardsRandN(
 	if err := spUpdate.Emit(event.TagStakePoolReward, balances); err != nil {
 		return err
 	}
-	return nil
+	return sp.compoundRewards(providerId, providerType, maxStake, balances)
 }
 
 func (sp *StakePool) getRandPools(balances cstate.StateContextI, seed int64, n int) []*DelegatePool {
@@ -553,11 +558,14 @@ func (sp *StakePool) getRandStakePools(balances cstate.StateContextI, seed int64
 	return stake, pools, nil
 }
 
+// DistributeRewards distributes rewards to the delegate pools by their stake,
+// the delegate pools compounding their rewards are staked up to maxStake
 func (sp *StakePool) DistributeRewards(
 	value currency.Coin,
 	providerId string,
 	providerType spenum.Provider,
 	rewardType spenum.Reward,
+	maxStake currency.Coin,
 	balances cstate.StateContextI,
 	options ...string,
 ) (err error) {
@@ -678,7 +686,7 @@ func (sp *StakePool) DistributeRewards(
 		return err
 	}
 
-	return nil
+	return sp.compoundRewards(providerId, providerType, maxStake, balances)
 }
 
 func (sp *StakePool) stake() (stake currency.Coin, err error) {
@@ -746,6 +754,9 @@ func equallyDistributeRewards(coins currency.Coin, pools []*DelegatePool, spUpda
 type StakePoolRequest struct {
 	ProviderType spenum.Provider `json:"provider_type,omitempty"`
 	ProviderID   string          `json:"provider_id,omitempty"`
+	// Amount to unlock, the whole delegate pool is unlocked if not set.
+	// Locks use the transaction value instead.
+	Amount currency.Coin `json:"amount,omitempty"`
 }
 
 func (spr *StakePoolRequest) Encode() []byte {
@@ -818,32 +829,38 @@ type ValidationSettings struct {
 }
 
 func validateLockRequest(t *transaction.Transaction, sp AbstractStakePool, vs ValidationSettings, balances cstate.StateContextI) (string, error) {
-	if t.Value == 0 {
-		return "", common.NewError("stake_pool_lock_failed",
-			fmt.Sprintf("no stake to lock: %v", t.Value))
+	return "", validateStake("stake_pool_lock_failed", t.ClientID, t.Value, sp, vs, balances)
+}
+
+// validateStake checks that the client can add the amount to its delegate
+// pool of the given stake pool.
+func validateStake(code, clientID string, amount currency.Coin, sp AbstractStakePool, vs ValidationSettings, balances cstate.StateContextI) error {
+	if amount == 0 {
+		return common.NewError(code,
+			fmt.Sprintf("no stake to lock: %v", amount))
 	}
-	if t.Value < vs.MinStake {
-		return "", common.NewError("stake_pool_lock_failed",
-			fmt.Sprintf("too small stake to lock: %v < %v", t.Value, vs.MinStake))
+	if amount < vs.MinStake {
+		return common.NewError(code,
+			fmt.Sprintf("too small stake to lock: %v < %v", amount, vs.MinStake))
 	}
 	poolStakeBefore := currency.Coin(0)
-	pool, ok := sp.GetPools()[t.ClientID]
+	pool, ok := sp.GetPools()[clientID]
 	if ok {
 		poolStakeBefore = pool.Balance
 	}
-	poolStakeAfter, err := currency.AddCoin(poolStakeBefore, t.Value)
+	poolStakeAfter, err := currency.AddCoin(poolStakeBefore, amount)
 	if err != nil {
-		return "", common.NewError("stake_pool_lock_failed", err.Error())
+		return common.NewError(code, err.Error())
 	}
 
 	if poolStakeAfter > vs.MaxStake {
-		return "", common.NewError("stake_pool_lock_failed",
+		return common.NewError(code,
 			fmt.Sprintf("too large stake to lock: %v > %v", poolStakeAfter, vs.MaxStake))
 	}
 
 	beforeFunc := func() (e error) {
-		if len(sp.GetPools()) >= vs.MaxNumDelegates && !sp.HasStakePool(t.ClientID) {
-			e = common.NewErrorf("stake_pool_lock_failed",
+		if len(sp.GetPools()) >= vs.MaxNumDelegates && !sp.HasStakePool(clientID) {
+			e = common.NewErrorf(code,
 				"max_delegates reached: %v, no more stake pools allowed",
 				vs.MaxNumDelegates)
 		}
@@ -851,23 +868,34 @@ func validateLockRequest(t *transaction.Transaction, sp AbstractStakePool, vs Va
 	}
 
 	afterFunc := func() (e error) {
-		if len(sp.GetPools()) >= sp.GetSettings().MaxNumDelegates && !sp.HasStakePool(t.ClientID) {
-			e = common.NewErrorf("stake_pool_lock_failed",
+		if len(sp.GetPools()) >= sp.GetSettings().MaxNumDelegates && !sp.HasStakePool(clientID) {
+			e = common.NewErrorf(code,
 				"max_delegates reached: %v, no more stake pools allowed",
 				vs.MaxNumDelegates)
 		}
 		return e
 	}
 
-	actError := cstate.WithActivation(balances, "apollo", beforeFunc, afterFunc)
-	if actError != nil {
-		return "", actError
-	}
+	return cstate.WithActivation(balances, "apollo", beforeFunc, afterFunc)
+}
 
-	return "", err
+// checkMinLockPeriod returns an error if the delegate pool was staked less
+// than the stake pool min lock period ago.
+func checkMinLockPeriod(code string, dp *DelegatePool) error {
+	// if StakeAt has valid value and lock period is less than MinLockPeriod
+	if dp.StakedAt > 0 {
+		stakedAt := common.ToTime(dp.StakedAt)
+		minLockPeriod := config.SmartContractConfig.GetDuration("stakepool.min_lock_period")
+		if !stakedAt.Add(minLockPeriod).Before(time.Now()) {
+			return common.NewErrorf(code, "token can only be unstaked till: %s", stakedAt.Add(minLockPeriod))
+		}
+	}
+	return nil
 }
 
-// StakePoolUnlock unlock tokens from provider, stake pool can return excess tokens from stake pool
+// StakePoolUnlock unlock tokens from provider, stake pool can return excess tokens from stake pool.
+// After hermes, if the request amount is less than the delegate pool balance
+// only that amount is unlocked and the rest stays staked.
 func StakePoolUnlock(t *transaction.Transaction, input []byte, balances cstate.StateContextI,
 	funcs ...func(providerType spenum.Provider, providerID string, balances cstate.StateContextI) (AbstractStakePool, error),
 ) (resp string, err error) {
@@ -892,30 +920,53 @@ func StakePoolUnlock(t *transaction.Transaction, input []byte, balances cstate.S
 		return "", common.NewErrorf("stake_pool_unlock_failed", "no such delegate pool: %v ", t.ClientID)
 	}
 
-	// if StakeAt has valid value and lock period is less than MinLockPeriod
-	if dp.StakedAt > 0 {
-		stakedAt := common.ToTime(dp.StakedAt)
-		minLockPeriod := config.SmartContractConfig.GetDuration("stakepool.min_lock_period")
-		if !stakedAt.Add(minLockPeriod).Before(time.Now()) {
-			return "", common.NewErrorf("stake_pool_unlock_failed", "token can only be unstaked till: %s", stakedAt.Add(minLockPeriod))
-		}
+	if err := checkMinLockPeriod("stake_pool_unlock_failed", dp); err != nil {
+		return "", err
 	}
 
-	output, err := sp.UnlockPool(t.ClientID, spr.ProviderType, spr.ProviderID, balances)
-	if err != nil {
-		return "", common.NewErrorf("stake_pool_unlock_failed", "%v", err)
+	// the request amount is ignored before hermes, the whole pool is unlocked
+	var partial bool
+	if err := cstate.WithActivation(balances, "hermes", func() error {
+		return nil
+	}, func() error {
+		if spr.Amount > dp.Balance {
+			return common.NewErrorf("stake_pool_unlock_failed",
+				"amount %v is greater than stake %v", spr.Amount, dp.Balance)
+		}
+		partial = spr.Amount > 0 && spr.Amount < dp.Balance
+		return nil
+	}); err != nil {
+		return "", err
 	}
 
-	err = sp.Empty(t.ToClientID, t.ClientID, t.ClientID, balances)
-	if err != nil {
-		return "", common.NewErrorf("stake_pool_unlock_failed",
-			"unlocking tokens: %v", err)
-	}
+	var output string
+	if partial {
+		output, err = sp.UnlockPartial(t.ClientID, spr.Amount, spr.ProviderType, spr.ProviderID, balances)
+		if err != nil {
+			return "", common.NewErrorf("stake_pool_unlock_failed", "%v", err)
+		}
 
-	err = sp.DeletePool(t.ClientID, spr.ProviderType, spr.ProviderID, balances)
-	if err != nil {
-		return "", common.NewErrorf("stake_pool_unlock_failed",
-			"deleting stake pool: %v", err)
+		if err = balances.AddTransfer(state.NewTransfer(t.ToClientID, t.ClientID, spr.Amount)); err != nil {
+			return "", common.NewErrorf("stake_pool_unlock_failed",
+				"unlocking tokens: %v", err)
+		}
+	} else {
+		output, err = sp.UnlockPool(t.ClientID, spr.ProviderType, spr.ProviderID, balances)
+		if err != nil {
+			return "", common.NewErrorf("stake_pool_unlock_failed", "%v", err)
+		}
+
+		err = sp.Empty(t.ToClientID, t.ClientID, t.ClientID, balances)
+		if err != nil {
+			return "", common.NewErrorf("stake_pool_unlock_failed",
+				"unlocking tokens: %v", err)
+		}
+
+		err = sp.DeletePool(t.ClientID, spr.ProviderType, spr.ProviderID, balances)
+		if err != nil {
+			return "", common.NewErrorf("stake_pool_unlock_failed",
+				"deleting stake pool: %v", err)
+		}
 	}
 
 	// Save the pool
@@ -251,7 +251,7 @@ func TestStakePool_DistributeRewards(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			sp, balances, DelegateRewards := setup(t, tt.args, tt.want)
-			err := sp.DistributeRewards(tt.args.value, providerID, providerType, spenum.BlockRewardBlobber, balances)
+			err := sp.DistributeRewards(tt.args.value, providerID, providerType, spenum.BlockRewardBlobber, 0, balances)
 			require.EqualValues(t, tt.want.err, err != nil)
 			if err != nil {
 				require.EqualValues(t, tt.want.errMsg, err.Error())
@@ -479,7 +479,7 @@ func TestStakePool_DistributeRewardsRandN(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			sp, balances := setup(t, tt.args)
-			err := sp.DistributeRewardsRandN(tt.args.value, providerID, providerType, RoundRandomSeed, NumMinerDelegatesRewarded, spenum.BlockRewardBlobber, balances)
+			err := sp.DistributeRewardsRandN(tt.args.value, providerID, providerType, RoundRandomSeed, NumMinerDelegatesRewarded, spenum.BlockRewardBlobber, 0, balances)
 			require.EqualValues(t, tt.want.err, err != nil)
 			if err != nil {
 				require.EqualValues(t, tt.want.errMsg, err.Error())
@@ -620,7 +620,7 @@ func TestStakePool_DistributeRewardsRandN_Randomness(t *testing.T) {
 
 			for i := 0; i < 10000; i++ {
 				RoundRandomSeed = time.Now().UnixNano()
-				err := sp.DistributeRewardsRandN(tt.args.value, providerID, providerType, RoundRandomSeed, 1, spenum.BlockRewardBlobber, balances)
+				err := sp.DistributeRewardsRandN(tt.args.value, providerID, providerType, RoundRandomSeed, 1, spenum.BlockRewardBlobber, 0, balances)
 				require.NoError(t, err)
 			}
 			validate(t, sp, tt.args)
@@ -0,0 +1,23 @@
+package stakepool
+
+import (
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/smartcontract/supply"
+)
+
+// SupplyEntries returns the stake and the unpaid rewards of the stake pool,
+// they are all held by its minter.
+func (sp *StakePool) SupplyEntries() []supply.Entry {
+	minter, err := cstate.GetMinter(sp.Minter)
+	if err != nil {
+		return nil
+	}
+	entries := []supply.Entry{{Contract: minter, Category: supply.ServiceCharges, Amount: sp.Reward}}
+	for _, dp := range sp.Pools {
+		entries = append(entries,
+			supply.Entry{Contract: minter, Category: supply.Stake, Amount: dp.Balance},
+			supply.Entry{Contract: minter, Category: supply.DelegateRewards, Amount: dp.Reward},
+		)
+	}
+	return entries
+}
@@ -3,6 +3,8 @@ package stakepool
 import (
 	"fmt"
 
+	"github.com/0chain/common/core/currency"
+
 	"0chain.net/smartcontract/dbs/event"
 
 	"0chain.net/smartcontract/stakepool/spenum"
@@ -43,6 +45,49 @@ func (sp *StakePool) UnlockPool(clientID string, providerType spenum.Provider, p
 	return toJson(lock), nil
 }
 
+// UnlockPartial unlocks the given amount from the delegate pool of the
+// client and leaves the rest staked, together with its pending rewards.
+// The remaining balance must not drop below the minimum stake of the pool.
+// The unlocked tokens are not transferred here.
+func (sp *StakePool) UnlockPartial(clientID string, amount currency.Coin, providerType spenum.Provider,
+	providerId datastore.Key, balances cstate.StateContextI) (string, error) {
+	dp, ok := sp.Pools[clientID]
+	if !ok {
+		return "", fmt.Errorf("can't find pool of %v", clientID)
+	}
+
+	if amount == 0 || amount >= dp.Balance {
+		return "", fmt.Errorf("partial unlock amount should be between 0 and %v, got %v", dp.Balance, amount)
+	}
+
+	remaining, err := currency.MinusCoin(dp.Balance, amount)
+	if err != nil {
+		return "", err
+	}
+	if remaining < sp.Settings.MinStake {
+		return "", fmt.Errorf("remaining stake %v is less than min stake %v", remaining, sp.Settings.MinStake)
+	}
+	dp.Balance = remaining
+
+	dpUpdate := newDelegatePoolUpdate(clientID, providerId, providerType)
+	dpUpdate.Updates["balance"] = dp.Balance
+	dpUpdate.emitUpdate(balances)
+
+	i, err := amount.Int64()
+	if err != nil {
+		return "", fmt.Errorf("can't cast amount of value (%v) to Int64", amount)
+	}
+	lock := event.DelegatePoolLock{
+		Client:       clientID,
+		ProviderId:   providerId,
+		ProviderType: providerType,
+		Amount:       i,
+		Total:        i,
+	}
+	balances.EmitEvent(event.TypeStats, event.TagUnlockStakePool, clientID, lock)
+	return toJson(lock), nil
+}
+
 func (sp *StakePool) DeletePool(clientID string, providerType spenum.Provider, providerId datastore.Key,
 	balances cstate.StateContextI) error {
 	dp, ok := sp.Pools[clientID]
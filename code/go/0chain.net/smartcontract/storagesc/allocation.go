@@ -72,6 +72,11 @@ func (sc *StorageSmartContract) addAllocation(alloc *StorageAllocation,
 			"saving new allocation in db: %v", err)
 	}
 
+	if err := addAllocationExpiry(balances, alloc.ID, alloc.Expiration); err != nil {
+		return "", common.NewErrorf("add_allocation_failed",
+			"adding allocation expiry: %v", err)
+	}
+
 	blobberIds := make([]string, 0, len(alloc.BlobberAllocs))
 	for _, v := range alloc.BlobberAllocs {
 		blobberIds = append(blobberIds, v.BlobberID)
@@ -96,6 +101,14 @@ type newAllocationRequest struct {
 	ThirdPartyExtendable bool       `json:"third_party_extendable"`
 	FileOptionsChanged   bool       `json:"file_options_changed"`
 	FileOptions          uint16     `json:"file_options"`
+
+	Placement *PlacementConstraints `json:"placement,omitempty"`
+	// MinReputation is the lowest reputation score, in [0; 1] range,
+	// of the blobbers accepted for the allocation.
+	MinReputation float64 `json:"min_reputation,omitempty"`
+	// AutoRepair opts the allocation in to the replacement of its
+	// degraded blobbers.
+	AutoRepair *AutoRepairPolicy `json:"auto_repair,omitempty"`
 }
 
 // storageAllocation from the request
@@ -112,6 +125,8 @@ func (nar *newAllocationRequest) storageAllocation(conf *Config, now common.Time
 	sa.WritePriceRange = nar.WritePriceRange
 	sa.ThirdPartyExtendable = nar.ThirdPartyExtendable
 	sa.FileOptions = nar.FileOptions
+	sa.Placement = nar.Placement
+	sa.AutoRepair = nar.AutoRepair
 
 	return
 }
@@ -141,6 +156,22 @@ func (nar *newAllocationRequest) validate(conf *Config) error {
 		return errors.New("insufficient allocation size")
 	}
 
+	if nar.Placement != nil {
+		if err := nar.Placement.validate(nar.DataShards + nar.ParityShards); err != nil {
+			return fmt.Errorf("invalid placement: %v", err)
+		}
+	}
+
+	if nar.MinReputation < 0 || nar.MinReputation > 1 {
+		return errors.New("min_reputation not in [0; 1] range")
+	}
+
+	if nar.AutoRepair != nil {
+		if err := nar.AutoRepair.validate(); err != nil {
+			return fmt.Errorf("invalid auto_repair: %v", err)
+		}
+	}
+
 	return nil
 }
 
@@ -419,7 +450,7 @@ func setupNewAllocation(
 	sa.ID = allocId
 	sa.Tx = allocId
 
-	blobberNodes, bSize, err := validateBlobbers(balances, common.ToTime(now), sa, blobbers, request.BlobberAuthTickets, conf)
+	blobberNodes, bSize, err := validateBlobbers(balances, common.ToTime(now), sa, blobbers, request.BlobberAuthTickets, request.MinReputation, conf)
 	if err != nil {
 		logging.Logger.Error("new_allocation_request_failed: error validating blobbers",
 			zap.Error(err))
@@ -474,6 +505,7 @@ func validateBlobbers(
 	sa *StorageAllocation,
 	blobbers []*storageNodeResponse,
 	blobberAuthTickets []string,
+	minReputation float64,
 	conf *Config,
 ) ([]*StorageNode, int64, error) {
 	sa.TimeUnit = conf.TimeUnit // keep the initial time unit
@@ -484,10 +516,30 @@ func validateBlobbers(
 	var bSize = sa.bSize()
 	var list, errs = sa.validateEachBlobber(balances, blobbers, blobberAuthTickets, common.Timestamp(creationDate.Unix()), conf)
 
+	if minReputation > 0 {
+		var (
+			reputable []*StorageNode
+			err       error
+		)
+		reputable, errs, err = filterByReputation(list, errs, minReputation, conf, balances)
+		if err != nil {
+			return nil, 0, err
+		}
+		list = reputable
+	}
+
 	if len(list) < size {
 		return nil, 0, errors.New("Not enough blobbers to honor the allocation: " + strings.Join(errs, ", "))
 	}
 
+	if sa.Placement != nil {
+		picked, err := sa.Placement.pick(list, size)
+		if err != nil {
+			return nil, 0, err
+		}
+		list = picked
+	}
+
 	sa.BlobberAllocs = make([]*BlobberAllocation, 0)
 	sa.Stats = &StorageAllocationStats{}
 
@@ -507,6 +559,10 @@ type updateAllocationRequest struct {
 	SetThirdPartyExtendable bool   `json:"set_third_party_extendable"`
 	FileOptionsChanged      bool   `json:"file_options_changed"`
 	FileOptions             uint16 `json:"file_options"`
+	// AutoRepair sets the auto repair policy of the allocation.
+	AutoRepair *AutoRepairPolicy `json:"auto_repair,omitempty"`
+	// DisableAutoRepair opts the allocation out of auto repair.
+	DisableAutoRepair bool `json:"disable_auto_repair"`
 }
 
 func (uar *updateAllocationRequest) decode(b []byte) error {
@@ -524,6 +580,7 @@ func (uar *updateAllocationRequest) validate(
 		len(uar.Name) == 0 &&
 		(!uar.SetThirdPartyExtendable || (uar.SetThirdPartyExtendable && alloc.ThirdPartyExtendable)) &&
 		(!uar.FileOptionsChanged || uar.FileOptions == alloc.FileOptions) &&
+		uar.AutoRepair == nil && (!uar.DisableAutoRepair || alloc.AutoRepair == nil) &&
 		(alloc.Owner == uar.OwnerID) {
 		return errors.New("update allocation changes nothing")
 	} else {
@@ -556,6 +613,15 @@ func (uar *updateAllocationRequest) validate(
 		return fmt.Errorf("FileOptions %d incorrect", uar.FileOptions)
 	}
 
+	if uar.AutoRepair != nil {
+		if uar.DisableAutoRepair {
+			return errors.New("cannot both set and disable auto repair")
+		}
+		if err := uar.AutoRepair.validate(); err != nil {
+			return fmt.Errorf("invalid auto_repair: %v", err)
+		}
+	}
+
 	return nil
 }
 
@@ -1004,6 +1070,9 @@ func (sc *StorageSmartContract) updateAllocationRequestInternal(
 			"can't update expired allocation")
 	}
 
+	// keep the expiration to update the expiry index if it's extended
+	expiration := alloc.Expiration
+
 	// update allocation transaction hash
 	alloc.Tx = t.Hash
 
@@ -1070,6 +1139,12 @@ func (sc *StorageSmartContract) updateAllocationRequestInternal(
 			alloc.FileOptions = request.FileOptions
 		}
 
+		if request.AutoRepair != nil {
+			alloc.AutoRepair = request.AutoRepair
+		} else if request.DisableAutoRepair {
+			alloc.AutoRepair = nil
+		}
+
 		if len(request.RemoveBlobberId) > 0 {
 			balances.EmitEvent(event.TypeStats, event.TagDeleteAllocationBlobberTerm, t.Hash, []event.AllocationBlobberTerm{
 				{
@@ -1080,11 +1155,21 @@ func (sc *StorageSmartContract) updateAllocationRequestInternal(
 		}
 
 		if request.OwnerID != alloc.Owner {
-			alloc.Owner = request.OwnerID
-			if request.OwnerPublicKey == "" {
-				return "", common.NewError("allocation_updating_failed", "owner public key is required when updating owner id")
+			actErr := chainstate.WithActivation(balances, "hermes", func() error {
+				alloc.Owner = request.OwnerID
+				if request.OwnerPublicKey == "" {
+					return common.NewError("allocation_updating_failed", "owner public key is required when updating owner id")
+				}
+				alloc.OwnerPublicKey = request.OwnerPublicKey
+				return nil
+			}, func() error {
+				// the new owner has to accept the allocation, see propose_allocation_owner
+				return common.NewError("allocation_updating_failed",
+					"owner can't be changed by update, propose the new owner instead")
+			})
+			if actErr != nil {
+				return "", actErr
 			}
-			alloc.OwnerPublicKey = request.OwnerPublicKey
 		}
 	}
 
@@ -1114,6 +1199,11 @@ func (sc *StorageSmartContract) updateAllocationRequestInternal(
 
 	// lock tokens if this transaction provides them
 
+	if err := updateAllocationExpiry(balances, alloc.ID, expiration, alloc.Expiration); err != nil {
+		return "", common.NewError("allocation_updating_failed",
+			"updating allocation expiry: "+err.Error())
+	}
+
 	err = alloc.saveUpdatedAllocation(blobbers, balances)
 	if err != nil {
 		return "", common.NewErrorf("allocation_reducing_failed", "%v", err)
@@ -1362,6 +1452,11 @@ func (sc *StorageSmartContract) cancelAllocationRequest(
 		return "", common.NewError("alloc_cancel_failed", err.Error())
 	}
 
+	if err := removeAllocationExpiry(balances, alloc.ID, alloc.Expiration); err != nil {
+		return "", common.NewError("alloc_cancel_failed",
+			"removing allocation expiry: "+err.Error())
+	}
+
 	alloc.Expiration = t.CreationDate
 	alloc.Finalized, alloc.Canceled = true, true
 
@@ -1425,27 +1520,42 @@ func (sc *StorageSmartContract) finalizeAllocationInternal(
 			"not allowed, unknown finalization initiator")
 	}
 
+	if err = sc.finalizeExpired(t, alloc, balances); err != nil {
+		return nil, err
+	}
+
+	return alloc, nil
+}
+
+// finalizeExpired finalizes an expired allocation without deleting it, for
+// both the finalize_allocation of the owner or a blobber and the built-in
+// finalization by the block generator.
+func (sc *StorageSmartContract) finalizeExpired(
+	t *transaction.Transaction,
+	alloc *StorageAllocation,
+	balances chainstate.StateContextI) (err error) {
+
 	// should not be finalized
 	if alloc.Finalized {
-		return nil, common.NewError("fini_alloc_failed",
+		return common.NewError("fini_alloc_failed",
 			"allocation already finalized")
 	}
 
 	conf, err := getConfig(balances)
 	if err != nil {
-		return nil, common.NewError("can't get config", err.Error())
+		return common.NewError("can't get config", err.Error())
 	}
 
 	// should be expired
 	if alloc.Expiration > t.CreationDate {
-		return nil, common.NewError("fini_alloc_failed",
+		return common.NewError("fini_alloc_failed",
 			"allocation is not expired yet")
 	}
 
 	var passRates []float64
 	passRates, err = sc.settleOpenChallengesAndGetPassRates(alloc, balances.GetBlock().Round, conf.MaxChallengeCompletionRounds, balances)
 	if err != nil {
-		return nil, common.NewError("fini_alloc_failed",
+		return common.NewError("fini_alloc_failed",
 			"calculating rest challenges success/fail rates: "+err.Error())
 	}
 
@@ -1453,11 +1563,11 @@ func (sc *StorageSmartContract) finalizeAllocationInternal(
 	for _, d := range alloc.BlobberAllocs {
 		var sp *stakePool
 		if sp, err = sc.getStakePool(spenum.Blobber, d.BlobberID, balances); err != nil {
-			return nil, common.NewError("fini_alloc_failed",
+			return common.NewError("fini_alloc_failed",
 				"can't get stake pool of "+d.BlobberID+": "+err.Error())
 		}
 		if err := sp.reduceOffer(d.Offer()); err != nil {
-			return nil, common.NewError("fini_alloc_failed",
+			return common.NewError("fini_alloc_failed",
 				"error removing offer: "+err.Error())
 		}
 		sps = append(sps, sp)
@@ -1465,13 +1575,18 @@ func (sc *StorageSmartContract) finalizeAllocationInternal(
 
 	err = sc.finishAllocation(t, alloc, passRates, sps, balances, conf)
 	if err != nil {
-		return nil, common.NewError("fini_alloc_failed", err.Error())
+		return common.NewError("fini_alloc_failed", err.Error())
+	}
+
+	if err := removeAllocationExpiry(balances, alloc.ID, alloc.Expiration); err != nil {
+		return common.NewError("fini_alloc_failed",
+			"removing allocation expiry: "+err.Error())
 	}
 
 	alloc.Finalized = true
 	balances.EmitEvent(event.TypeStats, event.TagUpdateAllocation, alloc.ID, alloc.buildDbUpdates())
 
-	return alloc, nil
+	return nil
 }
 
 func (sc *StorageSmartContract) finishAllocation(
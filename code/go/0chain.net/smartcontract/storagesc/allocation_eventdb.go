@@ -281,3 +281,26 @@ func emitUpdateAllocationBlobberTerms(sa *StorageAllocation, balances cstate.Sta
 func emitDeleteAllocationBlobberTerms(sa *StorageAllocation, balances cstate.StateContextI, t *transaction.Transaction) {
 	balances.EmitEvent(event.TypeStats, event.TagDeleteAllocationBlobberTerm, t.Hash, sa.buildEventBlobberTerms())
 }
+
+func emitAllocationRepair(allocationID, removedID, addedID, reason, status string, balances cstate.StateContextI) {
+	balances.EmitEvent(event.TypeStats, event.TagAllocationRepair, allocationID, event.AllocationRepair{
+		AllocationID:     allocationID,
+		RemovedBlobberID: removedID,
+		AddedBlobberID:   addedID,
+		Reason:           reason,
+		Status:           status,
+		Round:            balances.GetBlock().Round,
+	})
+}
+
+func emitOwnershipTransfer(alloc *StorageAllocation, fromOwnerID string, pt *OwnershipTransfer, status string, balances cstate.StateContextI) {
+	balances.EmitEvent(event.TypeStats, event.TagAllocationOwnershipTransfer, alloc.ID, event.AllocationOwnershipTransfer{
+		AllocationID: alloc.ID,
+		FromOwnerID:  fromOwnerID,
+		ToOwnerID:    pt.NewOwnerID,
+		Status:       status,
+		ExpiresAt:    int64(pt.ExpiresAt),
+		WritePool:    alloc.WritePool,
+		Round:        balances.GetBlock().Round,
+	})
+}
@@ -0,0 +1,436 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/smartcontract/partitions"
+	"github.com/0chain/common/core/util"
+)
+
+//go:generate msgp -io=false -tests=false -unexported=true -v
+
+// FinalizeExpiredAllocationFuncName is the function of the built-in
+// transactions the miners add to their blocks to finalize the expired
+// allocations.
+const FinalizeExpiredAllocationFuncName = "finalize_expired_allocation"
+
+// BackfillAllocationExpiryFuncName is the function of the built-in
+// transactions the miners add to their blocks to add the allocations created
+// before the expiry index to it.
+const BackfillAllocationExpiryFuncName = "backfill_allocation_expiry"
+
+// AllocationExpiryBackfillBatch bounds the allocations of a backfill
+// transaction.
+const AllocationExpiryBackfillBatch = 50
+
+// allocationExpiryPeriod is the time span of an expiry partitions, the
+// allocations expiring within the same period are kept together.
+const allocationExpiryPeriod common.Timestamp = 60 * 60
+
+var allocationExpiryPartitionSize = 50
+
+// expiredAllocationsScanFactor bounds the expired allocations collected by
+// a miner, relatively to the max finalized per round.
+const expiredAllocationsScanFactor = 10
+
+// ExpiringAllocationNode is an allocation in the expiry index.
+type ExpiringAllocationNode struct {
+	ID         string           `json:"id"`
+	Expiration common.Timestamp `json:"expiration"`
+}
+
+func (n *ExpiringAllocationNode) GetID() string {
+	return n.ID
+}
+
+// allocationExpiryPeriods is the sorted list of the expiry periods having
+// allocations in the index.
+type allocationExpiryPeriods struct {
+	Periods []int64 `json:"periods"`
+}
+
+func (ep *allocationExpiryPeriods) add(period int64) bool {
+	i := sort.Search(len(ep.Periods), func(i int) bool {
+		return ep.Periods[i] >= period
+	})
+	if i < len(ep.Periods) && ep.Periods[i] == period {
+		return false
+	}
+	ep.Periods = append(ep.Periods, 0)
+	copy(ep.Periods[i+1:], ep.Periods[i:])
+	ep.Periods[i] = period
+	return true
+}
+
+func (ep *allocationExpiryPeriods) remove(period int64) bool {
+	i := sort.Search(len(ep.Periods), func(i int) bool {
+		return ep.Periods[i] >= period
+	})
+	if i == len(ep.Periods) || ep.Periods[i] != period {
+		return false
+	}
+	ep.Periods = append(ep.Periods[:i], ep.Periods[i+1:]...)
+	return true
+}
+
+func allocationExpiryPeriodOf(expiration common.Timestamp) int64 {
+	return int64(expiration / allocationExpiryPeriod)
+}
+
+func allocationExpiryKey(period int64) datastore.Key {
+	var sb strings.Builder
+	sb.WriteString(ALLOCATION_EXPIRY_KEY)
+	sb.WriteString(":period:")
+	sb.WriteString(strconv.FormatInt(period, 10))
+
+	return sb.String()
+}
+
+func getAllocationExpiryPeriods(balances cstate.StateContextI) (*allocationExpiryPeriods, error) {
+	ep := &allocationExpiryPeriods{}
+	err := balances.GetTrieNode(ALLOCATION_EXPIRY_PERIODS_KEY, ep)
+	if err != nil && err != util.ErrValueNotPresent {
+		return nil, err
+	}
+	return ep, nil
+}
+
+func (ep *allocationExpiryPeriods) save(balances cstate.StateContextI) error {
+	_, err := balances.InsertTrieNode(ALLOCATION_EXPIRY_PERIODS_KEY, ep)
+	return err
+}
+
+// addAllocationExpiry adds an allocation to the expiry index, kept since the
+// hermes hard fork.
+func addAllocationExpiry(balances cstate.StateContextI, allocID string, expiration common.Timestamp) error {
+	return cstate.WithActivation(balances, "hermes", func() error {
+		return nil
+	}, func() error {
+		period := allocationExpiryPeriodOf(expiration)
+		parts, err := partitions.CreateIfNotExists(balances, allocationExpiryKey(period),
+			allocationExpiryPartitionSize)
+		if err != nil {
+			return err
+		}
+
+		if err := parts.Add(balances, &ExpiringAllocationNode{
+			ID:         allocID,
+			Expiration: expiration,
+		}); err != nil {
+			return err
+		}
+
+		if err := parts.Save(balances); err != nil {
+			return err
+		}
+
+		ep, err := getAllocationExpiryPeriods(balances)
+		if err != nil {
+			return err
+		}
+		if ep.add(period) {
+			return ep.save(balances)
+		}
+		return nil
+	})
+}
+
+// removeAllocationExpiry removes an allocation from the expiry index, the
+// allocations created before the index are not in it.
+func removeAllocationExpiry(balances cstate.StateContextI, allocID string, expiration common.Timestamp) error {
+	period := allocationExpiryPeriodOf(expiration)
+	parts, err := partitions.GetPartitions(balances, allocationExpiryKey(period))
+	if err != nil {
+		if err == util.ErrValueNotPresent {
+			return nil
+		}
+		return err
+	}
+
+	if err := parts.Remove(balances, allocID); err != nil {
+		if partitions.ErrItemNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	if err := parts.Save(balances); err != nil {
+		return err
+	}
+
+	size, err := parts.Size(balances)
+	if err != nil {
+		return err
+	}
+	if size > 0 {
+		return nil
+	}
+
+	ep, err := getAllocationExpiryPeriods(balances)
+	if err != nil {
+		return err
+	}
+	if ep.remove(period) {
+		return ep.save(balances)
+	}
+	return nil
+}
+
+// updateAllocationExpiry moves an allocation in the expiry index when its
+// expiration changes.
+func updateAllocationExpiry(balances cstate.StateContextI, allocID string, from, to common.Timestamp) error {
+	if from == to {
+		return nil
+	}
+	if err := removeAllocationExpiry(balances, allocID, from); err != nil {
+		return err
+	}
+	return addAllocationExpiry(balances, allocID, to)
+}
+
+// allocationExpiryBackfill is the progress of the backfill of the expiry
+// index, the allocations are added by id, up to the cursor.
+type allocationExpiryBackfill struct {
+	Cursor string `json:"cursor"`
+	Done   bool   `json:"done"`
+	// Round the backfill was done in.
+	Round int64 `json:"round"`
+}
+
+// allocationExpiryBackfillRequest is a batch of the allocations following the
+// cursor by id, an empty batch ends the backfill.
+//
+//msgp:ignore allocationExpiryBackfillRequest
+type allocationExpiryBackfillRequest struct {
+	Cursor        string   `json:"cursor"`
+	AllocationIDs []string `json:"allocation_ids"`
+}
+
+func getAllocationExpiryBackfill(balances cstate.StateContextI) (*allocationExpiryBackfill, error) {
+	bf := &allocationExpiryBackfill{}
+	err := balances.GetTrieNode(ALLOCATION_EXPIRY_BACKFILL_KEY, bf)
+	if err != nil && err != util.ErrValueNotPresent {
+		return nil, err
+	}
+	return bf, nil
+}
+
+// GetAllocationExpiryBackfillCursor returns the allocation id the next batch
+// of the backfill of the expiry index follows, and whether the backfill is
+// done. There is nothing to backfill before the hermes hard fork.
+func GetAllocationExpiryBackfillCursor(balances cstate.StateContextI) (cursor string, done bool, err error) {
+	err = cstate.WithActivation(balances, "hermes", func() error {
+		done = true
+		return nil
+	}, func() error {
+		bf, err := getAllocationExpiryBackfill(balances)
+		if err != nil {
+			return err
+		}
+		cursor, done = bf.Cursor, bf.Done
+		return nil
+	})
+	return
+}
+
+// backfillAllocationExpiry is a built-in SC function the block generators use
+// to add the allocations created before the hermes hard fork to the expiry
+// index, a batch of at most AllocationExpiryBackfillBatch allocations
+// following the cursor at a time. The generators take the batches from their
+// event db, the allocations missing, finalized or canceled in the state are
+// skipped.
+func (sc *StorageSmartContract) backfillAllocationExpiry(
+	t *transaction.Transaction, input []byte,
+	balances cstate.StateContextI) (string, error) {
+
+	if t.ClientID != balances.GetBlock().MinerID {
+		return "", common.NewError("backfill_allocation_expiry_failed",
+			"only the block generator can backfill the allocation expiry index")
+	}
+
+	if err := cstate.WithActivation(balances, "hermes", func() error {
+		return errors.New("the backfill is not enabled yet")
+	}, func() error {
+		return nil
+	}); err != nil {
+		return "", common.NewError("backfill_allocation_expiry_failed", err.Error())
+	}
+
+	var req allocationExpiryBackfillRequest
+	if err := json.Unmarshal(input, &req); err != nil {
+		return "", common.NewError("backfill_allocation_expiry_failed", err.Error())
+	}
+	if len(req.AllocationIDs) > AllocationExpiryBackfillBatch {
+		return "", common.NewErrorf("backfill_allocation_expiry_failed",
+			"more than %d allocations", AllocationExpiryBackfillBatch)
+	}
+
+	bf, err := getAllocationExpiryBackfill(balances)
+	if err != nil {
+		return "", common.NewError("backfill_allocation_expiry_failed", err.Error())
+	}
+	if bf.Done {
+		return "", common.NewError("backfill_allocation_expiry_failed",
+			"the backfill is done")
+	}
+	if req.Cursor != bf.Cursor {
+		return "", common.NewErrorf("backfill_allocation_expiry_failed",
+			"unexpected cursor %q, the backfill is at %q", req.Cursor, bf.Cursor)
+	}
+
+	for _, allocID := range req.AllocationIDs {
+		if allocID <= bf.Cursor {
+			return "", common.NewErrorf("backfill_allocation_expiry_failed",
+				"allocation %s doesn't follow %s", allocID, bf.Cursor)
+		}
+		bf.Cursor = allocID
+
+		alloc, err := sc.getAllocation(allocID, balances)
+		if err != nil {
+			if errors.Is(err, util.ErrValueNotPresent) {
+				continue
+			}
+			return "", common.NewError("backfill_allocation_expiry_failed", err.Error())
+		}
+		if alloc.Finalized || alloc.Canceled {
+			continue
+		}
+
+		err = addAllocationExpiry(balances, alloc.ID, alloc.Expiration)
+		if err != nil && !partitions.ErrItemExist(err) {
+			return "", common.NewErrorf("backfill_allocation_expiry_failed",
+				"adding allocation %s: %v", alloc.ID, err)
+		}
+	}
+	if len(req.AllocationIDs) == 0 {
+		bf.Done, bf.Round = true, balances.GetBlock().Round
+	}
+
+	if _, err := balances.InsertTrieNode(ALLOCATION_EXPIRY_BACKFILL_KEY, bf); err != nil {
+		return "", common.NewError("backfill_allocation_expiry_failed", err.Error())
+	}
+	return bf.Cursor, nil
+}
+
+// GetExpiredAllocations returns the allocations the block generator should
+// finalize at the given round, at most auto_finalize.max_per_round of them.
+// The picked allocations rotate by round, an allocation failing to finalize
+// doesn't hold the others back.
+func GetExpiredAllocations(balances cstate.StateContextI, round int64, now common.Timestamp) ([]string, error) {
+	conf, err := getConfig(balances)
+	if err != nil {
+		return nil, err
+	}
+	if !conf.AutoFinalize.Enabled || conf.AutoFinalize.MaxPerRound < 1 {
+		return nil, nil
+	}
+
+	ep, err := getAllocationExpiryPeriods(balances)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		max     = conf.AutoFinalize.MaxPerRound
+		limit   = max * expiredAllocationsScanFactor
+		last    = allocationExpiryPeriodOf(now)
+		expired = make([]string, 0, max)
+	)
+	for _, period := range ep.Periods {
+		if period > last || len(expired) >= limit {
+			break
+		}
+
+		parts, err := partitions.GetPartitions(balances, allocationExpiryKey(period))
+		if err != nil {
+			if err == util.ErrValueNotPresent {
+				continue
+			}
+			return nil, err
+		}
+
+		var decodeErr error
+		if err := parts.ForEach(balances, func(_ int, id string, data []byte) bool {
+			var n ExpiringAllocationNode
+			if _, decodeErr = n.UnmarshalMsg(data); decodeErr != nil {
+				return true
+			}
+			if n.Expiration <= now {
+				expired = append(expired, id)
+			}
+			return len(expired) >= limit
+		}); err != nil {
+			return nil, err
+		}
+		if decodeErr != nil {
+			return nil, decodeErr
+		}
+	}
+
+	if len(expired) <= max {
+		return expired, nil
+	}
+
+	ids := make([]string, 0, max)
+	start := int(round % int64(len(expired)))
+	for i := 0; i < max; i++ {
+		ids = append(ids, expired[(start+i)%len(expired)])
+	}
+	return ids, nil
+}
+
+// finalizeExpiredAllocation is a built-in SC function the block generators
+// use to finalize the expired allocations, the same way as a
+// finalize_allocation by the owner or a blobber of the allocation does.
+func (sc *StorageSmartContract) finalizeExpiredAllocation(
+	t *transaction.Transaction, input []byte,
+	balances cstate.StateContextI) (resp string, err error) {
+
+	if t.ClientID != balances.GetBlock().MinerID {
+		return "", common.NewError("fini_expired_alloc_failed",
+			"only the block generator can finalize expired allocations")
+	}
+
+	conf, err := getConfig(balances)
+	if err != nil {
+		return "", common.NewError("fini_expired_alloc_failed",
+			"can't get config: "+err.Error())
+	}
+	if !conf.AutoFinalize.Enabled {
+		return "", common.NewError("fini_expired_alloc_failed",
+			"auto finalization is disabled")
+	}
+
+	var req lockRequest
+	if err = req.decode(input); err != nil {
+		return "", common.NewError("fini_expired_alloc_failed", err.Error())
+	}
+
+	alloc, err := sc.getAllocation(req.AllocationID, balances)
+	if err != nil {
+		if errors.Is(err, util.ErrValueNotPresent) {
+			return "", common.NewError("fini_expired_alloc_failed",
+				"allocation not found: "+req.AllocationID)
+		}
+		return "", common.NewError("fini_expired_alloc_failed", err.Error())
+	}
+
+	if err = sc.finalizeExpired(t, alloc, balances); err != nil {
+		return "", err
+	}
+
+	_, err = balances.DeleteTrieNode(alloc.GetKey(sc.ID))
+	if err != nil {
+		return "", common.NewErrorf("fini_expired_alloc_failed", "could not delete allocation: %v", err)
+	}
+
+	return "finalized", nil
+}
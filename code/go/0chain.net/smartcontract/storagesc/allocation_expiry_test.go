@@ -0,0 +1,220 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"testing"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/smartcontract/partitions"
+	"github.com/0chain/common/core/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocationExpiryPeriods(t *testing.T) {
+	var ep allocationExpiryPeriods
+	require.True(t, ep.add(5))
+	require.True(t, ep.add(2))
+	require.True(t, ep.add(9))
+	require.False(t, ep.add(5))
+	require.Equal(t, []int64{2, 5, 9}, ep.Periods)
+
+	require.True(t, ep.remove(5))
+	require.False(t, ep.remove(5))
+	require.False(t, ep.remove(7))
+	require.Equal(t, []int64{2, 9}, ep.Periods)
+}
+
+func TestAllocationExpiryIndex(t *testing.T) {
+	var (
+		balances = newTestBalances(t, false)
+		conf     = setConfig(t, balances)
+		base     = 10 * allocationExpiryPeriod
+	)
+	conf.AutoFinalize = autoFinalizeConfig{Enabled: true, MaxPerRound: 2}
+	_, err := balances.InsertTrieNode(scConfigKey(ADDRESS), conf)
+	require.NoError(t, err)
+
+	require.NoError(t, addAllocationExpiry(balances, "alloc1", base+10))
+	require.NoError(t, addAllocationExpiry(balances, "alloc2", base+20))
+	require.NoError(t, addAllocationExpiry(balances, "alloc3", base+allocationExpiryPeriod+10))
+
+	ep, err := getAllocationExpiryPeriods(balances)
+	require.NoError(t, err)
+	require.Equal(t, []int64{10, 11}, ep.Periods)
+
+	// nothing expired yet
+	ids, err := GetExpiredAllocations(balances, 1, base)
+	require.NoError(t, err)
+	require.Empty(t, ids)
+
+	ids, err = GetExpiredAllocations(balances, 1, base+15)
+	require.NoError(t, err)
+	require.Equal(t, []string{"alloc1"}, ids)
+
+	// at most max_per_round, rotating by round
+	now := base + allocationExpiryPeriod + 10
+	ids, err = GetExpiredAllocations(balances, 0, now)
+	require.NoError(t, err)
+	require.Len(t, ids, 2)
+	other, err := GetExpiredAllocations(balances, 1, now)
+	require.NoError(t, err)
+	require.Len(t, other, 2)
+	require.NotEqual(t, ids, other)
+
+	// extended allocation moves to its new period
+	require.NoError(t, updateAllocationExpiry(balances, "alloc1", base+10,
+		base+5*allocationExpiryPeriod))
+	ids, err = GetExpiredAllocations(balances, 0, base+30)
+	require.NoError(t, err)
+	require.Equal(t, []string{"alloc2"}, ids)
+
+	// an emptied period leaves the index
+	require.NoError(t, removeAllocationExpiry(balances, "alloc2", base+20))
+	ep, err = getAllocationExpiryPeriods(balances)
+	require.NoError(t, err)
+	require.Equal(t, []int64{11, 15}, ep.Periods)
+
+	// allocations not in the index are ignored
+	require.NoError(t, removeAllocationExpiry(balances, "alloc2", base+20))
+	require.NoError(t, removeAllocationExpiry(balances, "unknown", base+allocationExpiryPeriod))
+
+	// disabled
+	conf.AutoFinalize.Enabled = false
+	_, err = balances.InsertTrieNode(scConfigKey(ADDRESS), conf)
+	require.NoError(t, err)
+	ids, err = GetExpiredAllocations(balances, 0, now)
+	require.NoError(t, err)
+	require.Empty(t, ids)
+}
+
+func TestFinalizeExpiredAllocationNotMiner(t *testing.T) {
+	var (
+		ssc      = newTestStorageSC()
+		balances = newTestBalances(t, false)
+		txn      = newTransaction("client", ADDRESS, 0, 1000)
+	)
+	balances.block.MinerID = "miner"
+	_, err := ssc.finalizeExpiredAllocation(txn, []byte(`{"allocation_id":"id"}`), balances)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "only the block generator")
+}
+
+func TestBackfillAllocationExpiry(t *testing.T) {
+	var (
+		balances = newTestBalances(t, false)
+		ssc      = newTestStorageSC()
+		txn      = newTransaction("miner", ADDRESS, 0, 1000)
+		base     = 10 * allocationExpiryPeriod
+	)
+	balances.block.MinerID = "miner"
+
+	// allocations created before the expiry index, alloc3 is deleted
+	for _, alloc := range []*StorageAllocation{
+		{ID: "alloc1", Expiration: base + 10},
+		{ID: "alloc2", Expiration: base + 20, Finalized: true},
+		{ID: "alloc4", Expiration: base + allocationExpiryPeriod},
+	} {
+		_, err := balances.InsertTrieNode(alloc.GetKey(ADDRESS), alloc)
+		require.NoError(t, err)
+	}
+
+	backfill := func(cursor string, ids ...string) (string, error) {
+		input, err := json.Marshal(&allocationExpiryBackfillRequest{
+			Cursor:        cursor,
+			AllocationIDs: ids,
+		})
+		require.NoError(t, err)
+		return ssc.backfillAllocationExpiry(txn, input, balances)
+	}
+
+	cursor, err := backfill("", "alloc1", "alloc2", "alloc3")
+	require.NoError(t, err)
+	require.Equal(t, "alloc3", cursor)
+
+	_, err = backfill("", "alloc4")
+	require.Error(t, err, "stale cursor")
+	_, err = backfill("alloc3", "alloc4", "alloc1")
+	require.Error(t, err, "not following the cursor")
+
+	cursor, err = backfill("alloc3", "alloc4")
+	require.NoError(t, err)
+	require.Equal(t, "alloc4", cursor)
+
+	cursor, done, err := GetAllocationExpiryBackfillCursor(balances)
+	require.NoError(t, err)
+	require.False(t, done)
+	require.Equal(t, "alloc4", cursor)
+
+	// an empty batch ends the backfill
+	_, err = backfill("alloc4")
+	require.NoError(t, err)
+	_, done, err = GetAllocationExpiryBackfillCursor(balances)
+	require.NoError(t, err)
+	require.True(t, done)
+	_, err = backfill("alloc4")
+	require.Error(t, err, "done")
+
+	ep, err := getAllocationExpiryPeriods(balances)
+	require.NoError(t, err)
+	require.Equal(t, []int64{10, 11}, ep.Periods)
+	for period, want := range map[int64][]string{
+		10: {"alloc1"},
+		11: {"alloc4"},
+	} {
+		ids, err := partitionItemIDs(balances, allocationExpiryKey(period))
+		require.NoError(t, err)
+		require.Equal(t, want, ids)
+	}
+
+	// only the block generator
+	_, err = ssc.backfillAllocationExpiry(newTransaction("client", ADDRESS, 0, 1000),
+		[]byte(`{"cursor":"alloc4"}`), balances)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "only the block generator")
+}
+
+func TestAllocationExpiryBeforeHermes(t *testing.T) {
+	var (
+		balances = newTestBalances(t, false)
+		ssc      = newTestStorageSC()
+		txn      = newTransaction("miner", ADDRESS, 0, 1000)
+	)
+	balances.block.MinerID = "miner"
+	h := cstate.NewHardFork("hermes", balances.block.Round+1)
+	_, err := balances.InsertTrieNode(h.GetKey(), h)
+	require.NoError(t, err)
+
+	require.NoError(t, addAllocationExpiry(balances, "alloc1", 10))
+	ep, err := getAllocationExpiryPeriods(balances)
+	require.NoError(t, err)
+	require.Empty(t, ep.Periods)
+
+	_, done, err := GetAllocationExpiryBackfillCursor(balances)
+	require.NoError(t, err)
+	require.True(t, done, "nothing to backfill")
+
+	_, err = ssc.backfillAllocationExpiry(txn, []byte(`{"cursor":""}`), balances)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not enabled")
+}
+
+// partitionItemIDs returns the ids of the items of the partitions, none if
+// the partitions don't exist.
+func partitionItemIDs(balances cstate.StateContextI, name string) ([]string, error) {
+	parts, err := partitions.GetPartitions(balances, name)
+	if err != nil {
+		if err == util.ErrValueNotPresent {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var ids []string
+	if err := parts.ForEach(balances, func(_ int, id string, _ []byte) bool {
+		ids = append(ids, id)
+		return false
+	}); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
@@ -2509,8 +2509,26 @@ func TestStorageSmartContract_updateAllocationRequest(t *testing.T) {
 		OwnerPublicKey: otherClient.pk,
 	}
 
+	// the owner can't be changed in one step
 	tp += 1000
-	resp, err = uarOwnerUpdate.callUpdateAllocReq(t, client.id, 0, tp, ssc, balances)
+	_, err = uarOwnerUpdate.callUpdateAllocReq(t, client.id, 0, tp, ssc, balances)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "propose the new owner")
+
+	proposeInput, err := json.Marshal(&proposeOwnershipTransferRequest{
+		AllocationID: alloc.ID,
+		NewOwnerID:   otherClient.id,
+		ExpiresAt:    common.Timestamp(tp + 100),
+	})
+	require.NoError(t, err)
+	_, err = ssc.proposeOwnershipTransfer(newTransaction(client.id, ADDRESS, 0, tp), proposeInput, balances)
+	require.NoError(t, err)
+
+	acceptInput, err := json.Marshal(&ownershipTransferRequest{AllocationID: alloc.ID})
+	require.NoError(t, err)
+	acceptTxn := newTransaction(otherClient.id, ADDRESS, 0, tp+10)
+	acceptTxn.PublicKey = otherClient.pk
+	resp, err = ssc.acceptOwnershipTransfer(acceptTxn, acceptInput, balances)
 	require.NoError(t, err)
 	require.NoError(t, deco.Decode([]byte(resp)))
 
@@ -333,6 +333,27 @@ func BenchmarkRestTests(
 				},
 				Endpoint: srh.getBlobberChallenges,
 			},
+			{
+				FuncName: "blobber-reputation",
+				Params: map[string]string{
+					"blobber_id": getMockBlobberId(0),
+				},
+				Endpoint: srh.getBlobberReputation,
+			},
+			{
+				FuncName: "allocation-repairs",
+				Params: map[string]string{
+					"allocation_id": getMockAllocationId(0),
+				},
+				Endpoint: srh.getAllocationRepairs,
+			},
+			{
+				FuncName: "allocation-owner-transfers",
+				Params: map[string]string{
+					"allocation_id": getMockAllocationId(mockTransferAllocationIndex),
+				},
+				Endpoint: srh.getAllocationOwnerTransfers,
+			},
 			{
 				FuncName: "search.block_number",
 				Params: map[string]string{
@@ -30,6 +30,7 @@ import (
 
 const (
 	mockFinalizedAllocationIndex = 2
+	mockTransferAllocationIndex  = 1
 )
 
 func AddMockAllocations(
@@ -85,9 +86,18 @@ func addMockAllocation(
 			FailedChallenges:          2,
 			LastestClosedChallengeTxn: "latest closed challenge transaction:" + id,
 		},
-		TimeUnit:  viper.GetDuration(sc.TimeUnit),
-		Finalized: i == mockFinalizedAllocationIndex,
-		WritePool: 2e10,
+		TimeUnit:   viper.GetDuration(sc.TimeUnit),
+		Finalized:  i == mockFinalizedAllocationIndex,
+		WritePool:  2e10,
+		AutoRepair: &AutoRepairPolicy{MinReputation: 0.5},
+	}
+
+	if i == mockTransferAllocationIndex {
+		sa.PendingTransfer = &OwnershipTransfer{
+			NewOwnerID: clients[getMockTransferOwnerIndex(cIndex, len(clients))],
+			ProposedAt: sa.StartTime,
+			ExpiresAt:  sa.Expiration,
+		}
 	}
 
 	startBlobbers := getMockBlobberBlockFromAllocationIndex(i)
@@ -801,6 +811,10 @@ func getMockOwnerFromAllocationIndex(allocation, numClinets int) int {
 	return allocation % (numClinets - 1 - viper.GetInt(sc.NumAllocationPayerPools))
 }
 
+func getMockTransferOwnerIndex(ownerIndex, numClients int) int {
+	return (ownerIndex + 1) % numClients
+}
+
 func getMockBlobberBlockFromAllocationIndex(i int) int {
 	return i % (viper.GetInt(sc.NumBlobbers) - viper.GetInt(sc.NumBlobbersPerAllocation))
 }
@@ -868,6 +882,15 @@ func SetMockConfig(
 	conf.BlockReward.QualifyingStake = currency.Coin(viper.GetFloat64(sc.StorageBlockRewardQualifyingStake) * 1e10)
 	conf.MaxBlobbersPerAllocation = viper.GetInt(sc.StorageMaxBlobbersPerAllocation)
 	conf.BlockReward.TriggerPeriod = viper.GetInt64(sc.StorageBlockRewardTriggerPeriod)
+	conf.Reputation = reputationConfig{
+		Decay:        0.95,
+		UptimeWeight: 0.2,
+		RewardWeight: 0.5,
+	}
+	conf.AutoFinalize = autoFinalizeConfig{
+		Enabled:     true,
+		MaxPerRound: 10,
+	}
 
 	_, err = balances.InsertTrieNode(scConfigKey(ADDRESS), conf)
 	if err != nil {
@@ -875,34 +898,43 @@ func SetMockConfig(
 	}
 	var mockCost = 100
 	conf.Cost = map[string]int{
-		"cost.update_settings":           mockCost,
-		"cost.read_redeem":               mockCost,
-		"cost.commit_connection":         mockCost,
-		"cost.new_allocation_request":    mockCost,
-		"cost.update_allocation_request": mockCost,
-		"cost.finalize_allocation":       mockCost,
-		"cost.cancel_allocation":         mockCost,
-		"cost.add_free_storage_assigner": mockCost,
-		"cost.free_allocation_request":   mockCost,
-		"cost.blobber_health_check":      mockCost,
-		"cost.update_blobber_settings":   mockCost,
-		"cost.pay_blobber_block_rewards": mockCost,
-		"cost.challenge_response":        mockCost,
-		"cost.generate_challenge":        mockCost,
-		"cost.add_validator":             mockCost,
-		"cost.update_validator_settings": mockCost,
-		"cost.add_blobber":               mockCost,
-		"cost.read_pool_lock":            mockCost,
-		"cost.read_pool_unlock":          mockCost,
-		"cost.write_pool_lock":           mockCost,
-		"cost.stake_pool_lock":           mockCost,
-		"cost.stake_pool_unlock":         mockCost,
-		"cost.commit_settings_changes":   mockCost,
-		"cost.collect_reward":            mockCost,
-		"cost.kill_blobber":              mockCost,
-		"cost.kill_validator":            mockCost,
-		"cost.shutdown_blobber":          mockCost,
-		"cost.shutdown_validator":        mockCost,
+		"cost.update_settings":             mockCost,
+		"cost.read_redeem":                 mockCost,
+		"cost.commit_connection":           mockCost,
+		"cost.new_allocation_request":      mockCost,
+		"cost.update_allocation_request":   mockCost,
+		"cost.finalize_allocation":         mockCost,
+		"cost.finalize_expired_allocation": mockCost,
+		"cost.backfill_allocation_expiry":  mockCost,
+		"cost.cancel_allocation":           mockCost,
+		"cost.repair_allocation":           mockCost,
+		"cost.propose_allocation_owner":    mockCost,
+		"cost.accept_allocation_owner":     mockCost,
+		"cost.cancel_allocation_owner":     mockCost,
+		"cost.add_free_storage_assigner":   mockCost,
+		"cost.free_allocation_request":     mockCost,
+		"cost.blobber_health_check":        mockCost,
+		"cost.update_blobber_settings":     mockCost,
+		"cost.pay_blobber_block_rewards":   mockCost,
+		"cost.challenge_response":          mockCost,
+		"cost.challenge_response_batch":    mockCost,
+		"cost.generate_challenge":          mockCost,
+		"cost.add_validator":               mockCost,
+		"cost.update_validator_settings":   mockCost,
+		"cost.add_blobber":                 mockCost,
+		"cost.read_pool_lock":              mockCost,
+		"cost.read_pool_unlock":            mockCost,
+		"cost.write_pool_lock":             mockCost,
+		"cost.stake_pool_lock":             mockCost,
+		"cost.stake_pool_unlock":           mockCost,
+		"cost.redelegate":                  mockCost,
+		"cost.stake_pool_auto_compound":    mockCost,
+		"cost.commit_settings_changes":     mockCost,
+		"cost.collect_reward":              mockCost,
+		"cost.kill_blobber":                mockCost,
+		"cost.kill_validator":              mockCost,
+		"cost.shutdown_blobber":            mockCost,
+		"cost.shutdown_validator":          mockCost,
 	}
 	return
 }
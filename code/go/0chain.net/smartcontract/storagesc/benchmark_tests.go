@@ -109,6 +109,36 @@ func BenchmarkTests(
 	ssc.setSC(ssc.SmartContract, &smartcontract.BCContext{})
 
 	creationTime := common.Now()
+	// mockChallengeResponse answers the mock challenge of the first blobber
+	// of the first allocation.
+	mockChallengeResponse := func() ChallengeResponse {
+		var validationTickets []*ValidationTicket
+		//always use first NumBlobbersPerAllocation/2 validators the same we use for challenge creation.
+		//to randomize it we need to load challenge here, not sure if it's needed
+		for i := 0; i < viper.GetInt(bk.NumBlobbersPerAllocation)/2; i++ {
+			vt := &ValidationTicket{
+				ChallengeID:  getMockChallengeId(getMockBlobberId(0), getMockAllocationId(0)),
+				BlobberID:    getMockBlobberId(0),
+				ValidatorID:  data.ValidatorIds[i],
+				ValidatorKey: data.ValidatorPublicKeys[i],
+				Result:       true,
+				Message:      "mock message",
+				MessageCode:  "mock message code",
+				Timestamp:    creationTime,
+			}
+			hash := encryption.Hash(fmt.Sprintf("%v:%v:%v:%v:%v:%v", vt.ChallengeID, vt.BlobberID,
+				vt.ValidatorID, vt.ValidatorKey, vt.Result, vt.Timestamp))
+			_ = sigScheme.SetPublicKey(data.ValidatorPublicKeys[i])
+			sigScheme.SetPrivateKey(data.ValidatorPrivateKeys[i])
+			vt.Signature, _ = sigScheme.Sign(hash)
+			validationTickets = append(validationTickets, vt)
+		}
+		return ChallengeResponse{
+			ID:                getMockChallengeId(getMockBlobberId(0), getMockAllocationId(0)),
+			ValidationTickets: validationTickets,
+		}
+	}
+
 	timings := make(map[string]time.Duration)
 	newAllocationRequestF := func(
 		t *transaction.Transaction,
@@ -255,6 +285,105 @@ func BenchmarkTests(
 				return bytes
 			}(),
 		},
+		{
+			name:     "storage.finalize_expired_allocation",
+			endpoint: ssc.finalizeExpiredAllocation,
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				CreationDate: creationTime + benchAllocationExpire(creationTime) + 1,
+				ClientID:     data.Miners[0],
+				ToClientID:   ADDRESS,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&lockRequest{
+					AllocationID: getMockAllocationId(0),
+				})
+				return bytes
+			}(),
+		},
+		{
+			name:     "storage.repair_allocation",
+			endpoint: ssc.repairAllocation,
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				CreationDate: creationTime,
+				ClientID:     data.Clients[0],
+				ToClientID:   ADDRESS,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&repairAllocationRequest{
+					AllocationID: getMockAllocationId(0),
+					BlobberID:    getMockBlobberId(0),
+					Candidates:   []string{getMockBlobberId(1)},
+				})
+				return bytes
+			}(),
+		},
+		{
+			name:     "storage.propose_allocation_owner",
+			endpoint: ssc.proposeOwnershipTransfer,
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				CreationDate: creationTime,
+				ClientID:     data.Clients[0],
+				ToClientID:   ADDRESS,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&proposeOwnershipTransferRequest{
+					AllocationID: getMockAllocationId(0),
+					NewOwnerID:   data.Clients[1],
+					ExpiresAt:    creationTime + 60,
+				})
+				return bytes
+			}(),
+		},
+		{
+			name:     "storage.accept_allocation_owner",
+			endpoint: ssc.acceptOwnershipTransfer,
+			txn: func() *transaction.Transaction {
+				owner := getMockOwnerFromAllocationIndex(mockTransferAllocationIndex, len(data.Clients))
+				newOwner := getMockTransferOwnerIndex(owner, len(data.Clients))
+				return &transaction.Transaction{
+					HashIDField: datastore.HashIDField{
+						Hash: encryption.Hash("mock transaction hash"),
+					},
+					CreationDate: creationTime,
+					ClientID:     data.Clients[newOwner],
+					PublicKey:    data.PublicKeys[newOwner],
+					ToClientID:   ADDRESS,
+				}
+			}(),
+			input: func() []byte {
+				bytes, _ := json.Marshal(&ownershipTransferRequest{
+					AllocationID: getMockAllocationId(mockTransferAllocationIndex),
+				})
+				return bytes
+			}(),
+		},
+		{
+			name:     "storage.cancel_allocation_owner",
+			endpoint: ssc.cancelOwnershipTransfer,
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				CreationDate: creationTime,
+				ClientID:     data.Clients[getMockOwnerFromAllocationIndex(mockTransferAllocationIndex, len(data.Clients))],
+				ToClientID:   ADDRESS,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&ownershipTransferRequest{
+					AllocationID: getMockAllocationId(mockTransferAllocationIndex),
+				})
+				return bytes
+			}(),
+		},
 		{
 			name:     "storage.cancel_allocation",
 			endpoint: ssc.cancelAllocationRequest,
@@ -559,6 +688,34 @@ func BenchmarkTests(
 				return bytes
 			}(),
 		},
+		{
+			name:     "storage.redelegate",
+			endpoint: ssc.redelegate,
+			txn: &transaction.Transaction{
+				ClientID:     getMockBlobberStakePoolId(0, 0, data.Clients),
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: (&stakepool.RedelegateRequest{
+				ProviderType:   spenum.Blobber,
+				FromProviderID: getMockBlobberId(0),
+				ToProviderID:   getMockBlobberId(1),
+			}).Encode(),
+		},
+		{
+			name:     "storage.stake_pool_auto_compound",
+			endpoint: ssc.stakePoolAutoCompound,
+			txn: &transaction.Transaction{
+				ClientID:     getMockBlobberStakePoolId(0, 0, data.Clients),
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: (&stakepool.AutoCompoundRequest{
+				ProviderType: spenum.Blobber,
+				ProviderID:   getMockBlobberId(0),
+				AutoCompound: true,
+			}).Encode(),
+		},
 		{
 			name:     "storage.collect_reward",
 			endpoint: ssc.collectReward,
@@ -604,30 +761,20 @@ func BenchmarkTests(
 				CreationDate: creationTime,
 			},
 			input: func() []byte {
-				var validationTickets []*ValidationTicket
-				//always use first NumBlobbersPerAllocation/2 validators the same we use for challenge creation.
-				//to randomize it we need to load challenge here, not sure if it's needed
-				for i := 0; i < viper.GetInt(bk.NumBlobbersPerAllocation)/2; i++ {
-					vt := &ValidationTicket{
-						ChallengeID:  getMockChallengeId(getMockBlobberId(0), getMockAllocationId(0)),
-						BlobberID:    getMockBlobberId(0),
-						ValidatorID:  data.ValidatorIds[i],
-						ValidatorKey: data.ValidatorPublicKeys[i],
-						Result:       true,
-						Message:      "mock message",
-						MessageCode:  "mock message code",
-						Timestamp:    creationTime,
-					}
-					hash := encryption.Hash(fmt.Sprintf("%v:%v:%v:%v:%v:%v", vt.ChallengeID, vt.BlobberID,
-						vt.ValidatorID, vt.ValidatorKey, vt.Result, vt.Timestamp))
-					_ = sigScheme.SetPublicKey(data.ValidatorPublicKeys[i])
-					sigScheme.SetPrivateKey(data.ValidatorPrivateKeys[i])
-					vt.Signature, _ = sigScheme.Sign(hash)
-					validationTickets = append(validationTickets, vt)
-				}
-				bytes, _ := json.Marshal(&ChallengeResponse{
-					ID:                getMockChallengeId(getMockBlobberId(0), getMockAllocationId(0)),
-					ValidationTickets: validationTickets,
+				bytes, _ := json.Marshal(mockChallengeResponse())
+				return bytes
+			}(),
+		},
+		{
+			name:     "storage.challenge_response_batch",
+			endpoint: ssc.verifyChallengeBatch,
+			txn: &transaction.Transaction{
+				ClientID:     getMockBlobberId(0),
+				CreationDate: creationTime,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&ChallengeResponseBatch{
+					Responses: []ChallengeResponse{mockChallengeResponse()},
 				})
 				return bytes
 			}(),
@@ -732,30 +879,46 @@ func BenchmarkTests(
 					"block_reward.zeta.k":           "0.9",
 					"block_reward.zeta.mu":          "0.2",
 
-					"cost.update_settings":           "105",
-					"cost.read_redeem":               "105",
-					"cost.commit_connection":         "105",
-					"cost.new_allocation_request":    "105",
-					"cost.update_allocation_request": "105",
-					"cost.finalize_allocation":       "105",
-					"cost.cancel_allocation":         "105",
-					"cost.add_free_storage_assigner": "105",
-					"cost.free_allocation_request":   "105",
-					"cost.blobber_health_check":      "105",
-					"cost.update_blobber_settings":   "105",
-					"cost.pay_blobber_block_rewards": "105",
-					"cost.challenge_response":        "105",
-					"cost.generate_challenge":        "105",
-					"cost.add_validator":             "105",
-					"cost.update_validator_settings": "105",
-					"cost.add_blobber":               "105",
-					"cost.read_pool_lock":            "105",
-					"cost.read_pool_unlock":          "105",
-					"cost.write_pool_lock":           "105",
-					"cost.stake_pool_lock":           "105",
-					"cost.stake_pool_unlock":         "105",
-					"cost.commit_settings_changes":   "105",
-					"cost.collect_reward":            "105",
+					"reputation.decay":         "0.95",
+					"reputation.uptime_weight": "0.2",
+					"reputation.reward_weight": "0.5",
+
+					"auto_finalize.enabled":       "true",
+					"auto_finalize.max_per_round": "10",
+
+					"cost.update_settings":             "105",
+					"cost.read_redeem":                 "105",
+					"cost.commit_connection":           "105",
+					"cost.new_allocation_request":      "105",
+					"cost.update_allocation_request":   "105",
+					"cost.finalize_allocation":         "105",
+					"cost.finalize_expired_allocation": "105",
+					"cost.backfill_allocation_expiry":  "105",
+					"cost.cancel_allocation":           "105",
+					"cost.repair_allocation":           "105",
+					"cost.propose_allocation_owner":    "105",
+					"cost.accept_allocation_owner":     "105",
+					"cost.cancel_allocation_owner":     "105",
+					"cost.add_free_storage_assigner":   "105",
+					"cost.free_allocation_request":     "105",
+					"cost.blobber_health_check":        "105",
+					"cost.update_blobber_settings":     "105",
+					"cost.pay_blobber_block_rewards":   "105",
+					"cost.challenge_response":          "105",
+					"cost.challenge_response_batch":    "105",
+					"cost.generate_challenge":          "105",
+					"cost.add_validator":               "105",
+					"cost.update_validator_settings":   "105",
+					"cost.add_blobber":                 "105",
+					"cost.read_pool_lock":              "105",
+					"cost.read_pool_unlock":            "105",
+					"cost.write_pool_lock":             "105",
+					"cost.stake_pool_lock":             "105",
+					"cost.stake_pool_unlock":           "105",
+					"cost.redelegate":                  "105",
+					"cost.stake_pool_auto_compound":    "105",
+					"cost.commit_settings_changes":     "105",
+					"cost.collect_reward":              "105",
 				},
 			}).Encode(),
 		},
@@ -136,6 +136,18 @@ func validateBlobberUpdateSettings(updateBlobberRequest *dto.StorageDtoNode, con
 		return err
 	}
 
+	if updateBlobberRequest.Region != nil {
+		if err := validateBlobberLabel("region", *updateBlobberRequest.Region); err != nil {
+			return err
+		}
+	}
+
+	if updateBlobberRequest.Operator != nil {
+		if err := validateBlobberLabel("operator", *updateBlobberRequest.Operator); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -238,11 +250,23 @@ func (sc *StorageSmartContract) updateBlobber(
 		}
 	}
 
+	// the labels are checked against the placement constraints of the
+	// allocations the blobber joins afterwards, not of the ones it serves
 	actErr := cstate.WithActivation(balances, "artemis", func() (e error) { return },
 		func() error {
-			return existingBlobber.Update(&storageNodeV2{}, func(e entitywrapper.EntityI) error {
-				b := e.(*storageNodeV2)
-				b.IsRestricted = updateBlobber.IsRestricted
+			return existingBlobber.Update(newStorageNodeEntity(balances), func(e entitywrapper.EntityI) error {
+				switch b := e.(type) {
+				case *storageNodeV2:
+					b.IsRestricted = updateBlobber.IsRestricted
+				case *storageNodeV3:
+					b.IsRestricted = updateBlobber.IsRestricted
+					if updateBlobber.Region != nil {
+						b.Region = *updateBlobber.Region
+					}
+					if updateBlobber.Operator != nil {
+						b.Operator = *updateBlobber.Operator
+					}
+				}
 				return nil
 			})
 		})
@@ -386,12 +410,12 @@ func (sc *StorageSmartContract) addBlobber(t *transaction.Transaction,
 	}
 
 	afterArtemis := func() error {
-		b := storageNodeV2{}
-		if err := json.Unmarshal(input, &b); err != nil {
+		b := newStorageNodeEntity(balances)
+		if err := json.Unmarshal(input, b); err != nil {
 			return common.NewError("add_or_update_blobber_failed",
 				"malformed request: "+err.Error())
 		}
-		blobber.SetEntity(&b)
+		blobber.SetEntity(b)
 		return nil
 	}
 
@@ -489,9 +513,10 @@ func (sc *StorageSmartContract) blobberHealthCheck(t *transaction.Transaction,
 	_ []byte, balances cstate.StateContextI,
 ) (string, error) {
 	var (
-		blobber  *StorageNode
-		downtime uint64
-		err      error
+		blobber         *StorageNode
+		downtime        uint64
+		lastHealthCheck common.Timestamp
+		err             error
 	)
 	if blobber, err = sc.getBlobber(t.ClientID, balances); err != nil {
 		return "", common.NewError("blobber_health_check_failed",
@@ -505,12 +530,17 @@ func (sc *StorageSmartContract) blobberHealthCheck(t *transaction.Transaction,
 	//nolint:errcheck
 	blobber.mustUpdateBase(func(b *storageNodeBase) error {
 		downtime = common.Downtime(b.LastHealthCheck, t.CreationDate, conf.HealthCheckPeriod)
+		lastHealthCheck = b.LastHealthCheck
 		b.LastHealthCheck = t.CreationDate
 		return nil
 	})
 
 	emitBlobberHealthCheck(blobber, downtime, balances)
 
+	if err := recordHealthCheck(t.ClientID, lastHealthCheck, t.CreationDate, downtime, balances); err != nil {
+		return "", common.NewError("blobber_health_check_failed", err.Error())
+	}
+
 	_, err = balances.InsertTrieNode(blobber.GetKey(),
 		blobber)
 	if err != nil {
@@ -634,7 +664,7 @@ func (sc *StorageSmartContract) commitBlobberRead(t *transaction.Transaction,
 	alloc.Stats.NumReads++
 
 	resp, err = rp.moveToBlobber(commitRead.ReadMarker.AllocationID,
-		commitRead.ReadMarker.BlobberID, sp, value, balances)
+		commitRead.ReadMarker.BlobberID, sp, value, conf.MaxStake, balances)
 	if err != nil {
 		return "", common.NewErrorf("commit_blobber_read",
 			"can't transfer tokens from read pool to stake pool: %v", err)
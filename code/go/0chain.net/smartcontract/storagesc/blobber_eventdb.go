@@ -33,9 +33,10 @@ func emitUpdateBlobber(sn *StorageNode, sp *stakePool, balances cstate.StateCont
 		OffersTotal: sp.TotalOffers,
 	}
 
-	if v2, ok := sn.Entity().(*storageNodeV2); ok && v2.IsRestricted != nil {
-		data.IsRestricted = *v2.IsRestricted
-	}
+	data.IsRestricted = sn.restricted()
+	labels := sn.labels()
+	data.Region = labels.Region
+	data.Operator = labels.Operator
 
 	balances.EmitEvent(event.TypeStats, event.TagUpdateBlobber, b.ID, data)
 	return nil
@@ -76,11 +77,10 @@ func emitAddBlobber(sn *StorageNode, sp *stakePool, balances cstate.StateContext
 		CreationRound: balances.GetBlock().Round,
 	}
 
-	if v2, ok := sn.Entity().(*storageNodeV2); ok {
-		if v2.IsRestricted != nil {
-			data.IsRestricted = *v2.IsRestricted
-		}
-	}
+	data.IsRestricted = sn.restricted()
+	labels := sn.labels()
+	data.Region = labels.Region
+	data.Operator = labels.Operator
 
 	balances.EmitEvent(event.TypeStats, event.TagAddBlobber, b.ID, data)
 	return nil
@@ -108,3 +108,10 @@ func emitBlobberHealthCheck(sn *StorageNode, downtime uint64, balances cstate.St
 
 	balances.EmitEvent(event.TypeStats, event.TagBlobberHealthCheck, b.ID, data)
 }
+
+func emitUpdateBlobberReputation(blobberID string, score float64, balances cstate.StateContextI) {
+	balances.EmitEvent(event.TypeStats, event.TagUpdateBlobberReputation, blobberID, event.Blobber{
+		Provider:   event.Provider{ID: blobberID},
+		Reputation: score,
+	})
+}
@@ -232,7 +232,7 @@ func (ssc *StorageSmartContract) blobberBlockRewards(t *transaction.Transaction,
 				zap.String("block_hash", balances.GetBlock().Hash))
 
 			if err := qsp.DistributeRewards(
-				reward, qualifyingBlobberIds[i], spenum.Blobber, spenum.BlockRewardBlobber, balances); err != nil {
+				reward, qualifyingBlobberIds[i], spenum.Blobber, spenum.BlockRewardBlobber, conf.MaxStake, balances); err != nil {
 				return common.NewError("blobber_block_rewards_failed", "minting capacity reward"+err.Error())
 			}
 
@@ -251,7 +251,7 @@ func (ssc *StorageSmartContract) blobberBlockRewards(t *transaction.Transaction,
 
 		if rShare > 0 {
 			for i := range stakePools {
-				if err := stakePools[i].DistributeRewards(rShare, qualifyingBlobberIds[i], spenum.Blobber, spenum.BlockRewardBlobber, balances); err != nil {
+				if err := stakePools[i].DistributeRewards(rShare, qualifyingBlobberIds[i], spenum.Blobber, spenum.BlockRewardBlobber, conf.MaxStake, balances); err != nil {
 					return common.NewError("blobber_block_rewards_failed", "minting capacity reward"+err.Error())
 				}
 			}
@@ -259,7 +259,7 @@ func (ssc *StorageSmartContract) blobberBlockRewards(t *transaction.Transaction,
 
 		if rl > 0 {
 			for i := 0; i < int(rl); i++ {
-				if err := stakePools[i].DistributeRewards(1, qualifyingBlobberIds[i], spenum.Blobber, spenum.BlockRewardBlobber, balances); err != nil {
+				if err := stakePools[i].DistributeRewards(1, qualifyingBlobberIds[i], spenum.Blobber, spenum.BlockRewardBlobber, conf.MaxStake, balances); err != nil {
 					return common.NewError("blobber_block_rewards_failed", "minting capacity reward"+err.Error())
 				}
 			}
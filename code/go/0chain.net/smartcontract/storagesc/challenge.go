@@ -157,12 +157,18 @@ func (sc *StorageSmartContract) blobberReward(
 		return err
 	}
 
+	// the reputation of the blobber scales its part of the reward
+	blobberReward, err = withholdByReputation(alloc, blobAlloc, cp, blobberReward, conf, balances)
+	if err != nil {
+		return fmt.Errorf("blobber reward failed: %v", err)
+	}
+
 	var sp *stakePool
 	if sp, err = sc.getStakePool(spenum.Blobber, blobAlloc.BlobberID, balances); err != nil {
 		return fmt.Errorf("can't get stake pool: %v", err)
 	}
 
-	err = cp.moveToBlobbers(sc.ID, blobberReward, blobAlloc.BlobberID, sp, balances, allocationID)
+	err = cp.moveToBlobbers(sc.ID, blobberReward, blobAlloc.BlobberID, sp, conf.MaxStake, balances, allocationID)
 	if err != nil {
 		return fmt.Errorf("rewarding blobbers: %v", err)
 	}
@@ -179,7 +185,7 @@ func (sc *StorageSmartContract) blobberReward(
 		return err
 	}
 
-	err = cp.moveToValidators(validatorsReward, validators, vsps, balances, allocationID)
+	err = cp.moveToValidators(validatorsReward, validators, vsps, conf.MaxStake, balances, allocationID)
 	if err != nil {
 		return fmt.Errorf("rewarding validators: %v", err)
 	}
@@ -305,7 +311,7 @@ func (sc *StorageSmartContract) blobberPenalty(
 	}
 
 	// validators reward
-	err = cp.moveToValidators(validatorsReward, validators, vSPs, balances, allocationID)
+	err = cp.moveToValidators(validatorsReward, validators, vSPs, conf.MaxStake, balances, allocationID)
 	if err != nil {
 		return fmt.Errorf("rewarding validators: %v", err)
 	}
@@ -408,60 +414,79 @@ func (sc *StorageSmartContract) verifyChallenge(t *transaction.Transaction,
 		return "", common.NewErrorf(errCode, "failed to decode txn input: %v", err)
 	}
 
+	cab, resp, err := sc.prepareChallengeResponse(t, &challResp, conf, balances)
+	if err != nil {
+		return resp, err
+	}
+
+	return sc.processChallengeResponse(t, conf, cab, balances)
+}
+
+// prepareChallengeResponse checks the response to a challenge and loads
+// the data needed to process it. It doesn't change the state, so a
+// rejected response can be skipped.
+func (sc *StorageSmartContract) prepareChallengeResponse(
+	t *transaction.Transaction,
+	challResp *ChallengeResponse,
+	conf *Config,
+	balances cstate.StateContextI,
+) (*challengeAllocBlobberPassResult, string, error) {
+	const errCode = "verify_challenge"
+
 	if len(challResp.ID) == 0 || len(challResp.ValidationTickets) == 0 {
-		return "", common.NewError(errCode, "invalid parameters to challenge response")
+		return nil, "", common.NewError(errCode, "invalid parameters to challenge response")
 	}
 
 	// get challenge node
 	challenge, err := sc.getStorageChallenge(challResp.ID, balances)
 	if err != nil {
-		return "", common.NewErrorf(errCode, "could not find challenge, %v", err)
+		return nil, "", common.NewErrorf(errCode, "could not find challenge, %v", err)
 	}
 	if challenge.Responded != int64(ChallengeNotResponded) {
-		return "", common.NewError(errCode, "challenge already processed")
+		return nil, "", common.NewError(errCode, "challenge already processed")
 	}
 
 	currentRound := balances.GetBlock().Round
 	if challenge.RoundCreatedAt+conf.MaxChallengeCompletionRounds <= currentRound {
-		return "", common.NewError(errCode, "challenge expired")
+		return nil, "", common.NewError(errCode, "challenge expired")
 	}
 
 	if challenge.BlobberID != t.ClientID {
-		return "", errors.New("challenge blobber id does not match")
+		return nil, "", errors.New("challenge blobber id does not match")
 	}
 
 	logging.Logger.Info("time_taken: receive challenge response",
 		zap.String("challenge_id", challenge.ID),
 		zap.Duration("delay", time.Since(common.ToTime(challenge.Created))))
 
-	result, err := verifyChallengeTickets(balances, challenge, &challResp)
+	result, err := verifyChallengeTickets(balances, challenge, challResp)
 	if err != nil {
-		return "", common.NewError(errCode, err.Error())
+		return nil, "", common.NewError(errCode, err.Error())
 	}
 
 	allocChallenges, err := sc.getAllocationChallenges(challenge.AllocationID, balances)
 	if err != nil {
-		return "", common.NewErrorf(errCode, "could not find allocation challenges, %v", err)
+		return nil, "", common.NewErrorf(errCode, "could not find allocation challenges, %v", err)
 	}
 
 	alloc, err := sc.getAllocation(challenge.AllocationID, balances)
 	if err != nil {
-		return "", common.NewErrorf(errCode,
+		return nil, "", common.NewErrorf(errCode,
 			"can't get related allocation: %v", err)
 	}
 
 	if t.CreationDate > alloc.Expiration {
-		return "", common.NewError(errCode, "allocation is finalized")
+		return nil, "", common.NewError(errCode, "allocation is finalized")
 	}
 
 	blobAlloc, ok := alloc.BlobberAllocsMap[t.ClientID]
 	if !ok {
-		return "", common.NewError(errCode, "blobber is not part of the allocation")
+		return nil, "", common.NewError(errCode, "blobber is not part of the allocation")
 	}
 
 	_, ok = allocChallenges.ChallengeMap[challResp.ID]
 	if !ok {
-		return "", common.NewErrorf(errCode,
+		return nil, "", common.NewErrorf(errCode,
 			"could not find the challenge with ID %s", challResp.ID)
 	}
 
@@ -469,7 +494,7 @@ func (sc *StorageSmartContract) verifyChallenge(t *transaction.Transaction,
 	latestSuccessfulChallTime := blobAlloc.LatestSuccessfulChallCreatedAt
 
 	if challenge.Created < latestFinalizedChallTime {
-		return "old challenge response", common.NewError(errCode, "old challenge response")
+		return nil, "old challenge response", common.NewError(errCode, "old challenge response")
 	}
 
 	challenge.Responded = int64(ChallengeResponded)
@@ -483,7 +508,18 @@ func (sc *StorageSmartContract) verifyChallenge(t *transaction.Transaction,
 		latestFinalizedChallTime:  latestFinalizedChallTime,
 	}
 
-	if !(result.pass) {
+	return cab, "", nil
+}
+
+// processChallengeResponse rewards or penalises the blobber for a prepared
+// challenge response.
+func (sc *StorageSmartContract) processChallengeResponse(
+	t *transaction.Transaction,
+	conf *Config,
+	cab *challengeAllocBlobberPassResult,
+	balances cstate.StateContextI,
+) (string, error) {
+	if !(cab.pass) {
 		return sc.challengeFailed(balances, cab)
 	}
 
@@ -508,6 +544,53 @@ type challengeAllocBlobberPassResult struct {
 	latestFinalizedChallTime  common.Timestamp
 }
 
+// verifyAggregatedChallengeTickets verifies the tickets of a response
+// against one aggregated signature instead of a signature per ticket.
+// The validators sign the tickets' common message, so the pairing is
+// computed once per result rather than once per validator.
+func verifyAggregatedChallengeTickets(
+	challenge *StorageChallenge,
+	cr *ChallengeResponse,
+	threshold int,
+) (*verifyTicketsResult, error) {
+	var (
+		success    int
+		validators = make([]string, len(cr.ValidationTickets))
+		verifier   = encryption.NewBLS0ChainAggregateVerifier()
+	)
+
+	for i, vt := range cr.ValidationTickets {
+		if err := vt.Validate(challenge.ID, challenge.BlobberID); err != nil {
+			return nil, fmt.Errorf("invalid validation ticket: %v", err)
+		}
+
+		ss := encryption.NewBLS0ChainScheme()
+		if err := ss.SetPublicKey(vt.ValidatorKey); err != nil {
+			return nil, fmt.Errorf("invalid validation ticket: %v", err)
+		}
+
+		if err := verifier.AddSigner(ss, vt.aggregateHash()); err != nil {
+			return nil, fmt.Errorf("invalid validation ticket: %v", err)
+		}
+
+		validators[i] = vt.ValidatorID
+		if vt.Result {
+			success++
+		}
+	}
+
+	if ok, err := verifier.Verify(cr.AggregatedSignature); !ok || err != nil {
+		return nil, fmt.Errorf("invalid aggregated signature: %v", err)
+	}
+
+	return &verifyTicketsResult{
+		pass:       success > threshold,
+		threshold:  threshold,
+		success:    success,
+		validators: validators,
+	}, nil
+}
+
 func verifyChallengeTickets(balances cstate.StateContextI,
 	challenge *StorageChallenge,
 	cr *ChallengeResponse,
@@ -536,6 +619,17 @@ func verifyChallengeTickets(balances cstate.StateContextI,
 		return nil, fmt.Errorf("validation tickets less than threshold: %d, tickets: %d", threshold, tksNum)
 	}
 
+	if cr.AggregatedSignature != "" {
+		var result *verifyTicketsResult
+		actErr := cstate.WithActivation(balances, "hermes", func() error {
+			return errors.New("aggregated validation tickets are not supported yet")
+		}, func() (err error) {
+			result, err = verifyAggregatedChallengeTickets(challenge, cr, threshold)
+			return err
+		})
+		return result, actErr
+	}
+
 	var (
 		success, failure int32
 		validators       = make([]string, len(cr.ValidationTickets)) // validators for rewards
@@ -677,6 +771,10 @@ func (sc *StorageSmartContract) processChallengePassed(
 	cab.blobAlloc.Stats.SuccessChallenges++
 	cab.blobAlloc.Stats.OpenChallenges--
 
+	if err := recordChallengeOutcome(cab.blobAlloc.BlobberID, true, balances); err != nil {
+		return "", common.NewError("verify_challenge", err.Error())
+	}
+
 	if err := cab.challenge.Save(balances, sc.ID); err != nil {
 		return "", common.NewError("verify_challenge_error", err.Error())
 	}
@@ -760,6 +858,10 @@ func (sc *StorageSmartContract) processChallengeFailed(
 	cab.blobAlloc.Stats.FailedChallenges++
 	cab.blobAlloc.Stats.OpenChallenges--
 
+	if err := cab.alloc.recordFailedChallenge(cab.blobAlloc.BlobberID, balances); err != nil {
+		return "", common.NewError("challenge_penalty_error", err.Error())
+	}
+
 	err := emitUpdateChallenge(cab.challenge, false, ChallengeRespondedInvalid, balances, cab.alloc.Stats)
 	if err != nil {
 		return "", err
@@ -0,0 +1,124 @@
+package storagesc
+
+import (
+	"encoding/json"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+)
+
+// maxChallengeResponseBatchSize limits the number of challenges one
+// challenge_response_batch transaction can answer.
+const maxChallengeResponseBatchSize = 100
+
+// ChallengeResponseBatch answers many challenges of one blobber in a
+// single transaction.
+type ChallengeResponseBatch struct {
+	Responses []ChallengeResponse `json:"responses"`
+}
+
+// challengeResponseResult is the outcome of one response of a batch.
+// A rejected response has the Error set and leaves its challenge open.
+type challengeResponseResult struct {
+	ChallengeID string `json:"challenge_id"`
+	Passed      bool   `json:"passed"`
+	Result      string `json:"result,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// challengeResponseBatchSize returns the number of responses of an
+// encoded batch, zero if the input can't be decoded.
+func challengeResponseBatchSize(input []byte) int {
+	var batch struct {
+		Responses []json.RawMessage `json:"responses"`
+	}
+	if err := json.Unmarshal(input, &batch); err != nil {
+		return 0
+	}
+	return len(batch.Responses)
+}
+
+// verifyChallengeBatch verifies the challenge responses of a batch one by
+// one. Each response is rewarded or penalised independently; a response
+// that can't be accepted, e.g. because its challenge has expired, is
+// rejected without affecting the others.
+func (sc *StorageSmartContract) verifyChallengeBatch(
+	t *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	const errCode = "verify_challenge_batch"
+
+	conf, err := sc.getConfig(balances, true)
+	if err != nil {
+		return "", common.NewErrorf(errCode,
+			"cannot get smart contract configurations: %v", err)
+	}
+
+	var batch ChallengeResponseBatch
+	if err := json.Unmarshal(input, &batch); err != nil {
+		return "", common.NewErrorf(errCode, "failed to decode txn input: %v", err)
+	}
+
+	if len(batch.Responses) == 0 {
+		return "", common.NewError(errCode, "empty challenge response batch")
+	}
+
+	if len(batch.Responses) > maxChallengeResponseBatchSize {
+		return "", common.NewErrorf(errCode, "too many challenge responses: %d > %d",
+			len(batch.Responses), maxChallengeResponseBatchSize)
+	}
+
+	results := make([]challengeResponseResult, 0, len(batch.Responses))
+	seen := make(map[string]struct{}, len(batch.Responses))
+	for i := range batch.Responses {
+		cr := &batch.Responses[i]
+		result := challengeResponseResult{ChallengeID: cr.ID}
+
+		if _, ok := seen[cr.ID]; ok {
+			result.Error = "duplicate challenge response"
+			results = append(results, result)
+			continue
+		}
+		seen[cr.ID] = struct{}{}
+
+		cab, resp, err := sc.prepareChallengeResponse(t, cr, conf, balances)
+		if err != nil {
+			result.Result = resp
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+
+		// the state may be changed partially, so the whole batch fails
+		resp, err = sc.processChallengeResponse(t, conf, cab, balances)
+		if err != nil {
+			return "", common.NewErrorf(errCode, "processing challenge %s: %v", cr.ID, err)
+		}
+
+		result.Passed = cab.pass
+		result.Result = resp
+		results = append(results, result)
+	}
+
+	out, err := json.Marshal(results)
+	if err != nil {
+		return "", common.NewErrorf(errCode, "encoding results: %v", err)
+	}
+	return string(out), nil
+}
+
+// ScaleCost makes the cost of a batched challenge response proportional
+// to the number of responses in the batch.
+func (sc *StorageSmartContract) ScaleCost(funcName string, input []byte, cost int) int {
+	if funcName != "challenge_response_batch" {
+		return cost
+	}
+
+	n := challengeResponseBatchSize(input)
+	if n < 1 {
+		n = 1
+	}
+	return cost * n
+}
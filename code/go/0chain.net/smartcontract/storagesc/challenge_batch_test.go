@@ -0,0 +1,134 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/smartcontract/partitions"
+	"github.com/stretchr/testify/require"
+)
+
+// prepareBatchChallenges sets up an allocation with 100MB written to one
+// of its blobbers and 10 validators, on the in memory state.
+func prepareBatchChallenges(t *testing.T) (*StorageSmartContract, *testBalances, int64,
+	*StorageAllocation, *Client, []*Client, *partitions.Partitions, *StorageNode) {
+	var (
+		ssc      = newTestStorageSC()
+		balances = newTestBalances(t, false)
+		client   = newClient(2000*x10, balances)
+		tp       = int64(1000)
+	)
+
+	allocID, blobs := addAllocation(t, ssc, client, tp, 0, 0, 0, 0, 0, balances, false)
+	alloc, err := ssc.getAllocation(allocID, balances)
+	require.NoError(t, err)
+
+	b := testGetBlobber(blobs, alloc, 0)
+	require.NotNil(t, b)
+
+	valids, tp := testAddValidators(t, balances, ssc, 10, tp)
+	_, tp = testCommitWrite(t, balances, client, allocID, "alloc-root-1", 100*1024*1024, tp, b.id, ssc, "")
+
+	alloc, err = ssc.getAllocation(allocID, balances)
+	require.NoError(t, err)
+	validators, err := getValidatorsList(balances)
+	require.NoError(t, err)
+	blobber, err := ssc.getBlobber(b.id, balances)
+	require.NoError(t, err)
+	return ssc, balances, tp, alloc, b, valids, validators, blobber
+}
+
+func TestVerifyChallengeBatch(t *testing.T) {
+	ssc, balances, tp, alloc, b, valids, validators, blobber := prepareBatchChallenges(t)
+	tp += 10
+
+	bk := &block.Block{}
+	bk.Round = 500
+	balances.setBlock(t, bk)
+
+	challIDs := []string{"chall-0", "chall-1", "chall-2"}
+	for i, id := range challIDs {
+		genChall(t, ssc, tp+int64(i), bk.Round-100+int64(i), id, 0, validators, alloc.ID, blobber, balances)
+	}
+
+	response := func(challID string, ok bool) ChallengeResponse {
+		cr := ChallengeResponse{ID: challID}
+		for _, v := range valids {
+			cr.ValidationTickets = append(cr.ValidationTickets, v.validTicket(t, challID, b.id, ok, tp))
+		}
+		return cr
+	}
+	passed := response("chall-0", true)
+	failed := response("chall-1", false)
+	unknown := response("unknown", true)
+
+	tx := newTransaction(b.id, ssc.ID, 0, tp)
+	balances.setTransaction(t, tx)
+
+	resp, err := ssc.verifyChallengeBatch(tx, mustEncode(t, &ChallengeResponseBatch{
+		Responses: []ChallengeResponse{passed, unknown, passed, failed},
+	}), balances)
+	require.NoError(t, err)
+
+	var results []challengeResponseResult
+	require.NoError(t, json.Unmarshal([]byte(resp), &results))
+	require.Len(t, results, 4)
+
+	require.Equal(t, challengeResponseResult{
+		ChallengeID: "chall-0",
+		Passed:      true,
+		Result:      "challenge passed by blobber",
+	}, results[0])
+
+	require.Equal(t, "unknown", results[1].ChallengeID)
+	require.False(t, results[1].Passed)
+	require.NotEmpty(t, results[1].Error)
+
+	require.Equal(t, challengeResponseResult{
+		ChallengeID: "chall-0",
+		Error:       "duplicate challenge response",
+	}, results[2])
+
+	require.Equal(t, challengeResponseResult{
+		ChallengeID: "chall-1",
+		Result:      "Challenge Failed by Blobber",
+	}, results[3])
+
+	// the rejected responses don't stop the others, the unanswered
+	// challenge stays open
+	allocChallenges, err := ssc.getAllocationChallenges(alloc.ID, balances)
+	require.NoError(t, err)
+	require.Len(t, allocChallenges.OpenChallenges, 1)
+	require.Equal(t, "chall-2", allocChallenges.OpenChallenges[0].ID)
+
+	alloc, err = ssc.getAllocation(alloc.ID, balances)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), alloc.Stats.SuccessChallenges)
+	require.Equal(t, int64(1), alloc.Stats.FailedChallenges)
+	require.Equal(t, int64(1), alloc.Stats.OpenChallenges)
+
+	// the answered challenges can't be answered again
+	resp, err = ssc.verifyChallengeBatch(tx, mustEncode(t, &ChallengeResponseBatch{
+		Responses: []ChallengeResponse{passed, response("chall-2", true)},
+	}), balances)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal([]byte(resp), &results))
+	require.Len(t, results, 2)
+	require.NotEmpty(t, results[0].Error)
+	require.True(t, results[1].Passed)
+
+	_, err = ssc.verifyChallengeBatch(tx, mustEncode(t, &ChallengeResponseBatch{}), balances)
+	require.Error(t, err)
+}
+
+func TestChallengeResponseBatchCost(t *testing.T) {
+	ssc := &StorageSmartContract{}
+
+	input := mustEncode(t, &ChallengeResponseBatch{
+		Responses: make([]ChallengeResponse, 3),
+	})
+	require.Equal(t, 300, ssc.ScaleCost("challenge_response_batch", input, 100))
+	require.Equal(t, 100, ssc.ScaleCost("challenge_response_batch", []byte("invalid"), 100))
+	require.Equal(t, 100, ssc.ScaleCost("challenge_response", input, 100))
+}
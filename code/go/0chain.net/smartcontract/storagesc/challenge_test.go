@@ -2312,3 +2312,58 @@ func confirmBlobberRewardOnFinalization(
 
 	return blobberReward
 }
+
+func TestVerifyAggregatedChallengeTickets(t *testing.T) {
+	balances := newTestBalances(t, false)
+
+	challenge := &StorageChallenge{
+		ID:              "challenge_id",
+		BlobberID:       "blobber_id",
+		TotalValidators: 4,
+		ValidatorIDMap:  make(map[string]struct{}),
+	}
+
+	var (
+		cr         = &ChallengeResponse{ID: challenge.ID}
+		signatures []string
+	)
+	for i := 0; i < challenge.TotalValidators; i++ {
+		valid := newClient(0, balances)
+		challenge.ValidatorIDMap[valid.id] = struct{}{}
+
+		vt := valid.validTicket(t, challenge.ID, challenge.BlobberID, i != 0, 0)
+		vt.Signature = ""
+		sig, err := valid.scheme.Sign(vt.aggregateHash())
+		require.NoError(t, err)
+
+		cr.ValidationTickets = append(cr.ValidationTickets, vt)
+		signatures = append(signatures, sig)
+	}
+
+	var err error
+	cr.AggregatedSignature, err = encryption.NewBLS0ChainScheme().AggregateSignatures(signatures)
+	require.NoError(t, err)
+
+	result, err := verifyChallengeTickets(balances, challenge, cr)
+	require.NoError(t, err)
+	require.True(t, result.pass)
+	require.Equal(t, 3, result.success)
+	require.Len(t, result.validators, challenge.TotalValidators)
+
+	t.Run("tampered result", func(t *testing.T) {
+		cr.ValidationTickets[0].Result = true
+		defer func() { cr.ValidationTickets[0].Result = false }()
+
+		_, err := verifyChallengeTickets(balances, challenge, cr)
+		require.Error(t, err)
+	})
+
+	t.Run("not active", func(t *testing.T) {
+		h := cstate.NewHardFork("hermes", 10)
+		_, err := balances.InsertTrieNode(h.GetKey(), h)
+		require.NoError(t, err)
+
+		_, err = verifyChallengeTickets(balances, challenge, cr)
+		require.Error(t, err)
+	})
+}
@@ -95,6 +95,7 @@ func (cp *challengePool) moveToValidators(
 	reward currency.Coin,
 	validators []datastore.Key,
 	vSPs []*stakePool,
+	maxStake currency.Coin,
 	balances cstate.StateContextI,
 	allocationID string,
 ) error {
@@ -112,7 +113,7 @@ func (cp *challengePool) moveToValidators(
 	}
 
 	for i, sp := range vSPs {
-		err := sp.DistributeRewards(oneReward, validators[i], spenum.Validator, spenum.ValidationReward, balances, allocationID)
+		err := sp.DistributeRewards(oneReward, validators[i], spenum.Validator, spenum.ValidationReward, maxStake, balances, allocationID)
 		if err != nil {
 			return fmt.Errorf("moving to validator %s: %v",
 				validators[i], err)
@@ -120,7 +121,7 @@ func (cp *challengePool) moveToValidators(
 	}
 	if bal > 0 {
 		for i := 0; i < int(bal); i++ {
-			err := vSPs[i].DistributeRewards(1, validators[i], spenum.Validator, spenum.ValidationReward, balances, allocationID)
+			err := vSPs[i].DistributeRewards(1, validators[i], spenum.Validator, spenum.ValidationReward, maxStake, balances, allocationID)
 			if err != nil {
 				return fmt.Errorf("moving to validator %s: %v",
 					validators[i], err)
@@ -135,6 +136,7 @@ func (cp *challengePool) moveToValidators(
 func (cp *challengePool) moveToBlobbers(sscKey string, reward currency.Coin,
 	blobberId datastore.Key,
 	sp *stakePool,
+	maxStake currency.Coin,
 	balances cstate.StateContextI,
 	allocationID string,
 ) error {
@@ -147,7 +149,7 @@ func (cp *challengePool) moveToBlobbers(sscKey string, reward currency.Coin,
 		return fmt.Errorf("not enough tokens in challenge pool: %v < %v", cp.Balance, reward)
 	}
 
-	err := sp.DistributeRewards(reward, blobberId, spenum.Blobber, spenum.ChallengePassReward, balances, allocationID)
+	err := sp.DistributeRewards(reward, blobberId, spenum.Blobber, spenum.ChallengePassReward, maxStake, balances, allocationID)
 	if err != nil {
 		return fmt.Errorf("can't move tokens to blobber: %v", err)
 	}
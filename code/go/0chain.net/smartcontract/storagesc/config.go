@@ -64,6 +64,26 @@ type blockRewardZeta struct {
 	Mu float64 `json:"mu"`
 }
 
+type reputationConfig struct {
+	// Decay is the weight (value in [0; 1) range) the challenge and health
+	// check history keeps when a new outcome is recorded.
+	Decay float64 `json:"decay"`
+	// UptimeWeight is the share (value in [0; 1] range) of the uptime in
+	// the reputation score, the rest is the challenge pass ratio.
+	UptimeWeight float64 `json:"uptime_weight"`
+	// RewardWeight is the share (value in [0; 1] range) of a challenge
+	// reward scaled by the blobber's reputation score. Zero turns the
+	// scaling off.
+	RewardWeight float64 `json:"reward_weight"`
+}
+
+type autoFinalizeConfig struct {
+	// Enabled lets the block generators finalize the expired allocations.
+	Enabled bool `json:"enabled"`
+	// MaxPerRound is the max number of allocations finalized by a block.
+	MaxPerRound int `json:"max_per_round"`
+}
+
 func newConfig() *Config {
 	return &Config{
 		ReadPool:               &readPoolConfig{},
@@ -148,6 +168,12 @@ type Config struct {
 
 	BlockReward *blockReward `json:"block_reward"`
 
+	// Reputation of blobbers derived from their challenge history.
+	Reputation reputationConfig `json:"reputation"`
+
+	// AutoFinalize of the expired allocations by the block generators.
+	AutoFinalize autoFinalizeConfig `json:"auto_finalize"`
+
 	OwnerId string         `json:"owner_id"`
 	Cost    map[string]int `json:"cost"`
 }
@@ -250,6 +276,23 @@ func (conf *Config) validate() (err error) {
 		return fmt.Errorf("owner_id does not set or empty")
 	}
 
+	if conf.Reputation.Decay < 0 || conf.Reputation.Decay >= 1 {
+		return fmt.Errorf("reputation.decay not in [0; 1) range: %v",
+			conf.Reputation.Decay)
+	}
+	if conf.Reputation.UptimeWeight < 0 || conf.Reputation.UptimeWeight > 1 {
+		return fmt.Errorf("reputation.uptime_weight not in [0; 1] range: %v",
+			conf.Reputation.UptimeWeight)
+	}
+	if conf.Reputation.RewardWeight < 0 || conf.Reputation.RewardWeight > 1 {
+		return fmt.Errorf("reputation.reward_weight not in [0; 1] range: %v",
+			conf.Reputation.RewardWeight)
+	}
+	if conf.AutoFinalize.Enabled && conf.AutoFinalize.MaxPerRound < 1 {
+		return fmt.Errorf("invalid auto_finalize.max_per_round (< 1): %v",
+			conf.AutoFinalize.MaxPerRound)
+	}
+
 	if conf.BlockReward.Gamma.A <= 0 {
 		return fmt.Errorf("invalid block_reward.gamma.a <= 0: %v", conf.BlockReward.Gamma.A)
 	}
@@ -476,6 +519,13 @@ func getConfiguredConfig() (conf *Config, err error) {
 	conf.BlockReward.Zeta.K = scc.GetFloat64(pfx + "block_reward.zeta.k")
 	conf.BlockReward.Zeta.Mu = scc.GetFloat64(pfx + "block_reward.zeta.mu")
 
+	conf.Reputation.Decay = scc.GetFloat64(pfx + "reputation.decay")
+	conf.Reputation.UptimeWeight = scc.GetFloat64(pfx + "reputation.uptime_weight")
+	conf.Reputation.RewardWeight = scc.GetFloat64(pfx + "reputation.reward_weight")
+
+	conf.AutoFinalize.Enabled = scc.GetBool(pfx + "auto_finalize.enabled")
+	conf.AutoFinalize.MaxPerRound = scc.GetInt(pfx + "auto_finalize.max_per_round")
+
 	conf.OwnerId = scc.GetString(pfx + "owner_id")
 	conf.Cost = scc.GetStringMapInt(pfx + "cost")
 
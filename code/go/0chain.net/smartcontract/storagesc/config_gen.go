@@ -9,9 +9,9 @@ import (
 // MarshalMsg implements msgp.Marshaler
 func (z *Config) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 33
+	// map header, size 35
 	// string "TimeUnit"
-	o = append(o, 0xde, 0x0, 0x21, 0xa8, 0x54, 0x69, 0x6d, 0x65, 0x55, 0x6e, 0x69, 0x74)
+	o = append(o, 0xde, 0x0, 0x23, 0xa8, 0x54, 0x69, 0x6d, 0x65, 0x55, 0x6e, 0x69, 0x74)
 	o = msgp.AppendDuration(o, z.TimeUnit)
 	// string "Minted"
 	o = append(o, 0xa6, 0x4d, 0x69, 0x6e, 0x74, 0x65, 0x64)
@@ -183,6 +183,27 @@ func (z *Config) MarshalMsg(b []byte) (o []byte, err error) {
 			return
 		}
 	}
+	// string "Reputation"
+	o = append(o, 0xaa, 0x52, 0x65, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e)
+	// map header, size 3
+	// string "Decay"
+	o = append(o, 0x83, 0xa5, 0x44, 0x65, 0x63, 0x61, 0x79)
+	o = msgp.AppendFloat64(o, z.Reputation.Decay)
+	// string "UptimeWeight"
+	o = append(o, 0xac, 0x55, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendFloat64(o, z.Reputation.UptimeWeight)
+	// string "RewardWeight"
+	o = append(o, 0xac, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendFloat64(o, z.Reputation.RewardWeight)
+	// string "AutoFinalize"
+	o = append(o, 0xac, 0x41, 0x75, 0x74, 0x6f, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65)
+	// map header, size 2
+	// string "Enabled"
+	o = append(o, 0x82, 0xa7, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64)
+	o = msgp.AppendBool(o, z.AutoFinalize.Enabled)
+	// string "MaxPerRound"
+	o = append(o, 0xab, 0x4d, 0x61, 0x78, 0x50, 0x65, 0x72, 0x52, 0x6f, 0x75, 0x6e, 0x64)
+	o = msgp.AppendInt(o, z.AutoFinalize.MaxPerRound)
 	// string "OwnerId"
 	o = append(o, 0xa7, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x49, 0x64)
 	o = msgp.AppendString(o, z.OwnerId)
@@ -525,6 +546,82 @@ func (z *Config) UnmarshalMsg(bts []byte) (o []byte, err error) {
 					return
 				}
 			}
+		case "Reputation":
+			var zb0005 uint32
+			zb0005, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Reputation")
+				return
+			}
+			for zb0005 > 0 {
+				zb0005--
+				field, bts, err = msgp.ReadMapKeyZC(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Reputation")
+					return
+				}
+				switch msgp.UnsafeString(field) {
+				case "Decay":
+					z.Reputation.Decay, bts, err = msgp.ReadFloat64Bytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Reputation", "Decay")
+						return
+					}
+				case "UptimeWeight":
+					z.Reputation.UptimeWeight, bts, err = msgp.ReadFloat64Bytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Reputation", "UptimeWeight")
+						return
+					}
+				case "RewardWeight":
+					z.Reputation.RewardWeight, bts, err = msgp.ReadFloat64Bytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Reputation", "RewardWeight")
+						return
+					}
+				default:
+					bts, err = msgp.Skip(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Reputation")
+						return
+					}
+				}
+			}
+		case "AutoFinalize":
+			var zb0006 uint32
+			zb0006, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "AutoFinalize")
+				return
+			}
+			for zb0006 > 0 {
+				zb0006--
+				field, bts, err = msgp.ReadMapKeyZC(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "AutoFinalize")
+					return
+				}
+				switch msgp.UnsafeString(field) {
+				case "Enabled":
+					z.AutoFinalize.Enabled, bts, err = msgp.ReadBoolBytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "AutoFinalize", "Enabled")
+						return
+					}
+				case "MaxPerRound":
+					z.AutoFinalize.MaxPerRound, bts, err = msgp.ReadIntBytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "AutoFinalize", "MaxPerRound")
+						return
+					}
+				default:
+					bts, err = msgp.Skip(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "AutoFinalize")
+						return
+					}
+				}
+			}
 		case "OwnerId":
 			z.OwnerId, bts, err = msgp.ReadStringBytes(bts)
 			if err != nil {
@@ -532,23 +629,23 @@ func (z *Config) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				return
 			}
 		case "Cost":
-			var zb0005 uint32
-			zb0005, bts, err = msgp.ReadMapHeaderBytes(bts)
+			var zb0007 uint32
+			zb0007, bts, err = msgp.ReadMapHeaderBytes(bts)
 			if err != nil {
 				err = msgp.WrapError(err, "Cost")
 				return
 			}
 			if z.Cost == nil {
-				z.Cost = make(map[string]int, zb0005)
+				z.Cost = make(map[string]int, zb0007)
 			} else if len(z.Cost) > 0 {
 				for key := range z.Cost {
 					delete(z.Cost, key)
 				}
 			}
-			for zb0005 > 0 {
+			for zb0007 > 0 {
 				var za0001 string
 				var za0002 int
-				zb0005--
+				zb0007--
 				za0001, bts, err = msgp.ReadStringBytes(bts)
 				if err != nil {
 					err = msgp.WrapError(err, "Cost")
@@ -599,7 +696,7 @@ func (z *Config) Msgsize() (s int) {
 	} else {
 		s += z.BlockReward.Msgsize()
 	}
-	s += 8 + msgp.StringPrefixSize + len(z.OwnerId) + 5 + msgp.MapHeaderSize
+	s += 11 + 1 + 6 + msgp.Float64Size + 13 + msgp.Float64Size + 13 + msgp.Float64Size + 13 + 1 + 8 + msgp.BoolSize + 12 + msgp.IntSize + 8 + msgp.StringPrefixSize + len(z.OwnerId) + 5 + msgp.MapHeaderSize
 	if z.Cost != nil {
 		for za0001, za0002 := range z.Cost {
 			_ = za0002
@@ -609,6 +706,67 @@ func (z *Config) Msgsize() (s int) {
 	return
 }
 
+// MarshalMsg implements msgp.Marshaler
+func (z autoFinalizeConfig) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "Enabled"
+	o = append(o, 0x82, 0xa7, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64)
+	o = msgp.AppendBool(o, z.Enabled)
+	// string "MaxPerRound"
+	o = append(o, 0xab, 0x4d, 0x61, 0x78, 0x50, 0x65, 0x72, 0x52, 0x6f, 0x75, 0x6e, 0x64)
+	o = msgp.AppendInt(o, z.MaxPerRound)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *autoFinalizeConfig) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Enabled":
+			z.Enabled, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Enabled")
+				return
+			}
+		case "MaxPerRound":
+			z.MaxPerRound, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MaxPerRound")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z autoFinalizeConfig) Msgsize() (s int) {
+	s = 1 + 8 + msgp.BoolSize + 12 + msgp.IntSize
+	return
+}
+
 // MarshalMsg implements msgp.Marshaler
 func (z *blockReward) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
@@ -1112,6 +1270,76 @@ func (z *readPoolConfig) Msgsize() (s int) {
 	return
 }
 
+// MarshalMsg implements msgp.Marshaler
+func (z reputationConfig) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "Decay"
+	o = append(o, 0x83, 0xa5, 0x44, 0x65, 0x63, 0x61, 0x79)
+	o = msgp.AppendFloat64(o, z.Decay)
+	// string "UptimeWeight"
+	o = append(o, 0xac, 0x55, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendFloat64(o, z.UptimeWeight)
+	// string "RewardWeight"
+	o = append(o, 0xac, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendFloat64(o, z.RewardWeight)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *reputationConfig) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Decay":
+			z.Decay, bts, err = msgp.ReadFloat64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Decay")
+				return
+			}
+		case "UptimeWeight":
+			z.UptimeWeight, bts, err = msgp.ReadFloat64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "UptimeWeight")
+				return
+			}
+		case "RewardWeight":
+			z.RewardWeight, bts, err = msgp.ReadFloat64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "RewardWeight")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z reputationConfig) Msgsize() (s int) {
+	s = 1 + 6 + msgp.Float64Size + 13 + msgp.Float64Size + 13 + msgp.Float64Size
+	return
+}
+
 // MarshalMsg implements msgp.Marshaler
 func (z stakePoolConfig) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
@@ -81,6 +81,13 @@ const (
 	BlockRewardZetaK
 	BlockRewardZetaMu
 
+	ReputationDecay
+	ReputationUptimeWeight
+	ReputationRewardWeight
+
+	AutoFinalizeEnabled
+	AutoFinalizeMaxPerRound
+
 	OwnerId
 
 	CostUpdateSettings
@@ -89,13 +96,20 @@ const (
 	CostNewAllocationRequest
 	CostUpdateAllocationRequest
 	CostFinalizeAllocation
+	CostFinalizeExpiredAllocation
+	CostBackfillAllocationExpiry
 	CostCancelAllocation
+	CostRepairAllocation
+	CostProposeAllocationOwner
+	CostAcceptAllocationOwner
+	CostCancelAllocationOwner
 	CostAddFreeStorageAssigner
 	CostFreeAllocationRequest
 	CostBlobberHealthCheck
 	CostUpdateBlobberSettings
 	CostPayBlobberBlockRewards
 	CostChallengeResponse
+	CostChallengeResponseBatch
 	CostGenerateChallenges
 	CostAddValidator
 	CostUpdateValidatorSettings
@@ -105,6 +119,8 @@ const (
 	CostWritePoolLock
 	CostStakePoolLock
 	CostStakePoolUnlock
+	CostRedelegate
+	CostStakePoolAutoCompound
 	CostCommitSettingsChanges
 	CostCollectReward
 	CostKillBlobber
@@ -181,6 +197,11 @@ func initSettingName() {
 	SettingName[BlockRewardZetaI] = "block_reward.zeta.i"
 	SettingName[BlockRewardZetaK] = "block_reward.zeta.k"
 	SettingName[BlockRewardZetaMu] = "block_reward.zeta.mu"
+	SettingName[ReputationDecay] = "reputation.decay"
+	SettingName[ReputationUptimeWeight] = "reputation.uptime_weight"
+	SettingName[ReputationRewardWeight] = "reputation.reward_weight"
+	SettingName[AutoFinalizeEnabled] = "auto_finalize.enabled"
+	SettingName[AutoFinalizeMaxPerRound] = "auto_finalize.max_per_round"
 	SettingName[OwnerId] = "owner_id"
 	SettingName[CostUpdateSettings] = "cost.update_settings"
 	SettingName[CostReadRedeem] = "cost.read_redeem"
@@ -188,13 +209,20 @@ func initSettingName() {
 	SettingName[CostNewAllocationRequest] = "cost.new_allocation_request"
 	SettingName[CostUpdateAllocationRequest] = "cost.update_allocation_request"
 	SettingName[CostFinalizeAllocation] = "cost.finalize_allocation"
+	SettingName[CostFinalizeExpiredAllocation] = "cost.finalize_expired_allocation"
+	SettingName[CostBackfillAllocationExpiry] = "cost.backfill_allocation_expiry"
 	SettingName[CostCancelAllocation] = "cost.cancel_allocation"
+	SettingName[CostRepairAllocation] = "cost.repair_allocation"
+	SettingName[CostProposeAllocationOwner] = "cost.propose_allocation_owner"
+	SettingName[CostAcceptAllocationOwner] = "cost.accept_allocation_owner"
+	SettingName[CostCancelAllocationOwner] = "cost.cancel_allocation_owner"
 	SettingName[CostAddFreeStorageAssigner] = "cost.add_free_storage_assigner"
 	SettingName[CostFreeAllocationRequest] = "cost.free_allocation_request"
 	SettingName[CostBlobberHealthCheck] = "cost.blobber_health_check"
 	SettingName[CostUpdateBlobberSettings] = "cost.update_blobber_settings"
 	SettingName[CostPayBlobberBlockRewards] = "cost.pay_blobber_block_rewards"
 	SettingName[CostChallengeResponse] = "cost.challenge_response"
+	SettingName[CostChallengeResponseBatch] = "cost.challenge_response_batch"
 	SettingName[CostGenerateChallenges] = "cost.generate_challenge"
 	SettingName[CostAddValidator] = "cost.add_validator"
 	SettingName[CostUpdateValidatorSettings] = "cost.update_validator_settings"
@@ -204,6 +232,8 @@ func initSettingName() {
 	SettingName[CostWritePoolLock] = "cost.write_pool_lock"
 	SettingName[CostStakePoolLock] = "cost.stake_pool_lock"
 	SettingName[CostStakePoolUnlock] = "cost.stake_pool_unlock"
+	SettingName[CostRedelegate] = "cost.redelegate"
+	SettingName[CostStakePoolAutoCompound] = "cost.stake_pool_auto_compound"
 	SettingName[CostCommitSettingsChanges] = "cost.commit_settings_changes"
 	SettingName[CostCollectReward] = "cost.collect_reward"
 	SettingName[CostKillBlobber] = "cost.kill_blobber"
@@ -262,6 +292,11 @@ func initSettings() {
 		BlockRewardZetaI.String():                 {BlockRewardZetaI, config.Float64},
 		BlockRewardZetaK.String():                 {BlockRewardZetaK, config.Float64},
 		BlockRewardZetaMu.String():                {BlockRewardZetaMu, config.Float64},
+		ReputationDecay.String():                  {ReputationDecay, config.Float64},
+		ReputationUptimeWeight.String():           {ReputationUptimeWeight, config.Float64},
+		ReputationRewardWeight.String():           {ReputationRewardWeight, config.Float64},
+		AutoFinalizeEnabled.String():              {AutoFinalizeEnabled, config.Boolean},
+		AutoFinalizeMaxPerRound.String():          {AutoFinalizeMaxPerRound, config.Int},
 		OwnerId.String():                          {OwnerId, config.Key},
 		CostUpdateSettings.String():               {CostUpdateSettings, config.Cost},
 		CostReadRedeem.String():                   {CostReadRedeem, config.Cost},
@@ -269,13 +304,20 @@ func initSettings() {
 		CostNewAllocationRequest.String():         {CostNewAllocationRequest, config.Cost},
 		CostUpdateAllocationRequest.String():      {CostUpdateAllocationRequest, config.Cost},
 		CostFinalizeAllocation.String():           {CostFinalizeAllocation, config.Cost},
+		CostFinalizeExpiredAllocation.String():    {CostFinalizeExpiredAllocation, config.Cost},
+		CostBackfillAllocationExpiry.String():     {CostBackfillAllocationExpiry, config.Cost},
 		CostCancelAllocation.String():             {CostCancelAllocation, config.Cost},
+		CostRepairAllocation.String():             {CostRepairAllocation, config.Cost},
+		CostProposeAllocationOwner.String():       {CostProposeAllocationOwner, config.Cost},
+		CostAcceptAllocationOwner.String():        {CostAcceptAllocationOwner, config.Cost},
+		CostCancelAllocationOwner.String():        {CostCancelAllocationOwner, config.Cost},
 		CostAddFreeStorageAssigner.String():       {CostAddFreeStorageAssigner, config.Cost},
 		CostFreeAllocationRequest.String():        {CostFreeAllocationRequest, config.Cost},
 		CostBlobberHealthCheck.String():           {CostBlobberHealthCheck, config.Cost},
 		CostUpdateBlobberSettings.String():        {CostUpdateBlobberSettings, config.Cost},
 		CostPayBlobberBlockRewards.String():       {CostPayBlobberBlockRewards, config.Cost},
 		CostChallengeResponse.String():            {CostChallengeResponse, config.Cost},
+		CostChallengeResponseBatch.String():       {CostChallengeResponseBatch, config.Cost},
 		CostGenerateChallenges.String():           {CostGenerateChallenges, config.Cost},
 		CostAddValidator.String():                 {CostAddValidator, config.Cost},
 		CostUpdateValidatorSettings.String():      {CostUpdateValidatorSettings, config.Cost},
@@ -285,6 +327,8 @@ func initSettings() {
 		CostWritePoolLock.String():                {CostWritePoolLock, config.Cost},
 		CostStakePoolLock.String():                {CostStakePoolLock, config.Cost},
 		CostStakePoolUnlock.String():              {CostStakePoolUnlock, config.Cost},
+		CostRedelegate.String():                   {CostRedelegate, config.Cost},
+		CostStakePoolAutoCompound.String():        {CostStakePoolAutoCompound, config.Cost},
 		CostCommitSettingsChanges.String():        {CostCommitSettingsChanges, config.Cost},
 		CostCollectReward.String():                {CostCollectReward, config.Cost},
 		CostKillBlobber.String():                  {CostKillBlobber, config.Cost},
@@ -365,6 +409,8 @@ func (conf *Config) setInt(key string, change int) error {
 		conf.MaxBlobberSelectForChallenge = change
 	case MaxDelegates:
 		conf.MaxDelegates = change
+	case AutoFinalizeMaxPerRound:
+		conf.AutoFinalize.MaxPerRound = change
 	default:
 		return fmt.Errorf("key: %v not implemented as int", key)
 	}
@@ -490,6 +536,12 @@ func (conf *Config) setFloat64(key string, change float64) error {
 			conf.BlockReward = &blockReward{}
 		}
 		conf.BlockReward.Zeta.Mu = change
+	case ReputationDecay:
+		conf.Reputation.Decay = change
+	case ReputationUptimeWeight:
+		conf.Reputation.UptimeWeight = change
+	case ReputationRewardWeight:
+		conf.Reputation.RewardWeight = change
 	default:
 		return fmt.Errorf("key: %v not implemented as float64", key)
 	}
@@ -517,6 +569,8 @@ func (conf *Config) setBoolean(key string, change bool) error {
 	switch Settings[key].setting {
 	case ChallengeEnabled:
 		conf.ChallengeEnabled = change
+	case AutoFinalizeEnabled:
+		conf.AutoFinalize.Enabled = change
 	default:
 		return fmt.Errorf("key: %v not implemented as boolean", key)
 	}
@@ -709,6 +763,16 @@ func (conf *Config) get(key Setting) interface{} {
 		return conf.BlockReward.Zeta.K
 	case BlockRewardZetaMu:
 		return conf.BlockReward.Zeta.Mu
+	case ReputationDecay:
+		return conf.Reputation.Decay
+	case ReputationUptimeWeight:
+		return conf.Reputation.UptimeWeight
+	case ReputationRewardWeight:
+		return conf.Reputation.RewardWeight
+	case AutoFinalizeEnabled:
+		return conf.AutoFinalize.Enabled
+	case AutoFinalizeMaxPerRound:
+		return conf.AutoFinalize.MaxPerRound
 	case OwnerId:
 		return conf.OwnerId
 	case MaxCharge:
@@ -63,6 +63,8 @@ func GetEndpoints(rh rest.RestHandlerI) []rest.Endpoint {
 		rest.MakeEndpoint(storage+"/allocations", common.UserRateLimit(srh.getAllocations)),
 		rest.MakeEndpoint(storage+"/expired-allocations", common.UserRateLimit(srh.getExpiredAllocations)),
 		rest.MakeEndpoint(storage+"/allocation-update-min-lock", common.UserRateLimit(srh.getAllocationUpdateMinLock)),
+		rest.MakeEndpoint(storage+"/allocation-repairs", common.UserRateLimit(srh.getAllocationRepairs)),
+		rest.MakeEndpoint(storage+"/allocation-owner-transfers", common.UserRateLimit(srh.getAllocationOwnerTransfers)),
 		rest.MakeEndpoint(storage+"/allocation", common.UserRateLimit(srh.getAllocation)),
 		rest.MakeEndpoint(storage+"/latestreadmarker", common.UserRateLimit(srh.getLatestReadMarker)),
 		rest.MakeEndpoint(storage+"/readmarkers", common.UserRateLimit(srh.getReadMarkers)),
@@ -73,6 +75,7 @@ func GetEndpoints(rh rest.RestHandlerI) []rest.Endpoint {
 		rest.MakeEndpoint(storage+"/openchallenges", common.UserRateLimit(srh.getOpenChallenges)),
 		rest.MakeEndpoint(storage+"/getchallenge", common.UserRateLimit(srh.getChallenge)),
 		rest.MakeEndpoint(storage+"/blobber-challenges", common.UserRateLimit(srh.getBlobberChallenges)),
+		rest.MakeEndpoint(storage+"/blobber-reputation", common.UserRateLimit(srh.getBlobberReputation)),
 		rest.MakeEndpoint(storage+"/getStakePoolStat", common.UserRateLimit(srh.getStakePoolStat)),
 		rest.MakeEndpoint(storage+"/getUserStakePoolStat", common.UserRateLimit(srh.getUserStakePoolStat)),
 		rest.MakeEndpoint(storage+"/block", common.UserRateLimit(srh.getBlock)),
@@ -280,6 +283,9 @@ type allocationBlobbersRequest struct {
 	WritePriceRange PriceRange `json:"write_price_range"`
 	Size            int64      `json:"size"`
 	IsRestricted    int        `json:"is_restricted"`
+
+	Placement     *PlacementConstraints `json:"placement,omitempty"`
+	MinReputation float64               `json:"min_reputation,omitempty"`
 }
 
 func (nar *allocationBlobbersRequest) decode(b []byte) error {
@@ -300,6 +306,10 @@ func (nar *allocationBlobbersRequest) decode(b []byte) error {
 //
 //   - Restricted status
 //
+//   - Placement constraints: required regions, max shards per operator or region
+//
+//   - Minimum reputation score
+//
 // parameters:
 //
 //	+name: allocation_data
@@ -390,6 +400,12 @@ func getBlobbersForRequest(request allocationBlobbersRequest, edb *event.EventDb
 			"invalid data shards:%v or parity shards:%v", request.DataShards, request.ParityShards)
 	}
 
+	if request.Placement != nil {
+		if err := request.Placement.validate(numberOfBlobbers); err != nil {
+			return nil, common.NewErrorf("allocation_creation_failed", "invalid placement: %v", err)
+		}
+	}
+
 	var allocationSize = bSize(request.Size, request.DataShards)
 
 	allocation := event.AllocationQuery{
@@ -412,6 +428,12 @@ func getBlobbersForRequest(request allocationBlobbersRequest, edb *event.EventDb
 		NumberOfDataShards: request.DataShards,
 		IsRestricted:       request.IsRestricted,
 	}
+	if request.Placement != nil {
+		allocation.Regions = request.Placement.RequiredRegions
+	}
+	if request.MinReputation > 0 {
+		allocation.MinReputation = request.MinReputation
+	}
 
 	logging.Logger.Debug("alloc_blobbers", zap.Int64("ReadPriceRange.Min", allocation.ReadPriceRange.Min),
 		zap.Int64("ReadPriceRange.Max", allocation.ReadPriceRange.Max), zap.Int64("WritePriceRange.Min", allocation.WritePriceRange.Min),
@@ -430,6 +452,26 @@ func getBlobbersForRequest(request allocationBlobbersRequest, edb *event.EventDb
 		return nil, fmt.Errorf("not enough blobbers to honor the allocation : %d < %d", len(blobberIDs), numberOfBlobbers)
 	}
 
+	if request.Placement != nil && request.Placement.isLimited() && len(blobberIDs) > 0 {
+		blobbers, err := edb.GetBlobberLabels(blobberIDs)
+		if err != nil {
+			return nil, errors.New("failed to get blobber labels: " + err.Error())
+		}
+
+		labels := make(map[string]blobberLabels, len(blobbers))
+		for _, b := range blobbers {
+			labels[b.ID] = blobberLabels{Region: b.Region, Operator: b.Operator}
+		}
+
+		ordered, err := request.Placement.orderByPlacement(blobberIDs, labels, numberOfBlobbers)
+		if err != nil && !isForce {
+			return nil, err
+		}
+		if err == nil {
+			blobberIDs = ordered
+		}
+	}
+
 	return blobberIDs, nil
 }
 
@@ -1148,6 +1190,182 @@ func getProviderStakePoolStats(providerType int, providerID string, edb *event.E
 	return nil, fmt.Errorf("unknown provider type")
 }
 
+// swagger:model blobberReputationResponse
+type blobberReputationResponse struct {
+	BlobberReputation
+	PassRatio   float64 `json:"pass_ratio"`
+	UptimeRatio float64 `json:"uptime_ratio"`
+	Score       float64 `json:"score"`
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/blobber-reputation storage-sc GetBlobberReputation
+// Get blobber reputation.
+//
+// Gets the reputation of a blobber, derived from its decayed challenge pass ratio and uptime.
+//
+// parameters:
+//
+//	+name: blobber_id
+//	  description: id of blobber for which to get the reputation
+//	  required: true
+//	  in: query
+//	  type: string
+//
+// responses:
+//
+//	200: blobberReputationResponse
+//	400:
+//	404:
+//	500:
+func (srh *StorageRestHandler) getBlobberReputation(w http.ResponseWriter, r *http.Request) {
+	blobberID := r.URL.Query().Get("blobber_id")
+	if blobberID == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing blobber_id URL query parameter"))
+		return
+	}
+
+	balances := srh.GetQueryStateContext()
+	if _, err := getBlobber(blobberID, balances); err != nil {
+		common.Respond(w, r, nil, smartcontract.NewErrNoResourceOrErrInternal(err, true, "can't get blobber"))
+		return
+	}
+
+	conf, err := getConfig(balances)
+	if err != nil {
+		common.Respond(w, r, nil, smartcontract.NewErrNoResourceOrErrInternal(err, true, cantGetConfigErrMsg))
+		return
+	}
+
+	br, err := getBlobberReputation(blobberID, balances)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("can't get blobber reputation", err.Error()))
+		return
+	}
+
+	common.Respond(w, r, blobberReputationResponse{
+		BlobberReputation: *br,
+		PassRatio:         reputationRatio(br.passRatio()),
+		UptimeRatio:       reputationRatio(br.uptimeRatio()),
+		Score:             reputationRatio(br.score(conf.Reputation)),
+	}, nil)
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/allocation-repairs storage-sc GetAllocationRepairs
+// Get allocation repairs.
+//
+// Gets the degraded blobbers of an auto repaired allocation and their replacements. Supports pagination.
+//
+// parameters:
+//
+//	+name: allocation_id
+//	  description: id of the allocation
+//	  required: true
+//	  in: query
+//	  type: string
+//	+name: offset
+//	  description: offset
+//	  in: query
+//	  type: string
+//	+name: limit
+//	  description: limit
+//	  in: query
+//	  type: string
+//	+name: sort
+//	  description: desc or asc
+//	  in: query
+//	  type: string
+//
+// responses:
+//
+//	200: []AllocationRepair
+//	400:
+//	500:
+func (srh *StorageRestHandler) getAllocationRepairs(w http.ResponseWriter, r *http.Request) {
+	allocationID := r.URL.Query().Get("allocation_id")
+	if allocationID == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing allocation_id URL query parameter"))
+		return
+	}
+
+	limit, err := common2.GetOffsetLimitOrderParam(r.URL.Query())
+	if err != nil {
+		common.Respond(w, r, nil, err)
+		return
+	}
+
+	edb := srh.GetQueryStateContext().GetEventDB()
+	if edb == nil {
+		common.Respond(w, r, nil, common.NewErrInternal("no db connection"))
+		return
+	}
+
+	repairs, err := edb.GetAllocationRepairs(allocationID, limit)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("can't get allocation repairs", err.Error()))
+		return
+	}
+
+	common.Respond(w, r, repairs, nil)
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/allocation-owner-transfers storage-sc GetAllocationOwnerTransfers
+// Get allocation owner transfers.
+//
+// Gets the proposed, accepted and cancelled ownership transfers of an allocation. Supports pagination.
+//
+// parameters:
+//
+//	+name: allocation_id
+//	  description: id of the allocation
+//	  required: true
+//	  in: query
+//	  type: string
+//	+name: offset
+//	  description: offset
+//	  in: query
+//	  type: string
+//	+name: limit
+//	  description: limit
+//	  in: query
+//	  type: string
+//	+name: sort
+//	  description: desc or asc
+//	  in: query
+//	  type: string
+//
+// responses:
+//
+//	200: []AllocationOwnershipTransfer
+//	400:
+//	500:
+func (srh *StorageRestHandler) getAllocationOwnerTransfers(w http.ResponseWriter, r *http.Request) {
+	allocationID := r.URL.Query().Get("allocation_id")
+	if allocationID == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing allocation_id URL query parameter"))
+		return
+	}
+
+	limit, err := common2.GetOffsetLimitOrderParam(r.URL.Query())
+	if err != nil {
+		common.Respond(w, r, nil, err)
+		return
+	}
+
+	edb := srh.GetQueryStateContext().GetEventDB()
+	if edb == nil {
+		common.Respond(w, r, nil, common.NewErrInternal("no db connection"))
+		return
+	}
+
+	transfers, err := edb.GetAllocationOwnershipTransfers(allocationID, limit)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("can't get allocation owner transfers", err.Error()))
+		return
+	}
+
+	common.Respond(w, r, transfers, nil)
+}
+
 // swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/blobber-challenges storage-sc GetBlobberChallenges
 // Get blobber challenges.
 //
@@ -2590,7 +2808,14 @@ type storageNodeResponse struct {
 	UncollectedServiceCharge currency.Coin `json:"uncollected_service_charge"`
 	CreatedAt                time.Time     `json:"created_at"`
 
-	IsRestricted bool `json:"is_restricted"`
+	IsRestricted bool    `json:"is_restricted"`
+	Region       string  `json:"region"`
+	Operator     string  `json:"operator"`
+	Reputation   float64 `json:"reputation"`
+
+	// version of the blobber entity the response was built from, the
+	// allocation requests convert it back to the same version
+	version string
 }
 
 func StoragNodeToStorageNodeResponse(sn StorageNode) storageNodeResponse {
@@ -2613,10 +2838,11 @@ func StoragNodeToStorageNodeResponse(sn StorageNode) storageNodeResponse {
 		NotAvailable:            b.NotAvailable,
 	}
 
-	sv2, ok := sn.Entity().(*storageNodeV2)
-	if ok && sv2.IsRestricted != nil {
-		sr.IsRestricted = *sv2.IsRestricted
-	}
+	sr.IsRestricted = sn.restricted()
+	labels := sn.labels()
+	sr.Region = labels.Region
+	sr.Operator = labels.Operator
+	sr.version = sn.Entity().GetVersion()
 
 	return sr
 }
@@ -2668,6 +2894,32 @@ func storageNodeResponseToStorageNodeV2(snr storageNodeResponse) *storageNodeV2
 	}
 }
 
+func storageNodeResponseToStorageNodeV3(snr storageNodeResponse) *storageNodeV3 {
+	return &storageNodeV3{
+		Provider: provider.Provider{
+			ID:              snr.ID,
+			ProviderType:    spenum.Blobber,
+			LastHealthCheck: snr.LastHealthCheck,
+			HasBeenKilled:   snr.IsKilled,
+			HasBeenShutDown: snr.IsShutdown,
+		},
+		BaseURL:                 snr.BaseURL,
+		Terms:                   snr.Terms,
+		Capacity:                snr.Capacity,
+		Allocated:               snr.Allocated,
+		PublicKey:               snr.PublicKey,
+		SavedData:               snr.SavedData,
+		DataReadLastRewardRound: snr.DataReadLastRewardRound,
+		LastRewardDataReadRound: snr.LastRewardDataReadRound,
+		StakePoolSettings:       snr.StakePoolSettings,
+		RewardRound:             snr.RewardRound,
+		NotAvailable:            snr.NotAvailable,
+		IsRestricted:            &snr.IsRestricted,
+		Region:                  snr.Region,
+		Operator:                snr.Operator,
+	}
+}
+
 func blobberTableToStorageNode(blobber event.Blobber) storageNodeResponse {
 	return storageNodeResponse{
 		ID:      blobber.ID,
@@ -2701,6 +2953,9 @@ func blobberTableToStorageNode(blobber event.Blobber) storageNodeResponse {
 		NotAvailable:             blobber.NotAvailable,
 		CreatedAt:                blobber.CreatedAt,
 		IsRestricted:             blobber.IsRestricted,
+		Region:                   blobber.Region,
+		Operator:                 blobber.Operator,
+		Reputation:               blobber.Reputation,
 	}
 }
 
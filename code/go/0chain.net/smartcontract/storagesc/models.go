@@ -44,6 +44,9 @@ var (
 	ALL_VALIDATORS_KEY               = ADDRESS + encryption.Hash("all_validators")
 	ALL_CHALLENGE_READY_BLOBBERS_KEY = ADDRESS + encryption.Hash("all_challenge_ready_blobbers")
 	BLOBBER_REWARD_KEY               = ADDRESS + encryption.Hash("blobber_rewards")
+	ALLOCATION_EXPIRY_KEY            = ADDRESS + encryption.Hash("allocation_expiry")
+	ALLOCATION_EXPIRY_PERIODS_KEY    = ADDRESS + encryption.Hash("allocation_expiry_periods")
+	ALLOCATION_EXPIRY_BACKFILL_KEY   = ADDRESS + encryption.Hash("allocation_expiry_backfill")
 )
 
 func getBlobberAllocationsKey(blobberID string) string {
@@ -84,6 +87,10 @@ func (an *Allocations) GetHashBytes() []byte {
 type ChallengeResponse struct {
 	ID                string              `json:"challenge_id"`
 	ValidationTickets []*ValidationTicket `json:"validation_tickets"`
+	// AggregatedSignature is the BLS aggregate of the validators'
+	// signatures over the tickets' common message. Tickets of such a
+	// response carry no individual signatures.
+	AggregatedSignature string `json:"aggregated_signature,omitempty"`
 }
 
 type AllocOpenChallenge struct {
@@ -598,7 +605,7 @@ func (d *BlobberAllocation) payChallengePoolPassPayments(alloc *StorageAllocatio
 		return 0, 0, common.NewError("challenge_penalty_on_finalization_error", err.Error())
 	}
 
-	challengeRewardPaid, err := d.challengeRewardOnFinalization(conf.TimeUnit, now, sp, cp, passRate, balances, alloc)
+	challengeRewardPaid, err := d.challengeRewardOnFinalization(conf.TimeUnit, conf.MaxStake, now, sp, cp, passRate, balances, alloc)
 	if err != nil {
 		return 0, 0, common.NewError("challenge_reward_on_finalization_error", err.Error())
 	}
@@ -606,7 +613,7 @@ func (d *BlobberAllocation) payChallengePoolPassPayments(alloc *StorageAllocatio
 	return challengeRewardPaid, challengePenaltyPaid, nil
 }
 
-func (d *BlobberAllocation) challengeRewardOnFinalization(timeUnit time.Duration, now common.Timestamp, sp *stakePool, cp *challengePool, passRate float64, balances chainstate.StateContextI, alloc *StorageAllocation) (currency.Coin, error) {
+func (d *BlobberAllocation) challengeRewardOnFinalization(timeUnit time.Duration, maxStake currency.Coin, now common.Timestamp, sp *stakePool, cp *challengePool, passRate float64, balances chainstate.StateContextI, alloc *StorageAllocation) (currency.Coin, error) {
 	if now <= d.LatestFinalizedChallCreatedAt {
 		logging.Logger.Info("challenge reward on finalization", zap.Any("now", now), zap.Any("latest finalized challenge created at", d.LatestFinalizedChallCreatedAt))
 		return 0, nil
@@ -649,7 +656,7 @@ func (d *BlobberAllocation) challengeRewardOnFinalization(timeUnit time.Duration
 		}
 		d.ChallengePoolIntegralValue = cv
 
-		err = sp.DistributeRewards(reward, d.BlobberID, spenum.Blobber, spenum.ChallengePassReward, balances, alloc.ID)
+		err = sp.DistributeRewards(reward, d.BlobberID, spenum.Blobber, spenum.ChallengePassReward, maxStake, balances, alloc.ID)
 		if err != nil {
 			return payment, fmt.Errorf("failed to distribute rewards blobber: %s, err: %v", d.BlobberID, err)
 		}
@@ -719,11 +726,11 @@ func (d *BlobberAllocation) challengePenaltyOnFinalization(conf *Config, alloc *
 	return move, nil
 }
 
-func (d *BlobberAllocation) payCancellationCharge(alloc *StorageAllocation, sp *stakePool, balances chainstate.StateContextI, sc *StorageSmartContract, passRate float64, totalWritePrice, cancellationCharge currency.Coin) (currency.Coin, error) {
+func (d *BlobberAllocation) payCancellationCharge(alloc *StorageAllocation, sp *stakePool, balances chainstate.StateContextI, conf *Config, sc *StorageSmartContract, passRate float64, totalWritePrice, cancellationCharge currency.Coin) (currency.Coin, error) {
 	blobberWritePriceWeight := float64(d.Terms.WritePrice) / float64(totalWritePrice)
 	reward, _ := currency.Float64ToCoin(float64(cancellationCharge) * blobberWritePriceWeight * passRate)
 
-	err := sp.DistributeRewards(reward, d.BlobberID, spenum.Blobber, spenum.CancellationChargeReward, balances, alloc.ID)
+	err := sp.DistributeRewards(reward, d.BlobberID, spenum.Blobber, spenum.CancellationChargeReward, conf.MaxStake, balances, alloc.ID)
 	if err != nil {
 		return 0, fmt.Errorf("failed to distribute rewards, blobber: %s, err: %v", d.BlobberID, err)
 	}
@@ -818,6 +825,14 @@ type StorageAllocation struct {
 	Stats             *StorageAllocationStats `json:"stats"`
 	DiverseBlobbers   bool                    `json:"diverse_blobbers"`
 	PreferredBlobbers []string                `json:"preferred_blobbers"`
+	// Placement constraints the blobbers of the allocation have to honour.
+	Placement *PlacementConstraints `json:"placement,omitempty"`
+	// AutoRepair lets the storage SC replace degraded blobbers of the
+	// allocation, nil if the owner didn't opt in.
+	AutoRepair *AutoRepairPolicy `json:"auto_repair,omitempty"`
+	// PendingTransfer is the ownership transfer proposed by the owner and
+	// not accepted yet.
+	PendingTransfer *OwnershipTransfer `json:"pending_transfer,omitempty"`
 	// Blobbers not to be used anywhere except /allocation and /allocations table
 	// if Blobbers are getting used in any smart-contract, we should avoid.
 	BlobberAllocs    []*BlobberAllocation          `json:"blobber_details"`
@@ -1100,7 +1115,7 @@ func (sa *StorageAllocation) payCancellationCharge(sps []*stakePool, balances ch
 	totalCancellationChargePaid := currency.Coin(0)
 
 	for i, ba := range sa.BlobberAllocs {
-		blobberCancellationChargePaid, err := ba.payCancellationCharge(sa, sps[i], balances, sc, passRates[i], totalWritePrice, cancellationCharge)
+		blobberCancellationChargePaid, err := ba.payCancellationCharge(sa, sps[i], balances, conf, sc, passRates[i], totalWritePrice, cancellationCharge)
 		if err != nil {
 			return fmt.Errorf("1 error paying cancellation charge: %v", err)
 		}
@@ -1155,7 +1170,7 @@ func (sa *StorageAllocation) payCancellationChargeToRemoveBlobber(sp *stakePool,
 		}
 	}
 
-	totalCancellationChargePaid, err := ba.payCancellationCharge(sa, sp, balances, sc, passRate, totalWritePrice, cancellationCharge)
+	totalCancellationChargePaid, err := ba.payCancellationCharge(sa, sp, balances, conf, sc, passRate, totalWritePrice, cancellationCharge)
 	if err != nil {
 		return fmt.Errorf("2 error paying cancellation charge: %v", err)
 	}
@@ -1586,20 +1601,26 @@ func (sa *StorageAllocation) changeBlobbers(
 
 	actErr := cstate.WithActivation(balances, "artemis", func() (e error) { return },
 		func() error {
-			return addedBlobber.Update(&storageNodeV2{}, func(e entitywrapper.EntityI) error {
-				b := e.(*storageNodeV2)
-
-				if b.IsRestricted != nil && *b.IsRestricted {
-					success, err := verifyBlobberAuthTicket(balances, sa.Owner, authTicket, b.PublicKey)
-					if err != nil {
-						return fmt.Errorf("blobber %s auth ticket verification failed: %v", b.ID, err.Error())
-					} else if !success {
-						return fmt.Errorf("blobber %s auth ticket verification failed", b.ID)
-					}
+			// v3 blobbers are already past v2
+			if _, ok := addedBlobber.Entity().(*storageNodeV3); !ok {
+				if err := addedBlobber.Update(&storageNodeV2{}, func(entitywrapper.EntityI) error {
+					return nil
+				}); err != nil {
+					return err
 				}
+			}
 
-				return nil
-			})
+			if addedBlobber.restricted() {
+				b := addedBlobber.mustBase()
+				success, err := verifyBlobberAuthTicket(balances, sa.Owner, authTicket, b.PublicKey)
+				if err != nil {
+					return fmt.Errorf("blobber %s auth ticket verification failed: %v", b.ID, err.Error())
+				} else if !success {
+					return fmt.Errorf("blobber %s auth ticket verification failed", b.ID)
+				}
+			}
+
+			return nil
 		})
 	if actErr != nil {
 		return nil, actErr
@@ -1626,6 +1647,12 @@ func (sa *StorageAllocation) changeBlobbers(
 		sa.BlobberAllocs = append(sa.BlobberAllocs, ba)
 	}
 
+	if sa.Placement != nil {
+		if err := sa.Placement.check(blobbers); err != nil {
+			return nil, err
+		}
+	}
+
 	sa.BlobberAllocsMap[addId] = ba
 
 	if err := sp.addOffer(ba.Offer()); err != nil {
@@ -1734,10 +1761,13 @@ func (sa *StorageAllocation) validateEachBlobber(
 		}
 
 		athenaFork := func() error {
-			snr := storageNodeResponseToStorageNodeV2(*b)
-			sn.SetEntity(snr)
-			if *snr.IsRestricted {
-				success, err := verifyBlobberAuthTicket(balances, sa.Owner, blobberAuthTickets[i], snr.PublicKey)
+			if b.version == storageNodeV3Version {
+				sn.SetEntity(storageNodeResponseToStorageNodeV3(*b))
+			} else {
+				sn.SetEntity(storageNodeResponseToStorageNodeV2(*b))
+			}
+			if sn.restricted() {
+				success, err := verifyBlobberAuthTicket(balances, sa.Owner, blobberAuthTickets[i], b.PublicKey)
 				if err != nil {
 					return fmt.Errorf("blobber %s auth ticket verification failed: %v", b.ID, err.Error())
 				} else if !success {
@@ -2006,6 +2036,10 @@ func (sa *StorageAllocation) removeExpiredChallenges(
 			if err != nil {
 				return 0, err
 			}
+
+			if err := sa.recordFailedChallenge(oc.BlobberID, balances); err != nil {
+				return 0, err
+			}
 		}
 	}
 
@@ -2072,6 +2106,10 @@ func (sa *StorageAllocation) removeOldChallenges(
 			if err != nil {
 				return err
 			}
+
+			if err := sa.recordFailedChallenge(oc.BlobberID, balances); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -2303,6 +2341,12 @@ func (vt *ValidationTicket) VerifySign(balances cstate.StateContextI) (bool, err
 	return verified, err
 }
 
+// aggregateHash is the common message signed by the validators agreeing
+// on a challenge result, so their signatures can be aggregated.
+func (vt *ValidationTicket) aggregateHash() string {
+	return encryption.Hash(fmt.Sprintf("%v:%v:%v", vt.ChallengeID, vt.BlobberID, vt.Result))
+}
+
 func (vt *ValidationTicket) Validate(challengeID, blobberID string) error {
 	if err := encryption.VerifyPublicKeyClientID(vt.ValidatorKey, vt.ValidatorID); err != nil {
 		return fmt.Errorf("invalid validator tickets: %v", err)
@@ -607,9 +607,9 @@ func (z *ChallengePoolChanges) Msgsize() (s int) {
 // MarshalMsg implements msgp.Marshaler
 func (z *ChallengeResponse) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 2
+	// map header, size 3
 	// string "ID"
-	o = append(o, 0x82, 0xa2, 0x49, 0x44)
+	o = append(o, 0x83, 0xa2, 0x49, 0x44)
 	o = msgp.AppendString(o, z.ID)
 	// string "ValidationTickets"
 	o = append(o, 0xb1, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73)
@@ -625,6 +625,9 @@ func (z *ChallengeResponse) MarshalMsg(b []byte) (o []byte, err error) {
 			}
 		}
 	}
+	// string "AggregatedSignature"
+	o = append(o, 0xb3, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65)
+	o = msgp.AppendString(o, z.AggregatedSignature)
 	return
 }
 
@@ -682,6 +685,12 @@ func (z *ChallengeResponse) UnmarshalMsg(bts []byte) (o []byte, err error) {
 					}
 				}
 			}
+		case "AggregatedSignature":
+			z.AggregatedSignature, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "AggregatedSignature")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -704,6 +713,7 @@ func (z *ChallengeResponse) Msgsize() (s int) {
 			s += z.ValidationTickets[za0001].Msgsize()
 		}
 	}
+	s += 20 + msgp.StringPrefixSize + len(z.AggregatedSignature)
 	return
 }
 
@@ -1127,9 +1137,9 @@ func (z *RewardRound) Msgsize() (s int) {
 // MarshalMsg implements msgp.Marshaler
 func (z *StorageAllocationDecode) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 24
+	// map header, size 27
 	// string "ID"
-	o = append(o, 0xde, 0x0, 0x18, 0xa2, 0x49, 0x44)
+	o = append(o, 0xde, 0x0, 0x1b, 0xa2, 0x49, 0x44)
 	o = msgp.AppendString(o, z.ID)
 	// string "Tx"
 	o = append(o, 0xa2, 0x54, 0x78)
@@ -1176,6 +1186,39 @@ func (z *StorageAllocationDecode) MarshalMsg(b []byte) (o []byte, err error) {
 	for za0001 := range z.PreferredBlobbers {
 		o = msgp.AppendString(o, z.PreferredBlobbers[za0001])
 	}
+	// string "Placement"
+	o = append(o, 0xa9, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74)
+	if z.Placement == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		o, err = z.Placement.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Placement")
+			return
+		}
+	}
+	// string "AutoRepair"
+	o = append(o, 0xaa, 0x41, 0x75, 0x74, 0x6f, 0x52, 0x65, 0x70, 0x61, 0x69, 0x72)
+	if z.AutoRepair == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		o, err = z.AutoRepair.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "AutoRepair")
+			return
+		}
+	}
+	// string "PendingTransfer"
+	o = append(o, 0xaf, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72)
+	if z.PendingTransfer == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		o, err = z.PendingTransfer.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "PendingTransfer")
+			return
+		}
+	}
 	// string "BlobberAllocs"
 	o = append(o, 0xad, 0x42, 0x6c, 0x6f, 0x62, 0x62, 0x65, 0x72, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x73)
 	o = msgp.AppendArrayHeader(o, uint32(len(z.BlobberAllocs)))
@@ -1385,6 +1428,57 @@ func (z *StorageAllocationDecode) UnmarshalMsg(bts []byte) (o []byte, err error)
 					return
 				}
 			}
+		case "Placement":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.Placement = nil
+			} else {
+				if z.Placement == nil {
+					z.Placement = new(PlacementConstraints)
+				}
+				bts, err = z.Placement.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Placement")
+					return
+				}
+			}
+		case "AutoRepair":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.AutoRepair = nil
+			} else {
+				if z.AutoRepair == nil {
+					z.AutoRepair = new(AutoRepairPolicy)
+				}
+				bts, err = z.AutoRepair.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "AutoRepair")
+					return
+				}
+			}
+		case "PendingTransfer":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.PendingTransfer = nil
+			} else {
+				if z.PendingTransfer == nil {
+					z.PendingTransfer = new(OwnershipTransfer)
+				}
+				bts, err = z.PendingTransfer.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "PendingTransfer")
+					return
+				}
+			}
 		case "BlobberAllocs":
 			var zb0003 uint32
 			zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
@@ -1569,6 +1663,24 @@ func (z *StorageAllocationDecode) Msgsize() (s int) {
 	for za0001 := range z.PreferredBlobbers {
 		s += msgp.StringPrefixSize + len(z.PreferredBlobbers[za0001])
 	}
+	s += 10
+	if z.Placement == nil {
+		s += msgp.NilSize
+	} else {
+		s += z.Placement.Msgsize()
+	}
+	s += 11
+	if z.AutoRepair == nil {
+		s += msgp.NilSize
+	} else {
+		s += z.AutoRepair.Msgsize()
+	}
+	s += 16
+	if z.PendingTransfer == nil {
+		s += msgp.NilSize
+	} else {
+		s += z.PendingTransfer.Msgsize()
+	}
 	s += 14 + msgp.ArrayHeaderSize
 	for za0002 := range z.BlobberAllocs {
 		if z.BlobberAllocs[za0002] == nil {
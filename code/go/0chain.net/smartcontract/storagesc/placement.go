@@ -0,0 +1,222 @@
+package storagesc
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+//go:generate msgp -io=false -tests=false -v
+
+const maxBlobberLabelLength = 64
+
+var blobberLabelRegexp = regexp.MustCompile(`^[a-z0-9]([a-z0-9._-]*[a-z0-9])?$`)
+
+// validateBlobberLabel checks a region or operator label declared by a
+// blobber. An empty label means the blobber didn't declare it.
+func validateBlobberLabel(kind, label string) error {
+	if label == "" {
+		return nil
+	}
+
+	if len(label) > maxBlobberLabelLength {
+		return fmt.Errorf("%s label is longer than %d characters", kind, maxBlobberLabelLength)
+	}
+
+	if !blobberLabelRegexp.MatchString(label) {
+		return fmt.Errorf("invalid %s label %q: only lower case letters, digits, '.', '-' and '_' are allowed", kind, label)
+	}
+
+	return nil
+}
+
+// blobberLabels is the placement relevant part of a blobber.
+type blobberLabels struct {
+	Region   string
+	Operator string
+}
+
+// PlacementConstraints restricts the blobbers an allocation can keep its
+// shards on. Blobbers that don't declare a label are counted together, as
+// if they shared one unnamed region or operator.
+//
+// The labels are declared by the blobbers themselves and nothing ties them
+// to the actual location or operator of a blobber. An operator running
+// several blobbers can declare a different operator label on each of them,
+// so MaxShardsPerOperator only holds against honest operators, it doesn't
+// protect an allocation from a single operator holding more shards. The
+// constraints are checked when blobbers are placed on the allocation, a
+// blobber changing its labels later doesn't move its allocations.
+type PlacementConstraints struct {
+	// RequiredRegions lists the regions the blobbers must be located in,
+	// no restriction if empty.
+	RequiredRegions []string `json:"required_regions,omitempty"`
+	// MaxShardsPerOperator limits the number of blobbers of one operator,
+	// no limit if zero.
+	MaxShardsPerOperator int `json:"max_shards_per_operator,omitempty"`
+	// MaxShardsPerRegion limits the number of blobbers in one region,
+	// no limit if zero.
+	MaxShardsPerRegion int `json:"max_shards_per_region,omitempty"`
+}
+
+func (pc *PlacementConstraints) validate(shards int) error {
+	if pc.MaxShardsPerOperator < 0 {
+		return errors.New("negative max_shards_per_operator")
+	}
+
+	if pc.MaxShardsPerRegion < 0 {
+		return errors.New("negative max_shards_per_region")
+	}
+
+	seen := make(map[string]struct{}, len(pc.RequiredRegions))
+	for _, region := range pc.RequiredRegions {
+		if region == "" {
+			return errors.New("empty required region")
+		}
+		if err := validateBlobberLabel("region", region); err != nil {
+			return err
+		}
+		if _, ok := seen[region]; ok {
+			return fmt.Errorf("duplicate required region %q", region)
+		}
+		seen[region] = struct{}{}
+	}
+
+	if pc.MaxShardsPerRegion > 0 && len(pc.RequiredRegions) > 0 &&
+		len(pc.RequiredRegions)*pc.MaxShardsPerRegion < shards {
+		return fmt.Errorf("%d required regions with at most %d shards each can't hold %d shards",
+			len(pc.RequiredRegions), pc.MaxShardsPerRegion, shards)
+	}
+
+	return nil
+}
+
+// isLimited reports whether the constraints cap the number of shards per
+// operator or region, which can't be enforced by filtering single blobbers.
+func (pc *PlacementConstraints) isLimited() bool {
+	return pc.MaxShardsPerOperator > 0 || pc.MaxShardsPerRegion > 0
+}
+
+func (pc *PlacementConstraints) allowsRegion(region string) bool {
+	if len(pc.RequiredRegions) == 0 {
+		return true
+	}
+	for _, r := range pc.RequiredRegions {
+		if r == region {
+			return true
+		}
+	}
+	return false
+}
+
+// placementTally counts the shards taken by each operator and region.
+type placementTally struct {
+	pc        *PlacementConstraints
+	operators map[string]int
+	regions   map[string]int
+}
+
+func newPlacementTally(pc *PlacementConstraints) *placementTally {
+	return &placementTally{
+		pc:        pc,
+		operators: make(map[string]int),
+		regions:   make(map[string]int),
+	}
+}
+
+// admit takes a shard for a blobber with the given labels, or returns
+// the reason the blobber can't take one more shard.
+func (t *placementTally) admit(l blobberLabels) error {
+	if !t.pc.allowsRegion(l.Region) {
+		return fmt.Errorf("region %q is not one of the required regions", l.Region)
+	}
+
+	if t.pc.MaxShardsPerOperator > 0 && t.operators[l.Operator] >= t.pc.MaxShardsPerOperator {
+		return fmt.Errorf("operator %q already holds %d shards", l.Operator, t.operators[l.Operator])
+	}
+
+	if t.pc.MaxShardsPerRegion > 0 && t.regions[l.Region] >= t.pc.MaxShardsPerRegion {
+		return fmt.Errorf("region %q already holds %d shards", l.Region, t.regions[l.Region])
+	}
+
+	t.operators[l.Operator]++
+	t.regions[l.Region]++
+	return nil
+}
+
+// pickLabels selects up to n of the given blobbers honouring the
+// constraints, keeping their order, and returns their indexes.
+func (pc *PlacementConstraints) pickLabels(labels []blobberLabels, n int) []int {
+	tally := newPlacementTally(pc)
+	picked := make([]int, 0, n)
+	for i, l := range labels {
+		if len(picked) == n {
+			break
+		}
+		if err := tally.admit(l); err != nil {
+			continue
+		}
+		picked = append(picked, i)
+	}
+	return picked
+}
+
+// pick selects the first n blobbers of the list that together honour the
+// constraints.
+func (pc *PlacementConstraints) pick(blobbers []*StorageNode, n int) ([]*StorageNode, error) {
+	labels := make([]blobberLabels, 0, len(blobbers))
+	for _, b := range blobbers {
+		labels = append(labels, b.labels())
+	}
+
+	picked := pc.pickLabels(labels, n)
+	if len(picked) < n {
+		return nil, fmt.Errorf("only %d of %d required blobbers satisfy the placement constraints", len(picked), n)
+	}
+
+	selected := make([]*StorageNode, 0, n)
+	for _, i := range picked {
+		selected = append(selected, blobbers[i])
+	}
+	return selected, nil
+}
+
+// check verifies that all the blobbers of an allocation together honour
+// the constraints.
+func (pc *PlacementConstraints) check(blobbers []*StorageNode) error {
+	tally := newPlacementTally(pc)
+	for _, b := range blobbers {
+		if err := tally.admit(b.labels()); err != nil {
+			return fmt.Errorf("blobber %s violates placement constraints: %v", b.Id(), err)
+		}
+	}
+	return nil
+}
+
+// orderByPlacement moves a selection of n blobbers honouring the
+// constraints to the front of the list, so that the first n suggested
+// blobbers can be used for the allocation as they are.
+func (pc *PlacementConstraints) orderByPlacement(ids []string, labels map[string]blobberLabels, n int) ([]string, error) {
+	ls := make([]blobberLabels, 0, len(ids))
+	for _, id := range ids {
+		ls = append(ls, labels[id])
+	}
+
+	picked := pc.pickLabels(ls, n)
+	if len(picked) < n {
+		return nil, fmt.Errorf("not enough blobbers to honor the placement constraints: %d < %d", len(picked), n)
+	}
+
+	ordered := make([]string, 0, len(ids))
+	taken := make(map[int]struct{}, len(picked))
+	for _, i := range picked {
+		ordered = append(ordered, ids[i])
+		taken[i] = struct{}{}
+	}
+	for i, id := range ids {
+		if _, ok := taken[i]; !ok {
+			ordered = append(ordered, id)
+		}
+	}
+	return ordered, nil
+}
@@ -0,0 +1,215 @@
+package storagesc
+
+import (
+	"testing"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/core/util/entitywrapper"
+	"0chain.net/smartcontract/provider"
+	"github.com/stretchr/testify/require"
+)
+
+func newLabeledBlobber(id, region, operator string) *StorageNode {
+	sn := &StorageNode{}
+	sn.SetEntity(&storageNodeV3{
+		Provider: provider.Provider{ID: id},
+		Region:   region,
+		Operator: operator,
+	})
+	return sn
+}
+
+func blobberIDs(blobbers []*StorageNode) []string {
+	ids := make([]string, 0, len(blobbers))
+	for _, b := range blobbers {
+		ids = append(ids, b.Id())
+	}
+	return ids
+}
+
+func TestValidateBlobberLabel(t *testing.T) {
+	require.NoError(t, validateBlobberLabel("region", ""))
+	require.NoError(t, validateBlobberLabel("region", "eu-west-1"))
+	require.NoError(t, validateBlobberLabel("operator", "acme.storage_2"))
+	require.Error(t, validateBlobberLabel("region", "EU"))
+	require.Error(t, validateBlobberLabel("region", "-eu"))
+	require.Error(t, validateBlobberLabel("region", "eu west"))
+	require.Error(t, validateBlobberLabel("operator", string(make([]byte, maxBlobberLabelLength+1))))
+}
+
+func TestPlacementConstraintsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		pc      PlacementConstraints
+		shards  int
+		wantErr bool
+	}{
+		{name: "empty", pc: PlacementConstraints{}, shards: 6},
+		{name: "regions", pc: PlacementConstraints{RequiredRegions: []string{"eu", "us"}}, shards: 6},
+		{name: "negative operator cap", pc: PlacementConstraints{MaxShardsPerOperator: -1}, shards: 6, wantErr: true},
+		{name: "negative region cap", pc: PlacementConstraints{MaxShardsPerRegion: -1}, shards: 6, wantErr: true},
+		{name: "empty region", pc: PlacementConstraints{RequiredRegions: []string{""}}, shards: 6, wantErr: true},
+		{name: "duplicate region", pc: PlacementConstraints{RequiredRegions: []string{"eu", "eu"}}, shards: 6, wantErr: true},
+		{
+			name:    "regions can't hold shards",
+			pc:      PlacementConstraints{RequiredRegions: []string{"eu", "us"}, MaxShardsPerRegion: 2},
+			shards:  6,
+			wantErr: true,
+		},
+		{
+			name:   "regions hold shards",
+			pc:     PlacementConstraints{RequiredRegions: []string{"eu", "us"}, MaxShardsPerRegion: 3},
+			shards: 6,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.pc.validate(tt.shards)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPlacementConstraintsPick(t *testing.T) {
+	blobbers := []*StorageNode{
+		newLabeledBlobber("b1", "eu", "acme"),
+		newLabeledBlobber("b2", "eu", "acme"),
+		newLabeledBlobber("b3", "us", "acme"),
+		newLabeledBlobber("b4", "eu", "globex"),
+		newLabeledBlobber("b5", "", ""),
+		newLabeledBlobber("b6", "ap", "initech"),
+	}
+
+	pc := PlacementConstraints{RequiredRegions: []string{"eu"}}
+	picked, err := pc.pick(blobbers, 3)
+	require.NoError(t, err)
+	require.Equal(t, []string{"b1", "b2", "b4"}, blobberIDs(picked))
+
+	pc = PlacementConstraints{MaxShardsPerOperator: 1}
+	picked, err = pc.pick(blobbers, 4)
+	require.NoError(t, err)
+	require.Equal(t, []string{"b1", "b4", "b5", "b6"}, blobberIDs(picked))
+
+	pc = PlacementConstraints{MaxShardsPerRegion: 1, MaxShardsPerOperator: 2}
+	picked, err = pc.pick(blobbers, 4)
+	require.NoError(t, err)
+	require.Equal(t, []string{"b1", "b3", "b5", "b6"}, blobberIDs(picked))
+
+	pc = PlacementConstraints{RequiredRegions: []string{"eu"}, MaxShardsPerOperator: 1}
+	_, err = pc.pick(blobbers, 3)
+	require.Error(t, err)
+}
+
+func TestPlacementConstraintsCheck(t *testing.T) {
+	pc := PlacementConstraints{RequiredRegions: []string{"eu", "us"}, MaxShardsPerOperator: 1}
+
+	require.NoError(t, pc.check([]*StorageNode{
+		newLabeledBlobber("b1", "eu", "acme"),
+		newLabeledBlobber("b2", "us", "globex"),
+	}))
+
+	require.Error(t, pc.check([]*StorageNode{
+		newLabeledBlobber("b1", "eu", "acme"),
+		newLabeledBlobber("b2", "us", "acme"),
+	}))
+
+	require.Error(t, pc.check([]*StorageNode{
+		newLabeledBlobber("b1", "eu", "acme"),
+		newLabeledBlobber("b2", "ap", "globex"),
+	}))
+
+	// blobbers without labels can't be placed in a required region
+	require.Error(t, pc.check([]*StorageNode{
+		newLabeledBlobber("b1", "", ""),
+	}))
+}
+
+func TestPlacementConstraintsOrderByPlacement(t *testing.T) {
+	labels := map[string]blobberLabels{
+		"b1": {Region: "eu", Operator: "acme"},
+		"b2": {Region: "eu", Operator: "acme"},
+		"b3": {Region: "eu", Operator: "globex"},
+		"b4": {Region: "us", Operator: "initech"},
+	}
+	ids := []string{"b1", "b2", "b3", "b4"}
+
+	pc := PlacementConstraints{MaxShardsPerOperator: 1}
+	ordered, err := pc.orderByPlacement(ids, labels, 3)
+	require.NoError(t, err)
+	require.Equal(t, []string{"b1", "b3", "b4", "b2"}, ordered)
+
+	pc = PlacementConstraints{MaxShardsPerRegion: 1}
+	_, err = pc.orderByPlacement(ids, labels, 3)
+	require.Error(t, err)
+}
+
+func TestStorageAllocationPlacementMsgp(t *testing.T) {
+	sa := &StorageAllocation{
+		ID: "alloc",
+		Placement: &PlacementConstraints{
+			RequiredRegions:      []string{"eu", "us"},
+			MaxShardsPerOperator: 2,
+			MaxShardsPerRegion:   3,
+		},
+	}
+
+	b, err := sa.MarshalMsg(nil)
+	require.NoError(t, err)
+
+	var got StorageAllocation
+	_, err = got.UnmarshalMsg(b)
+	require.NoError(t, err)
+	require.Equal(t, sa.Placement, got.Placement)
+
+	sa.Placement = nil
+	b, err = sa.MarshalMsg(nil)
+	require.NoError(t, err)
+
+	got = StorageAllocation{}
+	_, err = got.UnmarshalMsg(b)
+	require.NoError(t, err)
+	require.Nil(t, got.Placement)
+}
+
+func TestStorageNodeLabelsMsgp(t *testing.T) {
+	sn := newLabeledBlobber("b1", "eu", "acme")
+
+	b, err := sn.MarshalMsg(nil)
+	require.NoError(t, err)
+
+	var got StorageNode
+	_, err = got.UnmarshalMsg(b)
+	require.NoError(t, err)
+	require.Equal(t, blobberLabels{Region: "eu", Operator: "acme"}, got.labels())
+}
+
+func TestStorageNodeV3Migrate(t *testing.T) {
+	restricted := true
+	sn := &StorageNode{}
+	sn.SetEntity(&storageNodeV2{
+		Provider:     provider.Provider{ID: "b1"},
+		Capacity:     10,
+		IsRestricted: &restricted,
+	})
+
+	require.NoError(t, sn.Update(&storageNodeV3{}, func(e entitywrapper.EntityI) error {
+		e.(*storageNodeV3).Region = "eu"
+		return nil
+	}))
+	require.Equal(t, storageNodeV3Version, sn.Entity().GetVersion())
+	require.Equal(t, int64(10), sn.mustBase().Capacity)
+	require.True(t, sn.restricted())
+	require.Equal(t, blobberLabels{Region: "eu"}, sn.labels())
+
+	// the labels are added by hermes
+	balances := newTestBalances(t, false)
+	require.IsType(t, &storageNodeV3{}, newStorageNodeEntity(balances))
+	h := cstate.NewHardFork("hermes", balances.block.Round+1)
+	_, err := balances.InsertTrieNode(h.GetKey(), h)
+	require.NoError(t, err)
+	require.IsType(t, &storageNodeV2{}, newStorageNodeEntity(balances))
+}
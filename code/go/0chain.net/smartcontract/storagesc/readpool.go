@@ -103,7 +103,7 @@ func toJson(val interface{}) string {
 }
 
 func (rp *readPool) moveToBlobber(allocID, blobID string,
-	sp *stakePool, value currency.Coin, balances cstate.StateContextI) (resp string, err error) {
+	sp *stakePool, value, maxStake currency.Coin, balances cstate.StateContextI) (resp string, err error) {
 
 	// all redeems to response at the end
 	var redeems []readPoolRedeem
@@ -124,7 +124,7 @@ func (rp *readPool) moveToBlobber(allocID, blobID string,
 
 	rp.Balance = currentBalance
 
-	err = sp.DistributeRewards(value, blobID, spenum.Blobber, spenum.FileDownloadReward, balances, allocID)
+	err = sp.DistributeRewards(value, blobID, spenum.Blobber, spenum.FileDownloadReward, maxStake, balances, allocID)
 	if err != nil {
 		return "", fmt.Errorf("can't move tokens to blobber: %v", err)
 	}
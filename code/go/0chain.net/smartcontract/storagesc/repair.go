@@ -0,0 +1,404 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/encryption"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/stakepool/spenum"
+)
+
+//go:generate msgp -io=false -tests=false -v
+
+const (
+	repairReasonKilled        = "killed"
+	repairReasonShutdown      = "shutdown"
+	repairReasonLowReputation = "low_reputation"
+)
+
+// maxRepairCandidates limits the number of blobbers, supplied and sampled
+// together, a repair tries as replacement.
+const maxRepairCandidates = 20
+
+// AutoRepairPolicy lets the storage SC replace the degraded blobbers of an
+// allocation. A blobber is degraded when it is killed, shut down or its
+// reputation score drops below the policy minimum.
+type AutoRepairPolicy struct {
+	// MinReputation is the reputation score below which a blobber of the
+	// allocation is degraded, only killed and shut down blobbers are if
+	// zero. A replacement blobber must have at least this score.
+	MinReputation float64 `json:"min_reputation"`
+}
+
+func (p *AutoRepairPolicy) validate() error {
+	if p.MinReputation < 0 || p.MinReputation > 1 {
+		return errors.New("min_reputation not in [0; 1] range")
+	}
+	return nil
+}
+
+// degradedReason returns why the blobber with the given reputation score
+// is degraded, empty if it isn't.
+func (p *AutoRepairPolicy) degradedReason(blobber *StorageNode, score int64) string {
+	bb := blobber.mustBase()
+	switch {
+	case bb.IsKilled():
+		return repairReasonKilled
+	case bb.IsShutDown():
+		return repairReasonShutdown
+	case p.MinReputation > 0 && score < toReputationFixed(p.MinReputation):
+		return repairReasonLowReputation
+	default:
+		return ""
+	}
+}
+
+type repairAllocationRequest struct {
+	AllocationID string `json:"allocation_id"`
+	BlobberID    string `json:"blobber_id"`
+	// Candidates are blobbers suggested as replacement, tried before the
+	// randomly sampled ones. Only the owner can suggest them, anyone else
+	// could steer the repair to their own blobbers.
+	Candidates []string `json:"candidates,omitempty"`
+}
+
+func (rar *repairAllocationRequest) decode(b []byte) error {
+	return json.Unmarshal(b, rar)
+}
+
+// recordFailedChallenge records a failed challenge in the reputation of
+// the blobber. If the failure degrades the blobber of an auto repaired
+// allocation, the degraded repair event is emitted for the owner and the
+// blobbers to notice.
+func (sa *StorageAllocation) recordFailedChallenge(blobberID string, balances cstate.StateContextI) error {
+	if sa.AutoRepair == nil || sa.AutoRepair.MinReputation <= 0 {
+		return recordChallengeOutcome(blobberID, false, balances)
+	}
+
+	conf, err := getConfig(balances)
+	if err != nil {
+		return fmt.Errorf("can't get config: %v", err)
+	}
+
+	before, err := getBlobberReputation(blobberID, balances)
+	if err != nil {
+		return fmt.Errorf("can't get blobber reputation: %v", err)
+	}
+
+	if err := recordChallengeOutcome(blobberID, false, balances); err != nil {
+		return err
+	}
+
+	after, err := getBlobberReputation(blobberID, balances)
+	if err != nil {
+		return fmt.Errorf("can't get blobber reputation: %v", err)
+	}
+
+	minScore := toReputationFixed(sa.AutoRepair.MinReputation)
+	if before.score(conf.Reputation) >= minScore && after.score(conf.Reputation) < minScore {
+		emitAllocationRepair(sa.ID, blobberID, "", repairReasonLowReputation,
+			event.AllocationRepairDegraded, balances)
+	}
+	return nil
+}
+
+// checkReplacement returns the reason the candidate with the given
+// reputation score can't replace the removed blobber of the allocation.
+func (sa *StorageAllocation) checkReplacement(
+	candidate *StorageNode,
+	score int64,
+	blobbers []*StorageNode,
+	removeID string,
+) error {
+	cb := candidate.mustBase()
+	if _, ok := sa.BlobberAllocsMap[cb.ID]; ok {
+		return fmt.Errorf("blobber %s is already in the allocation", cb.ID)
+	}
+
+	if cb.IsKilled() || cb.IsShutDown() {
+		return fmt.Errorf("blobber %s is killed or shut down", cb.ID)
+	}
+
+	// restricted blobbers need an auth ticket of the owner
+	if candidate.restricted() {
+		return fmt.Errorf("blobber %s is restricted", cb.ID)
+	}
+
+	if score < toReputationFixed(sa.AutoRepair.MinReputation) {
+		return fmt.Errorf("blobber %s reputation %.4f is below %.4f",
+			cb.ID, reputationRatio(score), sa.AutoRepair.MinReputation)
+	}
+
+	if sa.Placement != nil {
+		swapped := make([]*StorageNode, 0, len(blobbers))
+		for _, b := range blobbers {
+			if b.Id() == removeID {
+				swapped = append(swapped, candidate)
+				continue
+			}
+			swapped = append(swapped, b)
+		}
+		if err := sa.Placement.check(swapped); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// selectReplacement returns the first of the candidates able to replace
+// the removed blobber of the allocation.
+func (sa *StorageAllocation) selectReplacement(
+	ids []string,
+	blobbers []*StorageNode,
+	removeID string,
+	conf *Config,
+	now common.Timestamp,
+	balances cstate.StateContextI,
+) (string, error) {
+	errs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		candidate, err := getBlobber(id, balances)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("can't get blobber %s: %v", id, err))
+			continue
+		}
+
+		br, err := getBlobberReputation(id, balances)
+		if err != nil {
+			return "", fmt.Errorf("can't get blobber %s reputation: %v", id, err)
+		}
+
+		if err := sa.checkReplacement(candidate, br.score(conf.Reputation), blobbers, removeID); err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+
+		sp, err := getStakePool(spenum.Blobber, id, balances)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("can't get blobber %s stake pool: %v", id, err))
+			continue
+		}
+
+		staked, err := sp.stake()
+		if err != nil {
+			return "", err
+		}
+
+		stakedCapacity, err := sp.stakedCapacity(candidate.mustBase().Terms.WritePrice)
+		if err != nil {
+			return "", err
+		}
+
+		// price ranges, free capacity and health of the blobber
+		if err := sa.isActive(candidate, staked, sp.TotalOffers, stakedCapacity, conf, now); err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+
+		return id, nil
+	}
+
+	return "", fmt.Errorf("no replacement blobber found: %s", strings.Join(errs, "; "))
+}
+
+// repairCandidates lists the blobbers to try as replacement, the supplied
+// ones first and then a random sample of the challenge ready blobbers.
+func repairCandidates(supplied []string, seed int64, balances cstate.StateContextI) ([]string, error) {
+	ids := make([]string, 0, maxRepairCandidates)
+	seen := make(map[string]struct{}, maxRepairCandidates)
+	add := func(id string) {
+		if _, ok := seen[id]; ok || len(ids) == maxRepairCandidates {
+			return
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+
+	for _, id := range supplied {
+		add(id)
+	}
+
+	if len(ids) == maxRepairCandidates {
+		return ids, nil
+	}
+
+	parts, _, err := partitionsChallengeReadyBlobbers(balances)
+	if err != nil {
+		return nil, fmt.Errorf("can't get challenge ready blobbers: %v", err)
+	}
+
+	size, err := parts.Size(balances)
+	if err != nil {
+		return nil, fmt.Errorf("can't get challenge ready blobbers: %v", err)
+	}
+	if size == 0 {
+		return ids, nil
+	}
+
+	var sample []ChallengeReadyBlobber
+	if err := parts.GetRandomItems(balances, rand.New(rand.NewSource(seed)), &sample); err != nil {
+		return nil, fmt.Errorf("can't sample challenge ready blobbers: %v", err)
+	}
+
+	for _, crb := range sample {
+		add(crb.BlobberID)
+	}
+	return ids, nil
+}
+
+// repairAllocation replaces a degraded blobber of an allocation opted in
+// to auto repair. Anyone can send it; the replacement is paid from the
+// allocation's write pool, and the blobbers and the client SDK re-upload
+// the missing shards on the repaired event. Available after hermes.
+func (sc *StorageSmartContract) repairAllocation(
+	t *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	if err := cstate.WithActivation(balances, "hermes", func() error {
+		return common.NewError("repair_allocation_failed", "auto repair is not enabled yet")
+	}, func() error {
+		return nil
+	}); err != nil {
+		return "", err
+	}
+
+	var req repairAllocationRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("repair_allocation_failed",
+			"invalid request: "+err.Error())
+	}
+
+	conf, err := getConfig(balances)
+	if err != nil {
+		return "", common.NewError("repair_allocation_failed",
+			"can't get config: "+err.Error())
+	}
+
+	alloc, err := sc.getAllocation(req.AllocationID, balances)
+	if err != nil {
+		return "", common.NewError("repair_allocation_failed",
+			"can't get allocation: "+err.Error())
+	}
+
+	if alloc.AutoRepair == nil {
+		return "", common.NewError("repair_allocation_failed",
+			"allocation is not opted in to auto repair")
+	}
+
+	if alloc.Finalized || alloc.Canceled {
+		return "", common.NewError("repair_allocation_failed",
+			"allocation is finalized")
+	}
+
+	if alloc.Expiration < t.CreationDate {
+		return "", common.NewError("repair_allocation_failed",
+			"can't repair expired allocation")
+	}
+
+	if _, ok := alloc.BlobberAllocsMap[req.BlobberID]; !ok {
+		return "", common.NewErrorf("repair_allocation_failed",
+			"blobber %s is not in the allocation", req.BlobberID)
+	}
+
+	blobbers, err := sc.getAllocationBlobbers(alloc, balances)
+	if err != nil {
+		return "", common.NewError("repair_allocation_failed", err.Error())
+	}
+
+	var removed *StorageNode
+	for _, b := range blobbers {
+		if b.Id() == req.BlobberID {
+			removed = b
+			break
+		}
+	}
+	if removed == nil {
+		return "", common.NewErrorf("repair_allocation_failed",
+			"can't get blobber %s", req.BlobberID)
+	}
+
+	br, err := getBlobberReputation(req.BlobberID, balances)
+	if err != nil {
+		return "", common.NewError("repair_allocation_failed",
+			"can't get blobber reputation: "+err.Error())
+	}
+
+	reason := alloc.AutoRepair.degradedReason(removed, br.score(conf.Reputation))
+	if reason == "" {
+		return "", common.NewErrorf("repair_allocation_failed",
+			"blobber %s is not degraded", req.BlobberID)
+	}
+
+	// seeded by the block, the sender can't grind the transaction hash to
+	// pick the replacement
+	roundSeed := strconv.FormatInt(balances.GetBlock().GetRoundRandomSeed(), 10)
+	seedSource, err := strconv.ParseUint(encryption.Hash(roundSeed + alloc.ID)[0:16], 16, 64)
+	if err != nil {
+		return "", common.NewErrorf("repair_allocation_failed",
+			"creating repair seed: %v", err)
+	}
+
+	var candidates []string
+	if t.ClientID == alloc.Owner {
+		candidates = req.Candidates
+	}
+
+	ids, err := repairCandidates(candidates, int64(seedSource), balances)
+	if err != nil {
+		return "", common.NewError("repair_allocation_failed", err.Error())
+	}
+
+	addID, err := alloc.selectReplacement(ids, blobbers, req.BlobberID, conf, t.CreationDate, balances)
+	if err != nil {
+		return "", common.NewError("repair_allocation_failed", err.Error())
+	}
+
+	alloc.Tx = t.Hash
+
+	blobbers, err = alloc.changeBlobbers(
+		conf, blobbers, addID, "", req.BlobberID, t.CreationDate, balances, sc, alloc.Owner,
+	)
+	if err != nil {
+		return "", common.NewError("repair_allocation_failed", err.Error())
+	}
+
+	cp, err := sc.getChallengePool(alloc.ID, balances)
+	if err != nil {
+		return "", common.NewError("repair_allocation_failed", err.Error())
+	}
+
+	tokensRequiredToLock, err := alloc.requiredTokensForUpdateAllocation(cp.Balance, false, t.CreationDate)
+	if err != nil {
+		return "", common.NewError("repair_allocation_failed", err.Error())
+	}
+
+	if tokensRequiredToLock > 0 {
+		return "", common.NewErrorf("repair_allocation_failed",
+			"write pool can't cover the replacement, %v more tokens required", tokensRequiredToLock)
+	}
+
+	if err := alloc.saveUpdatedAllocation(blobbers, balances); err != nil {
+		return "", common.NewError("repair_allocation_failed", err.Error())
+	}
+
+	balances.EmitEvent(event.TypeStats, event.TagDeleteAllocationBlobberTerm, t.Hash, []event.AllocationBlobberTerm{
+		{
+			AllocationIdHash: alloc.ID,
+			BlobberID:        req.BlobberID,
+		},
+	})
+	emitAddOrOverwriteAllocationBlobberTerms(alloc, balances, t)
+	emitAllocationRepair(alloc.ID, req.BlobberID, addID, reason, event.AllocationRepairRepaired, balances)
+
+	return string(alloc.Encode()), nil
+}
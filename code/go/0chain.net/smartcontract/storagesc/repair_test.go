@@ -0,0 +1,105 @@
+package storagesc
+
+import (
+	"testing"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutoRepairPolicyValidate(t *testing.T) {
+	require.NoError(t, (&AutoRepairPolicy{}).validate())
+	require.NoError(t, (&AutoRepairPolicy{MinReputation: 1}).validate())
+	require.Error(t, (&AutoRepairPolicy{MinReputation: -0.1}).validate())
+	require.Error(t, (&AutoRepairPolicy{MinReputation: 1.1}).validate())
+}
+
+func TestAutoRepairPolicyDegradedReason(t *testing.T) {
+	p := &AutoRepairPolicy{MinReputation: 0.5}
+
+	b := newLabeledBlobber("b1", "", "")
+	require.Equal(t, "", p.degradedReason(b, toReputationFixed(0.5)))
+	require.Equal(t, repairReasonLowReputation, p.degradedReason(b, toReputationFixed(0.4)))
+
+	b.Entity().(*storageNodeV2).HasBeenShutDown = true
+	require.Equal(t, repairReasonShutdown, p.degradedReason(b, toReputationFixed(1)))
+
+	b.Entity().(*storageNodeV2).HasBeenKilled = true
+	require.Equal(t, repairReasonKilled, p.degradedReason(b, toReputationFixed(1)))
+
+	// only killed and shut down blobbers are degraded without a minimum
+	p.MinReputation = 0
+	require.Equal(t, "", p.degradedReason(newLabeledBlobber("b2", "", ""), 0))
+}
+
+func TestStorageAllocationCheckReplacement(t *testing.T) {
+	blobbers := []*StorageNode{
+		newLabeledBlobber("b1", "eu", "acme"),
+		newLabeledBlobber("b2", "us", "acme"),
+	}
+	sa := &StorageAllocation{
+		AutoRepair: &AutoRepairPolicy{MinReputation: 0.5},
+		Placement:  &PlacementConstraints{MaxShardsPerRegion: 1},
+		BlobberAllocsMap: map[string]*BlobberAllocation{
+			"b1": {BlobberID: "b1"},
+			"b2": {BlobberID: "b2"},
+		},
+	}
+
+	require.NoError(t, sa.checkReplacement(newLabeledBlobber("b3", "eu", "other"), toReputationFixed(0.9), blobbers, "b1"))
+	require.Error(t, sa.checkReplacement(newLabeledBlobber("b3", "us", "other"), toReputationFixed(0.9), blobbers, "b1"),
+		"region us holds a shard already")
+	require.Error(t, sa.checkReplacement(newLabeledBlobber("b3", "eu", "other"), toReputationFixed(0.4), blobbers, "b1"),
+		"reputation below the policy minimum")
+	require.Error(t, sa.checkReplacement(newLabeledBlobber("b2", "eu", "acme"), toReputationFixed(0.9), blobbers, "b1"),
+		"blobber in the allocation already")
+
+	killed := newLabeledBlobber("b3", "eu", "other")
+	killed.Entity().(*storageNodeV2).HasBeenKilled = true
+	require.Error(t, sa.checkReplacement(killed, toReputationFixed(0.9), blobbers, "b1"))
+
+	restricted := newLabeledBlobber("b3", "eu", "other")
+	isRestricted := true
+	restricted.Entity().(*storageNodeV2).IsRestricted = &isRestricted
+	require.Error(t, sa.checkReplacement(restricted, toReputationFixed(0.9), blobbers, "b1"))
+}
+
+func TestStorageAllocationAutoRepairMsgp(t *testing.T) {
+	sa := &StorageAllocation{
+		ID:         "alloc",
+		AutoRepair: &AutoRepairPolicy{MinReputation: 0.75},
+	}
+
+	b, err := sa.MarshalMsg(nil)
+	require.NoError(t, err)
+
+	var got StorageAllocation
+	_, err = got.UnmarshalMsg(b)
+	require.NoError(t, err)
+	require.Equal(t, sa.AutoRepair, got.AutoRepair)
+
+	sa.AutoRepair = nil
+	b, err = sa.MarshalMsg(nil)
+	require.NoError(t, err)
+
+	got = StorageAllocation{}
+	_, err = got.UnmarshalMsg(b)
+	require.NoError(t, err)
+	require.Nil(t, got.AutoRepair)
+}
+
+func TestRepairAllocationBeforeHermes(t *testing.T) {
+	var (
+		balances = newTestBalances(t, false)
+		ssc      = newTestStorageSC()
+		txn      = newTransaction("client", ADDRESS, 0, 1000)
+	)
+	h := cstate.NewHardFork("hermes", balances.block.Round+1)
+	_, err := balances.InsertTrieNode(h.GetKey(), h)
+	require.NoError(t, err)
+
+	_, err = ssc.repairAllocation(txn,
+		[]byte(`{"allocation_id":"alloc","blobber_id":"b1"}`), balances)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not enabled")
+}
@@ -0,0 +1,283 @@
+package storagesc
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/smartcontract/dbs/event"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/util"
+)
+
+//go:generate msgp -io=false -tests=false -v
+
+func blobberReputationKey(blobberID string) datastore.Key {
+	return datastore.Key(ADDRESS + ":blobberreputation:" + blobberID)
+}
+
+// reputationScale is the fixed point scale of the reputation counts, ratios
+// and scores, a ratio of 1 is reputationScale.
+const reputationScale int64 = 1000000
+
+// toReputationFixed converts a configured ratio to the fixed point.
+func toReputationFixed(ratio float64) int64 {
+	return int64(math.Round(ratio * float64(reputationScale)))
+}
+
+// reputationRatio converts a fixed point ratio to a float, for the reports.
+func reputationRatio(v int64) float64 {
+	return float64(v) / float64(reputationScale)
+}
+
+// mulDiv returns a*b/c of the non negative a, b and positive c, saturated to
+// the max int64.
+func mulDiv(a, b, c int64) int64 {
+	hi, lo := bits.Mul64(uint64(a), uint64(b))
+	if hi >= uint64(c) {
+		return math.MaxInt64
+	}
+	q, _ := bits.Div64(hi, lo, uint64(c))
+	if q > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return int64(q)
+}
+
+// BlobberReputation keeps the challenge and health check history of a
+// blobber. The history decays on every new outcome, so the recent
+// behaviour of the blobber weighs the most.
+type BlobberReputation struct {
+	BlobberID string `json:"blobber_id"`
+	// Passed and Total are the decayed counts of the passed and of all
+	// the finalized challenges, in reputationScale units.
+	Passed int64 `json:"passed"`
+	Total  int64 `json:"total"`
+	// Uptime and Downtime are the decayed seconds the blobber was up and
+	// down between its health checks.
+	Uptime   int64 `json:"uptime"`
+	Downtime int64 `json:"downtime"`
+}
+
+// passRatio returns the share of passed challenges. A blobber without
+// finalized challenges is given the benefit of the doubt.
+func (br *BlobberReputation) passRatio() int64 {
+	if br.Total <= 0 {
+		return reputationScale
+	}
+	return mulDiv(br.Passed, reputationScale, br.Total)
+}
+
+// uptimeRatio returns the share of time the blobber was up. A blobber
+// without health checks is given the benefit of the doubt.
+func (br *BlobberReputation) uptimeRatio() int64 {
+	total := br.Uptime + br.Downtime
+	if total <= 0 {
+		return reputationScale
+	}
+	return mulDiv(br.Uptime, reputationScale, total)
+}
+
+// score of the blobber in [0; reputationScale] range.
+func (br *BlobberReputation) score(conf reputationConfig) int64 {
+	uw := toReputationFixed(conf.UptimeWeight)
+	return mulDiv(reputationScale-uw, br.passRatio(), reputationScale) +
+		mulDiv(uw, br.uptimeRatio(), reputationScale)
+}
+
+func (br *BlobberReputation) recordChallenge(passed bool, decay int64) {
+	br.Total = mulDiv(br.Total, decay, reputationScale) + reputationScale
+	br.Passed = mulDiv(br.Passed, decay, reputationScale)
+	if passed {
+		br.Passed += reputationScale
+	}
+}
+
+func (br *BlobberReputation) recordHealthCheck(prev, now common.Timestamp, downtime uint64, decay int64) {
+	if prev <= 0 || now <= prev {
+		return
+	}
+
+	var (
+		interval = int64(now - prev)
+		down     = interval
+	)
+	if downtime < uint64(interval) {
+		down = int64(downtime)
+	}
+
+	br.Uptime = mulDiv(br.Uptime, decay, reputationScale) + interval - down
+	br.Downtime = mulDiv(br.Downtime, decay, reputationScale) + down
+}
+
+// rewardShare returns the part of a challenge reward earned by a blobber
+// with the given score, the rest is returned to the allocation.
+func (conf reputationConfig) rewardShare(score int64) int64 {
+	rw := toReputationFixed(conf.RewardWeight)
+	return reputationScale - mulDiv(rw, reputationScale-score, reputationScale)
+}
+
+func getBlobberReputation(
+	blobberID string,
+	balances cstate.CommonStateContextI,
+) (*BlobberReputation, error) {
+	br := &BlobberReputation{BlobberID: blobberID}
+	err := balances.GetTrieNode(blobberReputationKey(blobberID), br)
+	switch err {
+	case nil, util.ErrValueNotPresent:
+		return br, nil
+	default:
+		return nil, err
+	}
+}
+
+// updateBlobberReputation applies the update to the reputation of the
+// blobber, saves it and emits the new score. The reputations are kept since
+// the hermes hard fork.
+func updateBlobberReputation(
+	blobberID string,
+	balances cstate.StateContextI,
+	update func(br *BlobberReputation, conf reputationConfig),
+) error {
+	return cstate.WithActivation(balances, "hermes", func() error {
+		return nil
+	}, func() error {
+		conf, err := getConfig(balances)
+		if err != nil {
+			return fmt.Errorf("can't get config: %v", err)
+		}
+
+		br, err := getBlobberReputation(blobberID, balances)
+		if err != nil {
+			return fmt.Errorf("can't get blobber reputation: %v", err)
+		}
+
+		update(br, conf.Reputation)
+
+		if _, err := balances.InsertTrieNode(blobberReputationKey(blobberID), br); err != nil {
+			return fmt.Errorf("can't save blobber reputation: %v", err)
+		}
+
+		emitUpdateBlobberReputation(blobberID, reputationRatio(br.score(conf.Reputation)), balances)
+		return nil
+	})
+}
+
+// recordChallengeOutcome updates the reputation of a blobber with the
+// outcome of one of its challenges.
+func recordChallengeOutcome(blobberID string, passed bool, balances cstate.StateContextI) error {
+	return updateBlobberReputation(blobberID, balances, func(br *BlobberReputation, conf reputationConfig) {
+		br.recordChallenge(passed, toReputationFixed(conf.Decay))
+	})
+}
+
+// recordHealthCheck updates the reputation of a blobber with the time
+// passed since its previous health check.
+func recordHealthCheck(
+	blobberID string,
+	prev, now common.Timestamp,
+	downtime uint64,
+	balances cstate.StateContextI,
+) error {
+	return updateBlobberReputation(blobberID, balances, func(br *BlobberReputation, conf reputationConfig) {
+		br.recordHealthCheck(prev, now, downtime, toReputationFixed(conf.Decay))
+	})
+}
+
+// withholdByReputation scales a challenge reward of the blobber by its
+// reputation score, since the hermes hard fork. The withheld part of the
+// reward is moved from the challenge pool back to the allocation's write
+// pool; the earned part is returned.
+func withholdByReputation(
+	alloc *StorageAllocation,
+	blobAlloc *BlobberAllocation,
+	cp *challengePool,
+	reward currency.Coin,
+	conf *Config,
+	balances cstate.StateContextI,
+) (currency.Coin, error) {
+	if conf.Reputation.RewardWeight <= 0 || reward == 0 {
+		return reward, nil
+	}
+
+	active := false
+	if err := cstate.WithActivation(balances, "hermes", func() error {
+		return nil
+	}, func() error {
+		active = true
+		return nil
+	}); err != nil || !active {
+		return reward, err
+	}
+
+	br, err := getBlobberReputation(blobAlloc.BlobberID, balances)
+	if err != nil {
+		return 0, fmt.Errorf("can't get blobber reputation: %v", err)
+	}
+
+	rv, err := reward.Int64()
+	if err != nil {
+		return 0, err
+	}
+	share := conf.Reputation.rewardShare(br.score(conf.Reputation))
+	earned := currency.Coin(mulDiv(rv, share, reputationScale))
+
+	withheld, err := currency.MinusCoin(reward, earned)
+	if err != nil || withheld == 0 {
+		return reward, err
+	}
+
+	if err := alloc.moveFromChallengePool(cp, withheld); err != nil {
+		return 0, fmt.Errorf("moving withheld reward back to write pool: %v", err)
+	}
+
+	coin, _ := withheld.Int64()
+	balances.EmitEvent(event.TypeStats, event.TagFromChallengePool, cp.ID, event.ChallengePoolLock{
+		Client:       alloc.Owner,
+		AllocationId: alloc.ID,
+		Amount:       coin,
+	})
+
+	moveBack, err := currency.AddCoin(alloc.MovedBack, withheld)
+	if err != nil {
+		return 0, err
+	}
+	alloc.MovedBack = moveBack
+
+	returned, err := currency.AddCoin(blobAlloc.Returned, withheld)
+	if err != nil {
+		return 0, err
+	}
+	blobAlloc.Returned = returned
+
+	return earned, nil
+}
+
+// filterByReputation drops the blobbers with a reputation score below
+// the minimum, adding the reason to the errs.
+func filterByReputation(
+	blobbers []*StorageNode,
+	errs []string,
+	minScore float64,
+	conf *Config,
+	balances cstate.CommonStateContextI,
+) ([]*StorageNode, []string, error) {
+	filtered := make([]*StorageNode, 0, len(blobbers))
+	for _, b := range blobbers {
+		br, err := getBlobberReputation(b.Id(), balances)
+		if err != nil {
+			return nil, nil, fmt.Errorf("can't get blobber %s reputation: %v", b.Id(), err)
+		}
+
+		if score := br.score(conf.Reputation); score < toReputationFixed(minScore) {
+			errs = append(errs, fmt.Sprintf("blobber %s reputation %.4f is below %.4f",
+				b.Id(), reputationRatio(score), minScore))
+			continue
+		}
+		filtered = append(filtered, b)
+	}
+	return filtered, errs, nil
+}
@@ -0,0 +1,116 @@
+package storagesc
+
+import (
+	"math"
+	"testing"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobberReputationScore(t *testing.T) {
+	var (
+		conf  = reputationConfig{Decay: 0.5, UptimeWeight: 0.2, RewardWeight: 0.5}
+		decay = toReputationFixed(conf.Decay)
+	)
+
+	br := &BlobberReputation{BlobberID: "b1"}
+	require.Equal(t, reputationScale, br.score(conf), "blobber without history")
+
+	br.recordChallenge(true, decay)
+	br.recordChallenge(false, decay)
+	// passed 0.5, total 1.5
+	require.Equal(t, int64(333333), br.passRatio())
+
+	br.recordChallenge(false, decay)
+	// passed 0.25, total 1.75, recent failures weigh more than the older pass
+	require.Equal(t, int64(142857), br.passRatio())
+
+	br.recordHealthCheck(0, 100, 0, decay)
+	require.Equal(t, reputationScale, br.uptimeRatio(), "first health check has no interval")
+
+	br.recordHealthCheck(100, 200, 40, decay)
+	require.Equal(t, int64(600000), br.uptimeRatio())
+
+	// 0.8 * 0.142857 + 0.2 * 0.6
+	require.Equal(t, int64(114285+120000), br.score(conf))
+
+	// the downtime is bounded by the interval
+	br.recordHealthCheck(200, 210, 100, decay)
+	require.Equal(t, int64(20+10), br.Downtime)
+	require.Equal(t, int64(30), br.Uptime)
+}
+
+func TestReputationRewardShare(t *testing.T) {
+	conf := reputationConfig{RewardWeight: 0}
+	require.Equal(t, reputationScale, conf.rewardShare(toReputationFixed(0.2)))
+
+	conf.RewardWeight = 0.5
+	require.Equal(t, reputationScale, conf.rewardShare(reputationScale))
+	require.Equal(t, toReputationFixed(0.6), conf.rewardShare(toReputationFixed(0.2)))
+
+	conf.RewardWeight = 1
+	require.Equal(t, toReputationFixed(0.2), conf.rewardShare(toReputationFixed(0.2)))
+}
+
+func TestReputationMulDiv(t *testing.T) {
+	require.Equal(t, int64(6), mulDiv(4, 3, 2))
+	require.Equal(t, int64(math.MaxInt64/2), mulDiv(math.MaxInt64, reputationScale/2, reputationScale),
+		"no overflow of the product")
+	require.Equal(t, int64(math.MaxInt64), mulDiv(math.MaxInt64, 2, 1), "saturated")
+}
+
+func TestReputationBeforeHermes(t *testing.T) {
+	balances := newTestBalances(t, false)
+	conf := setConfig(t, balances)
+	conf.Reputation = reputationConfig{Decay: 0.5, UptimeWeight: 0.2, RewardWeight: 1}
+	_, err := balances.InsertTrieNode(scConfigKey(ADDRESS), conf)
+	require.NoError(t, err)
+
+	h := cstate.NewHardFork("hermes", balances.block.Round+1)
+	_, err = balances.InsertTrieNode(h.GetKey(), h)
+	require.NoError(t, err)
+
+	require.NoError(t, recordChallengeOutcome("b1", false, balances))
+	require.NoError(t, recordHealthCheck("b1", 100, 200, 100, balances))
+	err = balances.GetTrieNode(blobberReputationKey("b1"), &BlobberReputation{})
+	require.Equal(t, util.ErrValueNotPresent, err, "nothing saved")
+
+	earned, err := withholdByReputation(&StorageAllocation{}, &BlobberAllocation{BlobberID: "b1"},
+		nil, 100, conf, balances)
+	require.NoError(t, err)
+	require.Equal(t, currency.Coin(100), earned, "nothing withheld")
+}
+
+func TestBlobberReputationMsgp(t *testing.T) {
+	br := &BlobberReputation{
+		BlobberID: "b1",
+		Passed:    1500000,
+		Total:     2250000,
+		Uptime:    3600,
+		Downtime:  60,
+	}
+
+	b, err := br.MarshalMsg(nil)
+	require.NoError(t, err)
+
+	var got BlobberReputation
+	_, err = got.UnmarshalMsg(b)
+	require.NoError(t, err)
+	require.Equal(t, *br, got)
+}
+
+func TestConfigReputationMsgp(t *testing.T) {
+	conf := newConfig()
+	conf.Reputation = reputationConfig{Decay: 0.95, UptimeWeight: 0.2, RewardWeight: 0.5}
+
+	b, err := conf.MarshalMsg(nil)
+	require.NoError(t, err)
+
+	got := newConfig()
+	_, err = got.UnmarshalMsg(b)
+	require.NoError(t, err)
+	require.Equal(t, conf.Reputation, got.Reputation)
+}
@@ -68,10 +68,17 @@ func (ssc *StorageSmartContract) setSC(sc *sci.SmartContract, _ sci.BCContextI)
 	ssc.SmartContractExecutionStats["new_allocation_request"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "new_allocation_request"), nil)
 	ssc.SmartContractExecutionStats["update_allocation_request"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "update_allocation_request"), nil)
 	ssc.SmartContractExecutionStats["finalize_allocation"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "finalize_allocation"), nil)
+	ssc.SmartContractExecutionStats[FinalizeExpiredAllocationFuncName] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, FinalizeExpiredAllocationFuncName), nil)
+	ssc.SmartContractExecutionStats[BackfillAllocationExpiryFuncName] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, BackfillAllocationExpiryFuncName), nil)
 	ssc.SmartContractExecutionStats["cancel_allocation"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "cancel_allocation"), nil)
+	ssc.SmartContractExecutionStats["repair_allocation"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "repair_allocation"), nil)
+	ssc.SmartContractExecutionStats["propose_allocation_owner"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "propose_allocation_owner"), nil)
+	ssc.SmartContractExecutionStats["accept_allocation_owner"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "accept_allocation_owner"), nil)
+	ssc.SmartContractExecutionStats["cancel_allocation_owner"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "cancel_allocation_owner"), nil)
 	ssc.SmartContractExecutionStats["free_allocation_request"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "free_allocation_request"), nil)
 	// challenge
 	ssc.SmartContractExecutionStats["challenge_response"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "challenge_response"), nil)
+	ssc.SmartContractExecutionStats["challenge_response_batch"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "challenge_response_batch"), nil)
 	ssc.SmartContractExecutionStats["generate_challenge"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "generate_challenge"), nil)
 	// validator
 	ssc.SmartContractExecutionStats["add_validator"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "add_validator (add/update SC function)"), nil)
@@ -106,6 +113,8 @@ func (ssc *StorageSmartContract) setSC(sc *sci.SmartContract, _ sci.BCContextI)
 	// stake pool
 	ssc.SmartContractExecutionStats["stake_pool_lock"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "stake_pool_lock"), nil)
 	ssc.SmartContractExecutionStats["stake_pool_unlock"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "stake_pool_unlock"), nil)
+	ssc.SmartContractExecutionStats["redelegate"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "redelegate"), nil)
+	ssc.SmartContractExecutionStats["stake_pool_auto_compound"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "stake_pool_auto_compound"), nil)
 	ssc.SmartContractExecutionStats["pay_reward"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "pay_reward (add/update/remove SC function)"), nil)
 
 }
@@ -189,8 +198,20 @@ func (sc *StorageSmartContract) Execute(t *transaction.Transaction,
 		resp, err = sc.updateAllocationRequest(t, input, balances)
 	case "finalize_allocation":
 		resp, err = sc.finalizeAllocation(t, input, balances)
+	case FinalizeExpiredAllocationFuncName:
+		resp, err = sc.finalizeExpiredAllocation(t, input, balances)
+	case BackfillAllocationExpiryFuncName:
+		resp, err = sc.backfillAllocationExpiry(t, input, balances)
 	case "cancel_allocation":
 		resp, err = sc.cancelAllocationRequest(t, input, balances)
+	case "repair_allocation":
+		resp, err = sc.repairAllocation(t, input, balances)
+	case "propose_allocation_owner":
+		resp, err = sc.proposeOwnershipTransfer(t, input, balances)
+	case "accept_allocation_owner":
+		resp, err = sc.acceptOwnershipTransfer(t, input, balances)
+	case "cancel_allocation_owner":
+		resp, err = sc.cancelOwnershipTransfer(t, input, balances)
 
 	// free allocations
 
@@ -243,6 +264,10 @@ func (sc *StorageSmartContract) Execute(t *transaction.Transaction,
 		resp, err = sc.stakePoolLock(t, input, balances)
 	case "stake_pool_unlock":
 		resp, err = sc.stakePoolUnlock(t, input, balances)
+	case "redelegate":
+		resp, err = sc.redelegate(t, input, balances)
+	case "stake_pool_auto_compound":
+		resp, err = sc.stakePoolAutoCompound(t, input, balances)
 	case "collect_reward":
 		resp, err = sc.collectReward(t, input, balances)
 	case "generate_challenge":
@@ -262,6 +287,8 @@ func (sc *StorageSmartContract) Execute(t *transaction.Transaction,
 
 	case "challenge_response":
 		resp, err = sc.verifyChallenge(t, input, balances)
+	case "challenge_response_batch":
+		resp, err = sc.verifyChallengeBatch(t, input, balances)
 
 	// configurations
 
@@ -442,3 +442,27 @@ func (ssc *StorageSmartContract) stakePoolUnlock(
 	actErr := chainstate.WithActivation(balances, "apollo", beforeFunc, afterFunc)
 	return resp, actErr
 }
+
+// enable or disable compounding of the rewards of a delegate pool
+func (ssc *StorageSmartContract) stakePoolAutoCompound(
+	t *transaction.Transaction,
+	input []byte,
+	balances chainstate.StateContextI,
+) (resp string, err error) {
+	return stakepool.StakePoolAutoCompound(t, input, balances, ssc.getStakePoolAdapter)
+}
+
+// move delegated stake between two blobbers or two validators
+func (ssc *StorageSmartContract) redelegate(
+	t *transaction.Transaction,
+	input []byte,
+	balances chainstate.StateContextI,
+) (resp string, err error) {
+	gn, err := getConfig(balances)
+	if err != nil {
+		return "", err
+	}
+	return stakepool.StakePoolRedelegate(t, input, balances,
+		stakepool.ValidationSettings{MaxStake: gn.MaxStake, MinStake: gn.MinStake, MaxNumDelegates: gn.MaxDelegates},
+		ssc.getStakePoolAdapter, ssc.refreshProvider)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"time"
 
+	cstate "0chain.net/chaincore/chain/state"
 	"0chain.net/core/common"
 	"0chain.net/core/datastore"
 	"0chain.net/core/util/entitywrapper"
@@ -22,6 +23,7 @@ func init() {
 		map[string]entitywrapper.EntityI{
 			entitywrapper.DefaultOriginVersion: &storageNodeV1{},
 			"v2":                               &storageNodeV2{},
+			"v3":                               &storageNodeV3{},
 		})
 }
 
@@ -121,9 +123,54 @@ func (sn *StorageNode) validate(conf *Config) error {
 		return errors.New("insufficient blobber capacity")
 	}
 
+	if v3, ok := sn.Entity().(*storageNodeV3); ok {
+		if err := validateBlobberLabel("region", v3.Region); err != nil {
+			return err
+		}
+		if err := validateBlobberLabel("operator", v3.Operator); err != nil {
+			return err
+		}
+	}
+
 	return validateBaseUrl(&csn.BaseURL)
 }
 
+// labels returns the region and operator declared by the blobber, only
+// v3 blobbers can declare them.
+func (sn *StorageNode) labels() blobberLabels {
+	if v3, ok := sn.Entity().(*storageNodeV3); ok {
+		return blobberLabels{Region: v3.Region, Operator: v3.Operator}
+	}
+	return blobberLabels{}
+}
+
+// restricted returns true if the blobber only joins allocations with an
+// auth ticket of their owner.
+func (sn *StorageNode) restricted() bool {
+	switch v := sn.Entity().(type) {
+	case *storageNodeV2:
+		return v.IsRestricted != nil && *v.IsRestricted
+	case *storageNodeV3:
+		return v.IsRestricted != nil && *v.IsRestricted
+	default:
+		return false
+	}
+}
+
+// newStorageNodeEntity returns the entity the blobbers are added or
+// updated as, v3 carrying the labels after hermes and v2 before.
+func newStorageNodeEntity(balances cstate.StateContextI) entitywrapper.EntityI {
+	var e entitywrapper.EntityI = &storageNodeV2{}
+	//nolint:errcheck
+	cstate.WithActivation(balances, "hermes", func() error {
+		return nil
+	}, func() error {
+		e = &storageNodeV3{}
+		return nil
+	})
+	return e
+}
+
 func (sn *StorageNode) GetKey() datastore.Key {
 	return provider.GetKey(sn.mustBase().ID)
 }
@@ -188,6 +235,8 @@ func (sb *storageNodeBase) CommitChangesTo(e entitywrapper.EntityI) {
 		*v = storageNodeV1(*sb)
 	case *storageNodeV2:
 		v.ApplyBaseChanges(storageNodeBase(*sb))
+	case *storageNodeV3:
+		v.ApplyBaseChanges(storageNodeBase(*sb))
 	}
 }
 
@@ -261,3 +310,81 @@ func (sn2 *storageNodeV2) ApplyBaseChanges(snc storageNodeBase) {
 	sn2.RewardRound = snc.RewardRound
 	sn2.NotAvailable = snc.NotAvailable
 }
+
+// storageNodeV3 adds the region and operator labels used by the placement
+// constraints of the allocations.
+type storageNodeV3 struct {
+	provider.Provider
+	Version                 string  `json:"version" msg:"version"`
+	BaseURL                 string  `json:"url"`
+	Terms                   Terms   `json:"terms"`     // terms
+	Capacity                int64   `json:"capacity"`  // total blobber capacity
+	Allocated               int64   `json:"allocated"` // allocated capacity
+	PublicKey               string  `json:"-"`
+	SavedData               int64   `json:"saved_data"`
+	DataReadLastRewardRound float64 `json:"data_read_last_reward_round"` // in GB
+	LastRewardDataReadRound int64   `json:"last_reward_data_read_round"` // last round when data read was updated
+	// StakePoolSettings used initially to create and setup stake pool.
+	StakePoolSettings stakepool.Settings `json:"stake_pool_settings"`
+	RewardRound       RewardRound        `json:"reward_round"`
+	NotAvailable      bool               `json:"not_available"`
+	IsRestricted      *bool              `json:"is_restricted,omitempty"`
+	Region            string             `json:"region,omitempty"`
+	Operator          string             `json:"operator,omitempty"`
+}
+
+const storageNodeV3Version = "v3"
+
+func (sn3 *storageNodeV3) GetVersion() string {
+	return storageNodeV3Version
+}
+
+func (sn3 *storageNodeV3) InitVersion() {
+	sn3.Version = storageNodeV3Version
+}
+
+func (sn3 *storageNodeV3) GetBase() entitywrapper.EntityBaseI {
+	return &storageNodeBase{
+		Provider:                sn3.Provider,
+		BaseURL:                 sn3.BaseURL,
+		Terms:                   sn3.Terms,
+		Capacity:                sn3.Capacity,
+		Allocated:               sn3.Allocated,
+		PublicKey:               sn3.PublicKey,
+		SavedData:               sn3.SavedData,
+		DataReadLastRewardRound: sn3.DataReadLastRewardRound,
+		LastRewardDataReadRound: sn3.LastRewardDataReadRound,
+		StakePoolSettings:       sn3.StakePoolSettings,
+		RewardRound:             sn3.RewardRound,
+		NotAvailable:            sn3.NotAvailable,
+	}
+}
+
+func (sn3 *storageNodeV3) MigrateFrom(e entitywrapper.EntityI) error {
+	switch v := e.(type) {
+	case *storageNodeV1:
+		sn3.ApplyBaseChanges(storageNodeBase(*v))
+	case *storageNodeV2:
+		sn3.ApplyBaseChanges(*v.GetBase().(*storageNodeBase))
+		sn3.IsRestricted = v.IsRestricted
+	default:
+		return errors.New("struct migrate fail, wrong storageNode type")
+	}
+	sn3.Version = storageNodeV3Version
+	return nil
+}
+
+func (sn3 *storageNodeV3) ApplyBaseChanges(snc storageNodeBase) {
+	sn3.Provider = snc.Provider
+	sn3.BaseURL = snc.BaseURL
+	sn3.Terms = snc.Terms
+	sn3.Capacity = snc.Capacity
+	sn3.Allocated = snc.Allocated
+	sn3.PublicKey = snc.PublicKey
+	sn3.SavedData = snc.SavedData
+	sn3.DataReadLastRewardRound = snc.DataReadLastRewardRound
+	sn3.LastRewardDataReadRound = snc.LastRewardDataReadRound
+	sn3.StakePoolSettings = snc.StakePoolSettings
+	sn3.RewardRound = snc.RewardRound
+	sn3.NotAvailable = snc.NotAvailable
+}
@@ -0,0 +1,122 @@
+package storagesc
+
+import (
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/provider"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"0chain.net/smartcontract/supply"
+)
+
+func init() {
+	supply.RegisterContract(supply.Contract{
+		Name:      "storage",
+		Address:   ADDRESS,
+		Providers: []spenum.Provider{spenum.Blobber, spenum.Validator},
+		Records:   supplyRecords,
+	})
+	supply.RegisterNode(supply.NodeType{
+		Name:    "blobber",
+		NewNode: func() supply.Node { return &StorageNode{} },
+		Key:     provider.GetKey,
+	})
+	supply.RegisterNode(supply.NodeType{
+		Name:    "validator",
+		NewNode: func() supply.Node { return &ValidationNode{} },
+		Key:     provider.GetKey,
+	})
+	supply.RegisterNode(supply.NodeType{
+		Name:    "allocation",
+		NewNode: func() supply.Node { return &StorageAllocation{} },
+		Key:     func(id string) string { return ADDRESS + id },
+	})
+	supply.RegisterNode(supply.NodeType{
+		Name:    "free_storage_assigner",
+		NewNode: func() supply.Node { return &freeStorageAssigner{} },
+		Key:     func(id string) string { return freeStorageAssignerKey(ADDRESS, id) },
+	})
+	supply.RegisterNode(supply.NodeType{
+		Name:    "blobber_stake_pool",
+		NewNode: func() supply.Node { return newStakePool() },
+		Key:     func(id string) string { return stakePoolKey(spenum.Blobber, id) },
+		IDsOf:   "blobber",
+	})
+	supply.RegisterNode(supply.NodeType{
+		Name:    "validator_stake_pool",
+		NewNode: func() supply.Node { return newStakePool() },
+		Key:     func(id string) string { return stakePoolKey(spenum.Validator, id) },
+		IDsOf:   "validator",
+	})
+	supply.RegisterNode(supply.NodeType{
+		Name:    "read_pool",
+		NewNode: func() supply.Node { return &readPool{} },
+		Key:     func(id string) string { return readPoolKey(ADDRESS, id) },
+		IDsOf:   supply.Accounts,
+	})
+	supply.RegisterNode(supply.NodeType{
+		Name:    "challenge_pool",
+		NewNode: func() supply.Node { return newChallengePool() },
+		Key:     func(id string) string { return challengePoolKey(ADDRESS, id) },
+		IDsOf:   "allocation",
+	})
+}
+
+// supplyRecords returns the block rewards paid to blobbers. The free
+// storage given away is recorded in the assigners.
+func supplyRecords(edb *event.EventDb, round int64) ([]supply.Entry, error) {
+	rewards, err := edb.GetRewardsTotal(round, spenum.BlockRewardBlobber)
+	if err != nil {
+		return nil, err
+	}
+	return []supply.Entry{{Category: supply.BlockRewards, Amount: rewards}}, nil
+}
+
+// SupplyEntries of a blobber are empty, its tokens are in its stake pool.
+func (sn *StorageNode) SupplyEntries() []supply.Entry {
+	return nil
+}
+
+// SupplyID returns the id of the blobber, the decoded node may be another
+// provider under the same key prefix, or no storage node at all.
+func (sn *StorageNode) SupplyID() string {
+	b, ok := sn.Base().(*storageNodeBase)
+	if !ok || b.ProviderType != spenum.Blobber {
+		return ""
+	}
+	return b.ID
+}
+
+// SupplyEntries of a validator are empty, its tokens are in its stake pool.
+func (sn *ValidationNode) SupplyEntries() []supply.Entry {
+	return nil
+}
+
+func (sn *ValidationNode) SupplyID() string {
+	if sn.ProviderType != spenum.Validator {
+		return ""
+	}
+	return sn.ID
+}
+
+func (sa *StorageAllocation) SupplyID() string {
+	return sa.ID
+}
+
+func (sa *StorageAllocation) SupplyEntries() []supply.Entry {
+	return []supply.Entry{{Contract: ADDRESS, Category: supply.WritePools, Amount: sa.WritePool}}
+}
+
+func (rp *readPool) SupplyEntries() []supply.Entry {
+	return []supply.Entry{{Contract: ADDRESS, Category: supply.ReadPools, Amount: rp.Balance}}
+}
+
+func (cp *challengePool) SupplyEntries() []supply.Entry {
+	return []supply.Entry{{Contract: ADDRESS, Category: supply.ChallengePools, Amount: cp.Balance}}
+}
+
+func (fsa *freeStorageAssigner) SupplyID() string {
+	return fsa.ClientId
+}
+
+func (fsa *freeStorageAssigner) SupplyEntries() []supply.Entry {
+	return []supply.Entry{{Contract: ADDRESS, Category: supply.FreeStorage, Amount: fsa.CurrentRedeemed}}
+}
@@ -0,0 +1,233 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"errors"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/encryption"
+	"0chain.net/smartcontract/dbs/event"
+)
+
+//go:generate msgp -io=false -tests=false -v
+
+// OwnershipTransfer is a change of the allocation owner proposed by the
+// current owner. It takes effect only when the proposed owner accepts it
+// before it expires, so a mistyped owner id can't take the allocation.
+//
+// The write pool belongs to the allocation and is handed over with it:
+// once accepted, the refunds of finalizing or cancelling the allocation
+// go to the new owner. Read pools belong to clients rather than to
+// allocations and stay with the previous owner.
+type OwnershipTransfer struct {
+	NewOwnerID string           `json:"new_owner_id"`
+	ProposedAt common.Timestamp `json:"proposed_at"`
+	ExpiresAt  common.Timestamp `json:"expires_at"`
+}
+
+func (ot *OwnershipTransfer) isExpired(now common.Timestamp) bool {
+	return ot.ExpiresAt <= now
+}
+
+type proposeOwnershipTransferRequest struct {
+	AllocationID string           `json:"allocation_id"`
+	NewOwnerID   string           `json:"new_owner_id"`
+	ExpiresAt    common.Timestamp `json:"expires_at"`
+}
+
+func (req *proposeOwnershipTransferRequest) decode(b []byte) error {
+	return json.Unmarshal(b, req)
+}
+
+func (req *proposeOwnershipTransferRequest) validate(alloc *StorageAllocation, now common.Timestamp) error {
+	switch {
+	case !encryption.IsHash(req.NewOwnerID):
+		return errors.New("invalid new owner id")
+	case req.NewOwnerID == alloc.Owner:
+		return errors.New("new owner is the current owner")
+	case req.ExpiresAt <= now:
+		return errors.New("transfer expires in the past")
+	case req.ExpiresAt > alloc.Expiration:
+		return errors.New("transfer expires after the allocation")
+	}
+	return nil
+}
+
+// ownershipTransferRequest is the input of accepting and cancelling an
+// ownership transfer.
+type ownershipTransferRequest struct {
+	AllocationID string `json:"allocation_id"`
+}
+
+func (req *ownershipTransferRequest) decode(b []byte) error {
+	return json.Unmarshal(b, req)
+}
+
+// getTransferableAllocation returns the allocation whose ownership can
+// still be changed.
+func (sc *StorageSmartContract) getTransferableAllocation(
+	allocationID string,
+	now common.Timestamp,
+	balances cstate.StateContextI,
+) (*StorageAllocation, error) {
+	alloc, err := sc.getAllocation(allocationID, balances)
+	if err != nil {
+		return nil, errors.New("can't get allocation: " + err.Error())
+	}
+
+	if alloc.Finalized || alloc.Canceled {
+		return nil, errors.New("allocation is finalized")
+	}
+
+	if alloc.Expiration < now {
+		return nil, errors.New("allocation is expired")
+	}
+
+	return alloc, nil
+}
+
+// proposeOwnershipTransfer proposes a new owner of an allocation. Only the
+// owner can propose one, and a pending transfer has to be cancelled or
+// expire before another one is proposed.
+func (sc *StorageSmartContract) proposeOwnershipTransfer(
+	t *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	const errCode = "propose_allocation_owner_failed"
+
+	var req proposeOwnershipTransferRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError(errCode, "invalid request: "+err.Error())
+	}
+
+	alloc, err := sc.getTransferableAllocation(req.AllocationID, t.CreationDate, balances)
+	if err != nil {
+		return "", common.NewError(errCode, err.Error())
+	}
+
+	if t.ClientID != alloc.Owner {
+		return "", common.NewError(errCode, "only owner can transfer the allocation")
+	}
+
+	if alloc.PendingTransfer != nil && !alloc.PendingTransfer.isExpired(t.CreationDate) {
+		return "", common.NewError(errCode,
+			"an ownership transfer is pending already, cancel it first")
+	}
+
+	if err := req.validate(alloc, t.CreationDate); err != nil {
+		return "", common.NewError(errCode, err.Error())
+	}
+
+	alloc.PendingTransfer = &OwnershipTransfer{
+		NewOwnerID: req.NewOwnerID,
+		ProposedAt: t.CreationDate,
+		ExpiresAt:  req.ExpiresAt,
+	}
+
+	if err := alloc.save(balances, sc.ID); err != nil {
+		return "", common.NewError(errCode, "saving allocation: "+err.Error())
+	}
+
+	emitOwnershipTransfer(alloc, alloc.Owner, alloc.PendingTransfer, event.OwnershipTransferProposed, balances)
+
+	return string(alloc.Encode()), nil
+}
+
+// acceptOwnershipTransfer makes the sender of the transaction the owner of
+// the allocation transferred to it. The public key the transaction is
+// signed with becomes the owner public key. Tokens sent with the
+// transaction are locked in the write pool of the allocation.
+func (sc *StorageSmartContract) acceptOwnershipTransfer(
+	t *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	const errCode = "accept_allocation_owner_failed"
+
+	var req ownershipTransferRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError(errCode, "invalid request: "+err.Error())
+	}
+
+	alloc, err := sc.getTransferableAllocation(req.AllocationID, t.CreationDate, balances)
+	if err != nil {
+		return "", common.NewError(errCode, err.Error())
+	}
+
+	pt := alloc.PendingTransfer
+	if pt == nil || pt.NewOwnerID != t.ClientID {
+		return "", common.NewError(errCode, "no ownership transfer pending for the client")
+	}
+
+	if pt.isExpired(t.CreationDate) {
+		return "", common.NewError(errCode, "ownership transfer expired")
+	}
+
+	if t.PublicKey == "" {
+		return "", common.NewError(errCode, "missing public key of the new owner")
+	}
+
+	if t.Value > 0 {
+		if err := alloc.addToWritePool(t, balances,
+			NewTokenTransfer(t.Value, t.ClientID, t.ToClientID, false)); err != nil {
+			return "", common.NewError(errCode, err.Error())
+		}
+	}
+
+	previousOwner := alloc.Owner
+	alloc.Owner = t.ClientID
+	alloc.OwnerPublicKey = t.PublicKey
+	alloc.PendingTransfer = nil
+	alloc.Tx = t.Hash
+
+	if err := alloc.save(balances, sc.ID); err != nil {
+		return "", common.NewError(errCode, "saving allocation: "+err.Error())
+	}
+
+	balances.EmitEvent(event.TypeStats, event.TagUpdateAllocation, alloc.ID, alloc.buildDbUpdates())
+	emitOwnershipTransfer(alloc, previousOwner, pt, event.OwnershipTransferAccepted, balances)
+
+	return string(alloc.Encode()), nil
+}
+
+// cancelOwnershipTransfer drops the pending ownership transfer of an
+// allocation. Both the owner and the proposed owner can cancel it.
+func (sc *StorageSmartContract) cancelOwnershipTransfer(
+	t *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	const errCode = "cancel_allocation_owner_failed"
+
+	var req ownershipTransferRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError(errCode, "invalid request: "+err.Error())
+	}
+
+	alloc, err := sc.getTransferableAllocation(req.AllocationID, t.CreationDate, balances)
+	if err != nil {
+		return "", common.NewError(errCode, err.Error())
+	}
+
+	pt := alloc.PendingTransfer
+	if pt == nil {
+		return "", common.NewError(errCode, "no ownership transfer pending")
+	}
+
+	if t.ClientID != alloc.Owner && t.ClientID != pt.NewOwnerID {
+		return "", common.NewError(errCode,
+			"only the owner or the proposed owner can cancel the transfer")
+	}
+
+	alloc.PendingTransfer = nil
+	if err := alloc.save(balances, sc.ID); err != nil {
+		return "", common.NewError(errCode, "saving allocation: "+err.Error())
+	}
+
+	emitOwnershipTransfer(alloc, alloc.Owner, pt, event.OwnershipTransferCancelled, balances)
+
+	return string(alloc.Encode()), nil
+}
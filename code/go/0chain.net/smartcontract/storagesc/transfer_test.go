@@ -0,0 +1,154 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"0chain.net/core/common"
+	"0chain.net/core/encryption"
+	"0chain.net/smartcontract/dbs/event"
+	"github.com/stretchr/testify/require"
+)
+
+func ownerTransferEvents(balances *testBalances) []event.AllocationOwnershipTransfer {
+	var transfers []event.AllocationOwnershipTransfer
+	for _, e := range balances.GetEvents() {
+		if e.Tag == event.TagAllocationOwnershipTransfer {
+			transfers = append(transfers, e.Data.(event.AllocationOwnershipTransfer))
+		}
+	}
+	return transfers
+}
+
+func TestOwnershipTransfer(t *testing.T) {
+	const now = 1000
+
+	var (
+		balances = newTestBalances(t, false)
+		ssc      = newTestStorageSC()
+		owner    = encryption.Hash("owner")
+		newOwner = encryption.Hash("new owner")
+		stranger = encryption.Hash("stranger")
+	)
+
+	alloc := &StorageAllocation{
+		ID:             encryption.Hash("alloc"),
+		Owner:          owner,
+		OwnerPublicKey: "owner public key",
+		Expiration:     now + 1000,
+		WritePool:      100,
+		Stats:          &StorageAllocationStats{},
+	}
+	require.NoError(t, alloc.save(balances, ADDRESS))
+
+	proposeAt := func(at int64, clientID, newOwnerID string, expiresAt common.Timestamp) error {
+		input, err := json.Marshal(&proposeOwnershipTransferRequest{
+			AllocationID: alloc.ID,
+			NewOwnerID:   newOwnerID,
+			ExpiresAt:    expiresAt,
+		})
+		require.NoError(t, err)
+		_, err = ssc.proposeOwnershipTransfer(newTransaction(clientID, ADDRESS, 0, at), input, balances)
+		return err
+	}
+
+	propose := func(clientID, newOwnerID string, expiresAt common.Timestamp) error {
+		return proposeAt(now, clientID, newOwnerID, expiresAt)
+	}
+
+	call := func(fn func([]byte) error) error {
+		input, err := json.Marshal(&ownershipTransferRequest{AllocationID: alloc.ID})
+		require.NoError(t, err)
+		return fn(input)
+	}
+
+	accept := func(clientID string, at int64) error {
+		return call(func(input []byte) error {
+			txn := newTransaction(clientID, ADDRESS, 0, at)
+			txn.PublicKey = clientID + " public key"
+			_, err := ssc.acceptOwnershipTransfer(txn, input, balances)
+			return err
+		})
+	}
+
+	cancel := func(clientID string) error {
+		return call(func(input []byte) error {
+			_, err := ssc.cancelOwnershipTransfer(newTransaction(clientID, ADDRESS, 0, now), input, balances)
+			return err
+		})
+	}
+
+	require.Error(t, propose(stranger, newOwner, now+100), "only owner proposes")
+	require.Error(t, propose(owner, "mistyped", now+100), "invalid new owner id")
+	require.Error(t, propose(owner, owner, now+100), "owner is the new owner")
+	require.Error(t, propose(owner, newOwner, now), "expires in the past")
+	require.Error(t, propose(owner, newOwner, now+2000), "expires after the allocation")
+	require.Error(t, cancel(owner), "nothing pending")
+
+	require.NoError(t, propose(owner, newOwner, now+100))
+	require.Error(t, propose(owner, stranger, now+100), "transfer pending already")
+
+	require.Error(t, cancel(stranger))
+	require.NoError(t, cancel(newOwner), "proposed owner can cancel")
+
+	require.NoError(t, propose(owner, newOwner, now+100))
+	require.Error(t, accept(stranger, now+10), "not the proposed owner")
+	require.Error(t, accept(newOwner, now+100), "transfer expired")
+
+	// an expired transfer can be replaced
+	require.NoError(t, proposeAt(now+100, owner, newOwner, now+200))
+	require.NoError(t, accept(newOwner, now+110))
+
+	alloc, err := ssc.getAllocation(alloc.ID, balances)
+	require.NoError(t, err)
+	require.Equal(t, newOwner, alloc.Owner)
+	require.Equal(t, newOwner+" public key", alloc.OwnerPublicKey)
+	require.Nil(t, alloc.PendingTransfer)
+	require.Error(t, cancel(owner), "nothing pending after acceptance")
+
+	var statuses []string
+	for _, e := range ownerTransferEvents(balances) {
+		statuses = append(statuses, e.Status)
+	}
+	require.Equal(t, []string{
+		event.OwnershipTransferProposed,
+		event.OwnershipTransferCancelled,
+		event.OwnershipTransferProposed,
+		event.OwnershipTransferProposed,
+		event.OwnershipTransferAccepted,
+	}, statuses)
+
+	accepted := ownerTransferEvents(balances)[4]
+	require.Equal(t, owner, accepted.FromOwnerID)
+	require.Equal(t, newOwner, accepted.ToOwnerID)
+	require.EqualValues(t, 100, accepted.WritePool)
+
+	// nothing changes on a finalized allocation
+	require.NoError(t, propose(newOwner, owner, now+100))
+	alloc.Finalized = true
+	require.NoError(t, alloc.save(balances, ADDRESS))
+	require.Error(t, cancel(newOwner), "allocation is finalized")
+	require.Error(t, accept(owner, now+10), "allocation is finalized")
+}
+
+func TestUpdateAllocationOwnerNeedsTransfer(t *testing.T) {
+	var (
+		balances = newTestBalances(t, false)
+		ssc      = newTestStorageSC()
+		client   = newClient(2000*x10, balances)
+		now      = int64(0)
+	)
+
+	allocID, _ := addAllocation(t, ssc, client, now, 0, 0, 0, 0, 0, balances, false)
+
+	input, err := json.Marshal(&updateAllocationRequest{
+		ID:             allocID,
+		OwnerID:        encryption.Hash("new owner"),
+		OwnerPublicKey: "new owner public key",
+	})
+	require.NoError(t, err)
+
+	_, err = ssc.updateAllocationRequest(newTransaction(client.id, ADDRESS, 0, now), input, balances)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "propose the new owner")
+}
@@ -0,0 +1,339 @@
+package supply
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"0chain.net/chaincore/state"
+	"0chain.net/core/encryption"
+	"0chain.net/smartcontract/dbs/event"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/util"
+)
+
+// clientStateSize is the size of an encoded client state, the transaction
+// hash followed by the round, balance and nonce.
+const clientStateSize = 32 + 3*8
+
+const (
+	CheckTotalSupply = "total_supply"
+	CheckSolvency    = "solvency"
+	CheckReserve     = "reserve"
+)
+
+// Options of a supply audit.
+type Options struct {
+	Round     int64
+	MaxSupply currency.Coin
+	// Genesis initial states, they give the genesis balances of the smart
+	// contracts and the initial stakes not backed by their balances.
+	Genesis *state.InitStates
+	// EventDb with the mint and burn records, the reserves are not
+	// reconciled without it.
+	EventDb *event.EventDb
+}
+
+// Report of a supply audit. The breakdown adds up to the total of all the
+// balances in the state.
+type Report struct {
+	Round         int64              `json:"round"`
+	StateHash     string             `json:"state_hash"`
+	MaxSupply     currency.Coin      `json:"max_supply"`
+	Total         currency.Coin      `json:"total"`
+	Breakdown     map[Category]int64 `json:"breakdown"`
+	Accounts      int64              `json:"accounts"`
+	Nodes         map[string]int64   `json:"nodes"`
+	OtherNodes    int64              `json:"other_nodes"`
+	Contracts     []*ContractSupply  `json:"contracts"`
+	Reconciled    bool               `json:"reconciled"`
+	RecordsError  string             `json:"records_error,omitempty"`
+	Discrepancies []Discrepancy      `json:"discrepancies"`
+}
+
+// ContractSupply is the supply held by a smart contract. The reserve is
+// the balance plus the unbacked initial stakes minus everything held for
+// others, it is negative when the smart contract is insolvent.
+type ContractSupply struct {
+	Name            string                     `json:"name"`
+	Address         string                     `json:"address"`
+	Balance         currency.Coin              `json:"balance"`
+	Unbacked        currency.Coin              `json:"unbacked_stakes"`
+	Held            map[Category]currency.Coin `json:"held"`
+	Reserve         int64                      `json:"reserve"`
+	Genesis         currency.Coin              `json:"genesis"`
+	Records         map[Category]currency.Coin `json:"records,omitempty"`
+	ExpectedReserve *int64                     `json:"expected_reserve,omitempty"`
+
+	reconcile bool
+}
+
+// Discrepancy is a failed check of the audit.
+type Discrepancy struct {
+	Check    string `json:"check"`
+	Contract string `json:"contract,omitempty"`
+	Expected int64  `json:"expected"`
+	Actual   int64  `json:"actual"`
+}
+
+type auditor struct {
+	report    *Report
+	contracts map[string]*ContractSupply
+	clients   currency.Coin
+
+	identified []NodeType
+	derived    []NodeType
+	// ids of the nodes found walking the state, by node type, and of the
+	// client states under Accounts.
+	ids map[string][]string
+}
+
+// Audit walks the given state and reports its supply breakdown and the
+// checks that failed. The state must be complete, the walk fails on pruned
+// or missing nodes.
+func Audit(ctx context.Context, mpt util.MerklePatriciaTrieI, opts Options) (*Report, error) {
+	a := &auditor{
+		report: &Report{
+			Round:         opts.Round,
+			StateHash:     util.ToHex(mpt.GetRoot()),
+			MaxSupply:     opts.MaxSupply,
+			Breakdown:     make(map[Category]int64),
+			Nodes:         make(map[string]int64),
+			Discrepancies: []Discrepancy{},
+		},
+		contracts: make(map[string]*ContractSupply),
+		ids:       make(map[string][]string),
+	}
+	a.identified, a.derived = sortedNodeTypes()
+	for addr, c := range contracts {
+		cs := a.contract(addr)
+		cs.Name = c.Name
+		cs.reconcile = c.Records != nil
+	}
+	a.addGenesis(opts.Genesis)
+
+	if err := mpt.Iterate(ctx, a.visit, util.NodeTypeValueNode); err != nil {
+		return nil, fmt.Errorf("walk state: %v", err)
+	}
+	if err := a.lookupDerived(mpt); err != nil {
+		return nil, err
+	}
+
+	if opts.EventDb != nil {
+		if err := a.addRecords(opts.EventDb, opts.Round); err != nil {
+			a.report.RecordsError = err.Error()
+		} else {
+			a.report.Reconciled = true
+		}
+	}
+
+	a.check()
+	return a.report, nil
+}
+
+func (a *auditor) contract(addr string) *ContractSupply {
+	cs, ok := a.contracts[addr]
+	if !ok {
+		cs = &ContractSupply{
+			Name:    addr,
+			Address: addr,
+			Held:    make(map[Category]currency.Coin),
+			Records: make(map[Category]currency.Coin),
+		}
+		a.contracts[addr] = cs
+	}
+	return cs
+}
+
+func (a *auditor) addGenesis(gs *state.InitStates) {
+	if gs == nil {
+		return
+	}
+	for _, s := range gs.States {
+		if _, ok := contracts[s.ID]; !ok {
+			continue
+		}
+		balance := s.Tokens
+		for _, cs := range s.State {
+			balance -= cs.Tokens
+		}
+		a.contract(s.ID).Genesis += balance
+	}
+	for _, s := range gs.Stakes {
+		for addr, c := range contracts {
+			for _, p := range c.Providers {
+				if p.String() == s.ProviderType {
+					a.contract(addr).Unbacked += s.Tokens
+				}
+			}
+		}
+	}
+}
+
+func (a *auditor) addRecords(edb *event.EventDb, round int64) error {
+	if _, err := edb.GetBlockByRound(round); err != nil {
+		return fmt.Errorf("event database has not processed round %d: %v", round, err)
+	}
+	for addr, c := range contracts {
+		if c.Records == nil {
+			continue
+		}
+		entries, err := c.Records(edb, round)
+		if err != nil {
+			return fmt.Errorf("%s records: %v", c.Name, err)
+		}
+		for _, e := range entries {
+			a.add(Entry{Contract: addr, Category: e.Category, Amount: e.Amount})
+		}
+	}
+	return nil
+}
+
+func (a *auditor) visit(_ context.Context, path util.Path, _ util.Key, node util.Node) error {
+	vn, ok := node.(*util.ValueNode)
+	if !ok {
+		return nil
+	}
+	b := vn.GetValueBytes()
+
+	for _, nt := range a.identified {
+		n := nt.NewNode()
+		id, ok := decodeIdentified(n, b)
+		if !ok || encryption.Hash(nt.Key(id)) != string(path) {
+			continue
+		}
+		a.ids[nt.Name] = append(a.ids[nt.Name], id)
+		a.addNode(nt, n)
+		return nil
+	}
+
+	if len(b) == clientStateSize {
+		var s state.State
+		if err := s.Decode(b); err != nil {
+			return fmt.Errorf("decode client state at %s: %v", path, err)
+		}
+		a.report.Accounts++
+		a.ids[Accounts] = append(a.ids[Accounts], string(path))
+		a.report.Total += s.Balance
+		if _, ok := contracts[string(path)]; ok {
+			a.contract(string(path)).Balance += s.Balance
+		} else {
+			a.clients += s.Balance
+		}
+		return nil
+	}
+
+	a.report.OtherNodes++
+	return nil
+}
+
+// decodeIdentified decodes the node and returns its id, nodes of other
+// types may fail to decode, or even panic on their content.
+func decodeIdentified(n Node, b []byte) (id string, ok bool) {
+	defer func() {
+		if recover() != nil {
+			id, ok = "", false
+		}
+	}()
+	if _, err := n.UnmarshalMsg(b); err != nil {
+		return "", false
+	}
+	id = n.(IdentifiedNode).SupplyID()
+	return id, id != ""
+}
+
+// lookupDerived looks up the nodes of the derived types by the ids of the
+// nodes found walking the state, they were counted as other nodes then.
+func (a *auditor) lookupDerived(mpt util.MerklePatriciaTrieI) error {
+	for _, nt := range a.derived {
+		for _, id := range a.ids[nt.IDsOf] {
+			n := nt.NewNode()
+			err := mpt.GetNodeValue(util.Path(encryption.Hash(nt.Key(id))), n)
+			switch err {
+			case nil:
+			case util.ErrValueNotPresent:
+				continue
+			default:
+				return fmt.Errorf("get %s of %s: %v", nt.Name, id, err)
+			}
+			a.report.OtherNodes--
+			a.addNode(nt, n)
+		}
+	}
+	return nil
+}
+
+func (a *auditor) addNode(nt NodeType, n Node) {
+	a.report.Nodes[nt.Name]++
+	for _, e := range n.SupplyEntries() {
+		a.add(e)
+	}
+}
+
+func (a *auditor) add(e Entry) {
+	cs := a.contract(e.Contract)
+	if e.Category.isRecord() {
+		cs.Records[e.Category] += e.Amount
+		return
+	}
+	cs.Held[e.Category] += e.Amount
+}
+
+func (a *auditor) check() {
+	r := a.report
+	r.Breakdown[Clients] = int64(a.clients)
+
+	for _, cs := range a.contracts {
+		cs.Reserve = int64(cs.Balance) + int64(cs.Unbacked)
+		for c, amount := range cs.Held {
+			cs.Reserve -= int64(amount)
+			r.Breakdown[c] += int64(amount)
+		}
+		r.Breakdown[Reserves] += cs.Reserve
+		r.Breakdown[Unbacked] -= int64(cs.Unbacked)
+		r.Contracts = append(r.Contracts, cs)
+	}
+	sort.Slice(r.Contracts, func(i, j int) bool {
+		return r.Contracts[i].Name < r.Contracts[j].Name
+	})
+
+	if r.MaxSupply > 0 && r.Total != r.MaxSupply {
+		r.Discrepancies = append(r.Discrepancies, Discrepancy{
+			Check:    CheckTotalSupply,
+			Expected: int64(r.MaxSupply),
+			Actual:   int64(r.Total),
+		})
+	}
+
+	for _, cs := range r.Contracts {
+		if cs.Reserve < 0 {
+			r.Discrepancies = append(r.Discrepancies, Discrepancy{
+				Check:    CheckSolvency,
+				Contract: cs.Name,
+				Expected: int64(cs.Balance) - cs.Reserve,
+				Actual:   int64(cs.Balance),
+			})
+		}
+
+		if !r.Reconciled || !cs.reconcile {
+			continue
+		}
+		expected := int64(cs.Genesis)
+		for c, amount := range cs.Records {
+			if issued[c] {
+				expected -= int64(amount)
+			} else if returned[c] {
+				expected += int64(amount)
+			}
+		}
+		cs.ExpectedReserve = &expected
+		if expected != cs.Reserve {
+			r.Discrepancies = append(r.Discrepancies, Discrepancy{
+				Check:    CheckReserve,
+				Contract: cs.Name,
+				Expected: expected,
+				Actual:   cs.Reserve,
+			})
+		}
+	}
+}
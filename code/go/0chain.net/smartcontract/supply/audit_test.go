@@ -0,0 +1,205 @@
+package supply
+
+import (
+	"context"
+	"testing"
+
+	"0chain.net/chaincore/state"
+	"0chain.net/core/common"
+	"0chain.net/core/config"
+	"0chain.net/core/encryption"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/logging"
+	"github.com/0chain/common/core/statecache"
+	"github.com/0chain/common/core/util"
+	"github.com/stretchr/testify/require"
+	"github.com/tinylib/msgp/msgp"
+	"go.uber.org/zap"
+)
+
+var (
+	testContract = encryption.Hash("test contract")
+	testClient   = encryption.Hash("test client")
+)
+
+// testPool is a pool of the test contract for each client, encoded as a
+// msgp map.
+type testPool struct {
+	Stake  currency.Coin
+	Reward currency.Coin
+}
+
+func (p *testPool) MarshalMsg(b []byte) ([]byte, error) {
+	b = msgp.AppendMapHeader(b, 2)
+	b = msgp.AppendString(b, "Stake")
+	b = msgp.AppendUint64(b, uint64(p.Stake))
+	b = msgp.AppendString(b, "Reward")
+	b = msgp.AppendUint64(b, uint64(p.Reward))
+	return b, nil
+}
+
+func (p *testPool) UnmarshalMsg(b []byte) ([]byte, error) {
+	n, b, err := msgp.ReadMapHeaderBytes(b)
+	if err != nil {
+		return nil, err
+	}
+	for i := uint32(0); i < n; i++ {
+		var field string
+		var v uint64
+		if field, b, err = msgp.ReadStringBytes(b); err != nil {
+			return nil, err
+		}
+		if v, b, err = msgp.ReadUint64Bytes(b); err != nil {
+			return nil, err
+		}
+		switch field {
+		case "Stake":
+			p.Stake = currency.Coin(v)
+		case "Reward":
+			p.Reward = currency.Coin(v)
+		}
+	}
+	return b, nil
+}
+
+func (p *testPool) SupplyEntries() []Entry {
+	return []Entry{
+		{Contract: testContract, Category: Stake, Amount: p.Stake},
+		{Contract: testContract, Category: DelegateRewards, Amount: p.Reward},
+	}
+}
+
+func init() {
+	logging.Logger = zap.NewNop()
+	common.SetupRootContext(context.Background())
+
+	RegisterContract(Contract{
+		Name:      "test",
+		Address:   testContract,
+		Providers: []spenum.Provider{spenum.Blobber},
+		Records: func(edb *event.EventDb, round int64) ([]Entry, error) {
+			rewards, err := edb.GetRewardsTotal(round, spenum.BlockRewardBlobber)
+			if err != nil {
+				return nil, err
+			}
+			return []Entry{{Category: BlockRewards, Amount: rewards}}, nil
+		},
+	})
+	RegisterNode(NodeType{
+		Name:    "test_pool",
+		NewNode: func() Node { return &testPool{} },
+		Key:     testPoolKey,
+		IDsOf:   Accounts,
+	})
+}
+
+func testPoolKey(clientID string) string {
+	return "test:pool:" + clientID
+}
+
+func newTestEventDb(t *testing.T) *event.EventDb {
+	edb, err := event.NewInMemoryEventDb(config.DbAccess{}, config.DbSettings{
+		Debug:                 true,
+		PartitionChangePeriod: 1,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, edb.Drop())
+		edb.Close()
+	})
+	return edb
+}
+
+func insertBalance(t *testing.T, mpt util.MerklePatriciaTrieI, id string, balance currency.Coin) {
+	s := state.State{TxnHashBytes: encryption.RawHash(id), Balance: balance}
+	_, err := mpt.Insert(util.Path(id), &s)
+	require.NoError(t, err)
+}
+
+func TestAudit(t *testing.T) {
+	// genesis: the contract keeps 1000 after giving 100 to the client and
+	// holds an unbacked initial stake of 50
+	genesis := &state.InitStates{
+		States: []state.InitState{{
+			ID:     testContract,
+			Tokens: 1100,
+			State:  []state.IDTokens{{ID: testClient, Tokens: 100}},
+		}},
+		Stakes: []state.InitStake{{ProviderType: spenum.Blobber.String(), Tokens: 50}},
+	}
+
+	edb := newTestEventDb(t)
+	require.NoError(t, edb.Get().Create(&event.Block{Hash: "h5", Round: 5}).Error)
+	require.NoError(t, edb.Get().Create(&event.RewardProvider{
+		Amount: 30, BlockNumber: 3, RewardType: spenum.BlockRewardBlobber,
+	}).Error)
+	require.NoError(t, edb.Get().Create(&event.RewardDelegate{
+		Amount: 20, BlockNumber: 4, RewardType: spenum.BlockRewardBlobber,
+	}).Error)
+	require.NoError(t, edb.Get().Create(&event.RewardDelegate{
+		Amount: 7, BlockNumber: 6, RewardType: spenum.BlockRewardBlobber,
+	}).Error)
+
+	// the client collected 50 of block rewards, 20 more are still owed
+	mpt := util.NewMerklePatriciaTrie(util.NewMemoryNodeDB(), 5, nil, statecache.NewEmpty())
+	insertBalance(t, mpt, testClient, 130)
+	insertBalance(t, mpt, testContract, 970)
+	_, err := mpt.Insert(util.Path(encryption.Hash(testPoolKey(testClient))), &testPool{Stake: 50, Reward: 20})
+	require.NoError(t, err)
+	_, err = mpt.Insert(util.Path(encryption.Hash("other")), &util.SecureSerializableValue{Buffer: []byte("other")})
+	require.NoError(t, err)
+	// a pool under another key is not counted
+	_, err = mpt.Insert(util.Path(encryption.Hash("pool")), &testPool{Stake: 1000})
+	require.NoError(t, err)
+
+	opts := Options{Round: 5, MaxSupply: 1100, Genesis: genesis, EventDb: edb}
+	report, err := Audit(context.Background(), mpt, opts)
+	require.NoError(t, err)
+	require.True(t, report.Reconciled)
+	require.Empty(t, report.Discrepancies)
+	require.Equal(t, currency.Coin(1100), report.Total)
+	require.Equal(t, int64(2), report.Accounts)
+	require.Equal(t, int64(1), report.Nodes["test_pool"])
+	require.Equal(t, int64(2), report.OtherNodes)
+	require.Equal(t, map[Category]int64{
+		Clients:         130,
+		Stake:           50,
+		DelegateRewards: 20,
+		Reserves:        950,
+		Unbacked:        -50,
+	}, report.Breakdown)
+
+	require.Len(t, report.Contracts, 1)
+	cs := report.Contracts[0]
+	require.Equal(t, int64(950), cs.Reserve)
+	require.Equal(t, int64(950), *cs.ExpectedReserve)
+
+	// a reward credited twice is owed without being recorded
+	_, err = mpt.Insert(util.Path(encryption.Hash(testPoolKey(testClient))), &testPool{Stake: 50, Reward: 40})
+	require.NoError(t, err)
+	report, err = Audit(context.Background(), mpt, opts)
+	require.NoError(t, err)
+	require.Equal(t, []Discrepancy{{Check: CheckReserve, Contract: "test", Expected: 950, Actual: 930}}, report.Discrepancies)
+
+	// tokens created out of nothing and a contract holding less than it owes
+	insertBalance(t, mpt, testClient, 140)
+	_, err = mpt.Insert(util.Path(encryption.Hash(testPoolKey(testClient))), &testPool{Stake: 50, Reward: 1000})
+	require.NoError(t, err)
+	report, err = Audit(context.Background(), mpt, opts)
+	require.NoError(t, err)
+	require.Equal(t, []Discrepancy{
+		{Check: CheckTotalSupply, Expected: 1100, Actual: 1110},
+		{Check: CheckSolvency, Contract: "test", Expected: 1000, Actual: 970},
+		{Check: CheckReserve, Contract: "test", Expected: 950, Actual: -30},
+	}, report.Discrepancies)
+
+	// the records are not reconciled when the event db is behind
+	opts.Round = 6
+	report, err = Audit(context.Background(), mpt, opts)
+	require.NoError(t, err)
+	require.False(t, report.Reconciled)
+	require.NotEmpty(t, report.RecordsError)
+	require.Nil(t, report.Contracts[0].ExpectedReserve)
+}
@@ -0,0 +1,160 @@
+// Package supply audits the token supply held in the state of a finalized
+// round.
+//
+// The supply is fixed at genesis, rewards and bridge mints are paid out of
+// the balances of the minting smart contracts and burns are paid back into
+// them. The audit walks the whole MPT, finds the nodes of every registered
+// node type by their state keys and checks that the balances add up to the
+// max token supply, that every smart contract holds the tokens locked in it
+// and that what is left in the reserve of a minter matches its genesis
+// balance minus the recorded mints plus the recorded fees and burns.
+package supply
+
+import (
+	"fmt"
+	"sort"
+
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/util"
+)
+
+// Category of tokens in the supply breakdown.
+type Category string
+
+const (
+	// Clients are the balances of all the accounts other than the
+	// registered smart contracts.
+	Clients Category = "clients"
+	// Reserves are the balances of the smart contracts that are not locked
+	// in any pool nor owed as rewards.
+	Reserves Category = "reserves"
+	// Unbacked are the initial stakes created at genesis without moving
+	// tokens to the smart contract, they are subtracted in the breakdown.
+	Unbacked Category = "unbacked_stakes"
+
+	Stake           Category = "stake"
+	DelegateRewards Category = "delegate_rewards"
+	ServiceCharges  Category = "service_charges"
+	ReadPools       Category = "read_pools"
+	WritePools      Category = "write_pools"
+	ChallengePools  Category = "challenge_pools"
+	VestingPools    Category = "vesting_pools"
+
+	// BlockRewards, FeeRewards, BridgeMints and FreeStorage are records of
+	// tokens paid out of the reserve of a smart contract.
+	BlockRewards Category = "block_rewards"
+	FeeRewards   Category = "fee_rewards"
+	BridgeMints  Category = "bridge_mints"
+	FreeStorage  Category = "free_storage"
+	// TxnFees and BridgeBurns are records of tokens paid into the reserve.
+	TxnFees     Category = "txn_fees"
+	BridgeBurns Category = "bridge_burns"
+)
+
+var (
+	issued   = map[Category]bool{BlockRewards: true, FeeRewards: true, BridgeMints: true, FreeStorage: true}
+	returned = map[Category]bool{TxnFees: true, BridgeBurns: true}
+)
+
+func (c Category) isRecord() bool {
+	return issued[c] || returned[c]
+}
+
+// Entry is an amount of tokens of a category held by, or recorded against,
+// a smart contract.
+type Entry struct {
+	Contract string
+	Category Category
+	Amount   currency.Coin
+}
+
+// Node is a state node that holds tokens.
+type Node interface {
+	util.MPTSerializable
+	SupplyEntries() []Entry
+}
+
+// IdentifiedNode is a node that gives its own id, the id is empty when the
+// node decoded is not of the type.
+type IdentifiedNode interface {
+	Node
+	SupplyID() string
+}
+
+// Accounts are the ids of the client states, for the node types keyed by
+// client.
+const Accounts = "accounts"
+
+// NodeType is a type of state node holding tokens, or giving the ids of the
+// nodes that do. State nodes carry no type information and their paths are
+// the hashes of their keys, a node is of a type when the state key of the
+// type for its id hashes to its path. The nodes of types without IDsOf are
+// IdentifiedNode and are found walking the state, the others are looked up
+// by the ids of the nodes of the IDsOf type, or of the Accounts.
+type NodeType struct {
+	Name    string
+	NewNode func() Node
+	// Key returns the state key of the node with the given id.
+	Key func(id string) string
+	// IDsOf is the node type, or Accounts, the nodes of this type share
+	// their ids with. It can't be a type with IDsOf itself.
+	IDsOf string
+}
+
+// RecordSource returns the records of the tokens paid out of and back into
+// the reserve of a smart contract up to and including the given round.
+type RecordSource func(edb *event.EventDb, round int64) ([]Entry, error)
+
+// Contract is a smart contract holding tokens.
+type Contract struct {
+	Name    string
+	Address string
+	// Providers whose initial stakes are held by the smart contract.
+	Providers []spenum.Provider
+	// Records of the minted and burned tokens, only contracts with records
+	// have their reserve reconciled.
+	Records RecordSource
+}
+
+var (
+	contracts = make(map[string]Contract)
+	nodeTypes = make(map[string]NodeType)
+)
+
+// RegisterContract registers a smart contract holding tokens.
+func RegisterContract(c Contract) {
+	contracts[c.Address] = c
+}
+
+// RegisterNode registers a node type holding tokens.
+func RegisterNode(nt NodeType) {
+	if _, ok := nodeTypes[nt.Name]; ok {
+		panic(fmt.Sprintf("supply: %s is already registered", nt.Name))
+	}
+	if nt.IDsOf == "" {
+		if _, ok := nt.NewNode().(IdentifiedNode); !ok {
+			panic(fmt.Sprintf("supply: %s nodes don't give their ids", nt.Name))
+		}
+	}
+	nodeTypes[nt.Name] = nt
+}
+
+// sortedNodeTypes returns the registered node types, by name, split into
+// the ones found walking the state and the ones looked up by ids.
+func sortedNodeTypes() (identified, derived []NodeType) {
+	names := make([]string, 0, len(nodeTypes))
+	for name := range nodeTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if nt := nodeTypes[name]; nt.IDsOf == "" {
+			identified = append(identified, nt)
+		} else {
+			derived = append(derived, nt)
+		}
+	}
+	return identified, derived
+}
@@ -0,0 +1,21 @@
+package vestingsc
+
+import "0chain.net/smartcontract/supply"
+
+func init() {
+	supply.RegisterContract(supply.Contract{Name: "vesting", Address: ADDRESS})
+	supply.RegisterNode(supply.NodeType{
+		Name:    "vesting_pool",
+		NewNode: func() supply.Node { return newVestingPool() },
+		Key:     func(id string) string { return id },
+	})
+}
+
+// SupplyID returns the id of the vesting pool, which is its state key.
+func (vp *vestingPool) SupplyID() string {
+	return vp.ID
+}
+
+func (vp *vestingPool) SupplyEntries() []supply.Entry {
+	return []supply.Entry{{Contract: ADDRESS, Category: supply.VestingPools, Amount: vp.Balance}}
+}
@@ -180,6 +180,16 @@ func BenchmarkTests(data benchmark.BenchData, scheme benchmark.SignatureScheme)
 					ProviderType: spenum.Authorizer,
 				}).Encode(),
 			},
+			{
+				name:     benchmark.ZcnSc + AutoCompoundFunc,
+				endpoint: sc.AutoCompound,
+				txn:      createTransaction(data.Clients[0], data.PublicKeys[0], 3000),
+				input: (&stakepool.AutoCompoundRequest{
+					ProviderID:   data.Clients[0],
+					ProviderType: spenum.Authorizer,
+					AutoCompound: true,
+				}).Encode(),
+			},
 		},
 	)
 }
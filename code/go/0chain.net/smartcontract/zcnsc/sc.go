@@ -28,6 +28,7 @@ const (
 	DeleteFromDelegatePoolFunc    = "delete-from-delegate-pool"
 	UpdateAuthorizerStakePoolFunc = "update-authorizer-stake-pool"
 	CollectRewardsFunc            = "collect-rewards"
+	AutoCompoundFunc              = "auto-compound"
 )
 
 // ZCNSmartContract ...
@@ -66,6 +67,7 @@ func (zcn *ZCNSmartContract) InitSC() {
 	zcn.smartContractFunctions[CollectRewardsFunc] = zcn.CollectRewards
 	zcn.smartContractFunctions[AddToDelegatePoolFunc] = zcn.AddToDelegatePool           // stakepool lock
 	zcn.smartContractFunctions[DeleteFromDelegatePoolFunc] = zcn.DeleteFromDelegatePool // stakepool unlock
+	zcn.smartContractFunctions[AutoCompoundFunc] = zcn.AutoCompound
 }
 
 // SetSC ...
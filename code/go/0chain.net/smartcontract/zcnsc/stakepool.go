@@ -196,6 +196,13 @@ func (zcn *ZCNSmartContract) AddToDelegatePool(t *transaction.Transaction,
 	}, zcn.getStakePoolAdapter)
 }
 
+func (zcn *ZCNSmartContract) AutoCompound(
+	t *transaction.Transaction, inputData []byte,
+	balances cstate.StateContextI) (resp string, err error) {
+
+	return stakepool.StakePoolAutoCompound(t, inputData, balances, zcn.getStakePoolAdapter)
+}
+
 func (zcn *ZCNSmartContract) DeleteFromDelegatePool(
 	t *transaction.Transaction, inputData []byte,
 	balances cstate.StateContextI) (resp string, err error) {
@@ -0,0 +1,89 @@
+package zcnsc
+
+import (
+	"encoding/json"
+
+	sci "0chain.net/chaincore/smartcontractinterface"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/provider"
+	"0chain.net/smartcontract/stakepool"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"0chain.net/smartcontract/supply"
+	"github.com/0chain/common/core/currency"
+)
+
+const supplyRecordsBatchSize = 1000
+
+func init() {
+	supply.RegisterContract(supply.Contract{
+		Name:      "zcn",
+		Address:   ADDRESS,
+		Providers: []spenum.Provider{spenum.Authorizer},
+		Records:   supplyRecords,
+	})
+	supply.RegisterNode(supply.NodeType{
+		Name:    "authorizer",
+		NewNode: func() supply.Node { return &AuthorizerNode{} },
+		Key:     provider.GetKey,
+	})
+	supply.RegisterNode(supply.NodeType{
+		Name:    "authorizer_stake_pool",
+		NewNode: func() supply.Node { return NewStakePool() },
+		Key:     func(id string) string { return stakepool.StakePoolKey(spenum.Authorizer, id) },
+		IDsOf:   "authorizer",
+	})
+}
+
+// SupplyEntries of an authorizer are empty, its tokens are in its stake
+// pool.
+func (an *AuthorizerNode) SupplyEntries() []supply.Entry {
+	return nil
+}
+
+func (an *AuthorizerNode) SupplyID() string {
+	if an.ProviderType != spenum.Authorizer {
+		return ""
+	}
+	return an.ID
+}
+
+// supplyRecords returns the tokens minted and burned through the bridge.
+// The minted amount is the one in the output of the mint transactions, it
+// is net of the authorizer fee which is paid as a reward.
+func supplyRecords(edb *event.EventDb, round int64) ([]supply.Entry, error) {
+	var minted, burned currency.Coin
+	err := edb.FindTransactionsToClient(ADDRESS, round, transaction.TxnSuccess, supplyRecordsBatchSize,
+		func(txns []event.Transaction) error {
+			for _, txn := range txns {
+				var data sci.SmartContractTransactionData
+				if err := json.Unmarshal([]byte(txn.TransactionData), &data); err != nil {
+					continue
+				}
+				switch data.FunctionName {
+				case MintFunc:
+					var payload MintPayload
+					if err := payload.Decode([]byte(txn.TransactionOutput)); err != nil {
+						return err
+					}
+					minted += payload.Amount
+				case BurnFunc:
+					burned += txn.Value
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	fees, err := edb.GetRewardsTotal(round, spenum.FeeRewardAuthorizer)
+	if err != nil {
+		return nil, err
+	}
+	return []supply.Entry{
+		{Category: supply.BridgeMints, Amount: minted},
+		{Category: supply.FeeRewards, Amount: fees},
+		{Category: supply.BridgeBurns, Amount: burned},
+	}, nil
+}